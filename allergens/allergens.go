@@ -0,0 +1,198 @@
+package allergens
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+	"unicode"
+
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// dictionaryData dictionnaire des 14 allergènes réglementés (règlement UE n° 1169/2011)
+// Pour ajouter un synonyme, il suffit de compléter dictionary.json
+//
+//go:embed dictionary.json
+var dictionaryData []byte
+
+// Allergen entrée du dictionnaire
+type Allergen struct {
+	ID         string   `json:"id"`
+	Label      string   `json:"label"`
+	Keywords   []string `json:"keywords"`   // expressions signalant l'allergène
+	Exclusions []string `json:"exclusions"` // expressions à ignorer ("coconut milk" pour le lait)
+	FreeFrom   []string `json:"free_from"`  // mentions écartant l'allergène sur toute la ligne ("dairy-free")
+}
+
+// Dictionary dictionnaire d'allergènes prêt à l'emploi (expressions découpées en mots au singulier)
+type Dictionary struct {
+	allergens []Allergen
+	compiled  []compiledAllergen
+}
+
+type compiledAllergen struct {
+	keywords   [][]string
+	exclusions [][]string
+	freeFrom   [][]string
+}
+
+// defaultDictionary dictionnaire chargé depuis dictionary.json
+var defaultDictionary = mustLoadDictionary(dictionaryData)
+
+// DefaultDictionary retourne le dictionnaire embarqué
+func DefaultDictionary() *Dictionary {
+	return defaultDictionary
+}
+
+// LoadDictionary construit un dictionnaire à partir de son contenu JSON
+func LoadDictionary(data []byte) (*Dictionary, error) {
+	var list []Allergen
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, err
+	}
+
+	dict := &Dictionary{allergens: list, compiled: make([]compiledAllergen, len(list))}
+	for i, allergen := range list {
+		dict.compiled[i] = compiledAllergen{
+			keywords:   compile(allergen.Keywords),
+			exclusions: compile(allergen.Exclusions),
+			freeFrom:   compile(allergen.FreeFrom),
+		}
+	}
+	return dict, nil
+}
+
+// mustLoadDictionary charge le dictionnaire embarqué (erreur de build si le JSON est invalide)
+func mustLoadDictionary(data []byte) *Dictionary {
+	dict, err := LoadDictionary(data)
+	if err != nil {
+		panic("allergens: dictionary.json invalide: " + err.Error())
+	}
+	return dict
+}
+
+// Allergens retourne les entrées du dictionnaire
+func (d *Dictionary) Allergens() []Allergen {
+	return d.allergens
+}
+
+// IDs retourne les identifiants des allergènes, dans l'ordre du dictionnaire
+func (d *Dictionary) IDs() []string {
+	ids := make([]string, 0, len(d.allergens))
+	for _, allergen := range d.allergens {
+		ids = append(ids, allergen.ID)
+	}
+	return ids
+}
+
+// Valid indique si l'identifiant correspond à un allergène du dictionnaire
+func (d *Dictionary) Valid(id string) bool {
+	for _, allergen := range d.allergens {
+		if allergen.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Detect retourne les allergènes présents dans les ingrédients, dans l'ordre du dictionnaire
+func (d *Dictionary) Detect(ings []models.Ingredient) []string {
+	found := make([]bool, len(d.allergens))
+	for _, ing := range ings {
+		tokens := tokenize(ingredients.Text(ing))
+		for i := range d.compiled {
+			if !found[i] && d.compiled[i].matches(tokens) {
+				found[i] = true
+			}
+		}
+	}
+
+	detected := []string{}
+	for i, allergen := range d.allergens {
+		if found[i] {
+			detected = append(detected, allergen.ID)
+		}
+	}
+	return detected
+}
+
+// DetectLine retourne les allergènes présents dans une ligne d'ingrédient
+func (d *Dictionary) DetectLine(line string) []string {
+	return d.Detect([]models.Ingredient{{Quantity: line}})
+}
+
+// matches indique si la ligne contient l'allergène
+// Un mot-clé couvert par une exclusion ("coconut milk") ou une ligne « sans » ("dairy-free") ne compte pas
+func (a compiledAllergen) matches(tokens []string) bool {
+	for _, phrase := range a.freeFrom {
+		if len(find(tokens, phrase)) > 0 {
+			return false
+		}
+	}
+
+	excluded := make([]bool, len(tokens))
+	for _, phrase := range a.exclusions {
+		for _, start := range find(tokens, phrase) {
+			for i := start; i < start+len(phrase); i++ {
+				excluded[i] = true
+			}
+		}
+	}
+
+	for _, phrase := range a.keywords {
+		for _, start := range find(tokens, phrase) {
+			covered := false
+			for i := start; i < start+len(phrase); i++ {
+				covered = covered || excluded[i]
+			}
+			if !covered {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// compile découpe les expressions du dictionnaire avec la même normalisation que les lignes
+func compile(phrases []string) [][]string {
+	compiled := make([][]string, 0, len(phrases))
+	for _, phrase := range phrases {
+		if tokens := tokenize(phrase); len(tokens) > 0 {
+			compiled = append(compiled, tokens)
+		}
+	}
+	return compiled
+}
+
+// find retourne les positions où l'expression apparaît dans la suite de mots
+func find(tokens, phrase []string) []int {
+	var positions []int
+	for start := 0; start+len(phrase) <= len(tokens); start++ {
+		found := true
+		for i, word := range phrase {
+			if tokens[start+i] != word {
+				found = false
+				break
+			}
+		}
+		if found {
+			positions = append(positions, start)
+		}
+	}
+	return positions
+}
+
+// tokenize découpe une ligne en mots au singulier (traits d'union et apostrophes conservés)
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-' && r != '\''
+	})
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.Trim(word, "-'"); word != "" {
+			tokens = append(tokens, ingredients.Singular(word))
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,67 @@
+package allergens
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDictionaryCoversEURegulation(t *testing.T) {
+	dict := DefaultDictionary()
+	require.Len(t, dict.IDs(), 14)
+	for _, id := range []string{"gluten", "crustaceans", "eggs", "fish", "peanuts", "soy", "milk", "nuts", "celery", "mustard", "sesame", "sulphites", "lupin", "molluscs"} {
+		assert.True(t, dict.Valid(id), id)
+	}
+	assert.False(t, dict.Valid("tomato"))
+}
+
+func TestDetectLine(t *testing.T) {
+	dict := DefaultDictionary()
+
+	tests := []struct {
+		line     string
+		expected []string
+	}{
+		{"2 cups all-purpose flour", []string{"gluten"}},
+		{"1 cup gluten-free flour", []string{}},
+		{"2 large eggs, beaten", []string{"eggs"}},
+		{"1 eggplant, cubed", []string{}},
+		{"1 (13.5 ounce) can coconut milk", []string{}},
+		{"1 cup soy milk", []string{"soy"}},
+		{"1 cup almond milk", []string{"nuts"}},
+		{"2 tablespoons peanut butter", []string{"peanuts"}},
+		{"1/2 cup unsalted butter, softened", []string{"milk"}},
+		{"1 teaspoon nutmeg", []string{}},
+		{"1/4 cup toasted pine nuts", []string{}},
+		{"1/2 cup chopped walnuts", []string{"nuts"}},
+		{"1 pound large shrimp, peeled", []string{"crustaceans"}},
+		{"1 tablespoon oyster sauce", []string{"molluscs"}},
+		{"2 stalks celery, diced", []string{"celery"}},
+		{"1 tablespoon Dijon mustard", []string{"mustard"}},
+		{"1 teaspoon toasted sesame oil", []string{"sesame"}},
+		{"1/2 cup dry white wine", []string{"sulphites"}},
+		{"2 tablespoons soy sauce", []string{"gluten", "soy"}},
+		{"1 teaspoon Worcestershire sauce", []string{"fish"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.expected, dict.DetectLine(tt.line))
+		})
+	}
+}
+
+func TestDetectRecipe(t *testing.T) {
+	detected := DefaultDictionary().Detect([]models.Ingredient{
+		{Quantity: "2 cups milk"},
+		{Quantity: "3 eggs"},
+		{Quantity: "1 cup flour"},
+		{Quantity: "1 cup cheddar cheese"},
+	})
+
+	// Chaque allergène n'apparaît qu'une fois, dans l'ordre du dictionnaire
+	assert.Equal(t, []string{"gluten", "eggs", "milk"}, detected)
+	assert.Equal(t, []string{}, DefaultDictionary().Detect(nil))
+}
@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Scopes disponibles pour les clés d'API
+const (
+	ScopeRead   = "read"   // Lecture des recettes
+	ScopeImport = "import" // Importation de recettes
+	ScopeScrape = "scrape" // Lancement du scraper
+	ScopeAdmin  = "admin"  // Administration (inclut tous les autres scopes)
+)
+
+// keyPrefix préfixe des clés générées, pour les reconnaître facilement
+const keyPrefix = "rk_"
+
+// ErrInvalidKey est retournée quand une clé est inconnue ou révoquée
+var ErrInvalidKey = errors.New("clé d'API invalide ou révoquée")
+
+// apiKeyCollection collection des clés d'API, ouverte par Open au démarrage
+var apiKeyCollection *mongo.Collection
+
+// Open désigne la base MongoDB où sont stockées les clés d'API
+// Appelé au démarrage, avant toute vérification de clé
+func Open(db *mongo.Database) {
+	apiKeyCollection = db.Collection("api_keys")
+}
+
+// ValidScope indique si le scope fait partie des scopes connus
+func ValidScope(scope string) bool {
+	switch scope {
+	case ScopeRead, ScopeImport, ScopeScrape, ScopeAdmin:
+		return true
+	}
+	return false
+}
+
+// HasScope indique si la clé possède le scope demandé (le scope admin donne tous les droits)
+func HasScope(key *models.APIKey, scope string) bool {
+	for _, s := range key.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// HashKey retourne le hash SHA-256 (hexadécimal) d'une clé en clair
+func HashKey(plain string) string {
+	sum := sha256.Sum256([]byte(plain))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateKey génère une nouvelle clé aléatoire en clair
+func generateKey() (string, error) {
+	bytes := make([]byte, 24)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return keyPrefix + hex.EncodeToString(bytes), nil
+}
+
+// displayPrefix retourne le début de la clé, affichable sans risque
+func displayPrefix(plain string) string {
+	if len(plain) < len(keyPrefix)+6 {
+		return plain
+	}
+	return plain[:len(keyPrefix)+6]
+}
+
+// CreateKey génère et enregistre une nouvelle clé d'API
+// Retourne la clé en clair (affichée une seule fois) et le document stocké
+func CreateKey(ctx context.Context, name string, scopes []string, rateLimit int) (string, *models.APIKey, error) {
+	plain, err := generateKey()
+	if err != nil {
+		return "", nil, err
+	}
+
+	key := &models.APIKey{
+		Name:      name,
+		Prefix:    displayPrefix(plain),
+		KeyHash:   HashKey(plain),
+		Scopes:    scopes,
+		RateLimit: rateLimit,
+		CreatedAt: time.Now(),
+	}
+
+	result, err := apiKeyCollection.InsertOne(ctx, key)
+	if err != nil {
+		return "", nil, err
+	}
+	key.ID = result.InsertedID.(primitive.ObjectID)
+
+	return plain, key, nil
+}
+
+// FindByKey retrouve une clé active à partir de sa valeur en clair
+func FindByKey(ctx context.Context, plain string) (*models.APIKey, error) {
+	var key models.APIKey
+	filter := bson.M{"key_hash": HashKey(plain), "revoked": false}
+	if err := apiKeyCollection.FindOne(ctx, filter).Decode(&key); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrInvalidKey
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListKeys retourne toutes les clés d'API (sans les hash)
+func ListKeys(ctx context.Context) ([]models.APIKey, error) {
+	cursor, err := apiKeyCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	keys := []models.APIKey{}
+	if err := cursor.All(ctx, &keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// GetKey retourne une clé d'API par son identifiant
+func GetKey(ctx context.Context, id primitive.ObjectID) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := apiKeyCollection.FindOne(ctx, bson.M{"_id": id}).Decode(&key); err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// RevokeKey révoque une clé d'API
+func RevokeKey(ctx context.Context, id primitive.ObjectID) error {
+	result, err := apiKeyCollection.UpdateOne(ctx, bson.M{"_id": id}, bson.M{"$set": bson.M{"revoked": true}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+	return nil
+}
+
+// RecordUsage incrémente les compteurs d'utilisation d'une clé
+// route: identifiant de la route appelée (ex: "GET /recettes")
+func RecordUsage(ctx context.Context, id primitive.ObjectID, route string) error {
+	update := bson.M{
+		"$inc": bson.M{
+			"request_count":  1,
+			"usage." + route: 1,
+		},
+		"$set": bson.M{"last_used_at": time.Now()},
+	}
+	_, err := apiKeyCollection.UpdateOne(ctx, bson.M{"_id": id}, update)
+	return err
+}
+
+// EnsureBootstrapKey enregistre la clé admin définie par ADMIN_API_KEY si elle n'existe pas encore
+// Permet de créer les premières clés via l'API sans accès direct à la base
+func EnsureBootstrapKey() {
+	plain := os.Getenv("ADMIN_API_KEY")
+	if plain == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := models.APIKey{
+		Name:      "bootstrap-admin",
+		Prefix:    displayPrefix(plain),
+		KeyHash:   HashKey(plain),
+		Scopes:    []string{ScopeAdmin},
+		CreatedAt: time.Now(),
+	}
+	filter := bson.M{"key_hash": key.KeyHash}
+	_, err := apiKeyCollection.UpdateOne(ctx, filter, bson.M{"$setOnInsert": key}, options.Update().SetUpsert(true))
+	if err != nil {
+		logger.LogError("Échec de l'enregistrement de la clé admin initiale", err, nil)
+		return
+	}
+
+	logger.LogInfo("Clé admin initiale disponible", map[string]interface{}{
+		"prefix": key.Prefix,
+	})
+}
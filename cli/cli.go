@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/controllers"
+	"github.com/maxime-louis14/api-golang/database"
+	"github.com/maxime-louis14/api-golang/importers"
+)
+
+// Command sous-commande exécutée à la place du serveur
+type Command struct {
+	Name        string
+	Description string
+	Run         func(args []string) int
+}
+
+// commands sous-commandes disponibles
+var commands = []Command{
+	{Name: "import", Description: "importe des recettes d'un format tiers", Run: runImport},
+	{Name: "migrate", Description: "applique, annule ou liste les migrations (up|down|status)", Run: runMigrate},
+}
+
+// Run exécute la sous-commande args[0] et retourne le code de sortie du processus
+func Run(args []string) int {
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(os.Stdout)
+		return 0
+	}
+	for _, command := range commands {
+		if command.Name == args[0] {
+			return command.Run(args[1:])
+		}
+	}
+	fmt.Fprintf(os.Stderr, "commande inconnue : %s\n\n", args[0])
+	usage(os.Stderr)
+	return 2
+}
+
+// usage liste les sous-commandes
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage : api-server [commande] [options]")
+	fmt.Fprintln(w, "Sans commande, le serveur démarre.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commandes :")
+	for _, command := range commands {
+		fmt.Fprintf(w, "  %-10s %s\n", command.Name, command.Description)
+	}
+}
+
+// runImport importe un ou plusieurs fichiers : import -format paprika recettes.paprikarecipes
+func runImport(args []string) int {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	format := flags.String("format", "", "format des fichiers ("+strings.Join(importers.Formats(), ", ")+")")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage : api-server import -format <format> <fichier>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if *format == "" || flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	client := database.DBinstance()
+	defer client.Disconnect(context.Background())
+	stores, err := controllers.NewStores(database.OpenDatabase(client))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "stockage des recettes invalide : %v\n", err)
+		return 1
+	}
+	recettes := controllers.NewRecetteHandler(stores)
+
+	status := 0
+	for _, path := range flags.Args() {
+		result, err := recettes.ImportFile(path, *format)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s : échec de l'importation : %v\n", path, err)
+			status = 1
+			continue
+		}
+		fmt.Printf("%s : %d recette(s) lue(s), %d ajoutée(s), %d modifiée(s), %d inchangée(s), %d en quarantaine\n",
+			path, result.Total, result.Inserted, result.Updated, result.Unchanged, result.Quarantined)
+		recettes.MirrorImportImages(result.RunID)
+	}
+	return status
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/database"
+	"github.com/maxime-louis14/api-golang/migrations"
+)
+
+// runMigrate applique, annule ou liste les migrations : migrate up [-to N], migrate down [-steps N], migrate status
+func runMigrate(args []string) int {
+	usage := "Usage : api-server migrate up [-to version] | down [-steps n] | status"
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	flags := flag.NewFlagSet("migrate "+args[0], flag.ContinueOnError)
+	target := flags.Int("to", 0, "dernière version à appliquer (toutes par défaut)")
+	steps := flags.Int("steps", 1, "nombre de migrations à annuler")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if *steps < 1 || *target < 0 {
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+
+	dbName := os.Getenv("DB_NAME")
+	if dbName == "" {
+		fmt.Fprintln(os.Stderr, "DB_NAME n'est pas défini")
+		return 1
+	}
+	client := database.DBinstance()
+	defer client.Disconnect(context.Background())
+	db := client.Database(dbName)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	switch args[0] {
+	case "up":
+		applied, err := migrations.Up(ctx, db, *target)
+		for _, record := range applied {
+			fmt.Printf("%04d %s : appliquée (%s)\n", record.Version, record.Name, record.Duration)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "échec des migrations : %v\n", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("Aucune migration en attente")
+		}
+	case "down":
+		reverted, err := migrations.Down(ctx, db, *steps)
+		for _, record := range reverted {
+			fmt.Printf("%04d %s : annulée (%s)\n", record.Version, record.Name, record.Duration)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "échec de l'annulation : %v\n", err)
+			return 1
+		}
+		if len(reverted) == 0 {
+			fmt.Println("Aucune migration à annuler")
+		}
+	case "status":
+		states, err := migrations.Status(ctx, db)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "échec de lecture des migrations : %v\n", err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNOM\tÉTAT\tAPPLIQUÉE LE")
+		for _, state := range states {
+			status, appliedAt := "en attente", ""
+			if state.Applied {
+				status = "appliquée"
+				appliedAt = state.AppliedAt.Local().Format("2006-01-02 15:04:05")
+			}
+			if state.Unknown {
+				status = "inconnue"
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", state.Version, state.Name, status, appliedAt)
+		}
+		w.Flush()
+	default:
+		fmt.Fprintln(os.Stderr, usage)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,153 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// CreateAPIKeyRequest corps de la requête de création de clé d'API
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit int      `json:"rate_limit"`
+}
+
+// CreateAPIKey génère une nouvelle clé d'API (la valeur en clair n'est retournée qu'une fois)
+func CreateAPIKey(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+
+	var req CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidBody)
+	}
+	if req.Name == "" {
+		return middleware.SendError(c, 400, i18n.KeyNameRequired)
+	}
+	if len(req.Scopes) == 0 {
+		req.Scopes = []string{auth.ScopeRead}
+	}
+	for _, scope := range req.Scopes {
+		if !auth.ValidScope(scope) {
+			return middleware.SendError(c, 400, i18n.UnknownScope, scope)
+		}
+	}
+	if req.RateLimit < 0 {
+		return middleware.SendError(c, 400, i18n.InvalidRateLimit)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	plain, key, err := auth.CreateKey(ctx, req.Name, req.Scopes, req.RateLimit)
+	if err != nil {
+		logger.LogError("Échec de création de la clé d'API", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.KeyCreateFailed)
+	}
+
+	logger.LogInfo("Clé d'API créée", map[string]interface{}{
+		"request_id": requestID,
+		"key_id":     key.ID.Hex(),
+		"prefix":     key.Prefix,
+		"scopes":     key.Scopes,
+	})
+
+	return c.Status(201).JSON(fiber.Map{
+		"key":     plain,
+		"api_key": key,
+	})
+}
+
+// GetAPIKeys liste les clés d'API avec leurs compteurs d'utilisation
+func GetAPIKeys(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	keys, err := auth.ListKeys(ctx)
+	if err != nil {
+		logger.LogError("Échec de récupération des clés d'API", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.KeysFetchFailed)
+	}
+
+	return c.Status(200).JSON(keys)
+}
+
+// GetAPIKeyUsage retourne les compteurs d'utilisation d'une clé d'API
+func GetAPIKeyUsage(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidKeyID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key, err := auth.GetKey(ctx, objID)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return middleware.SendError(c, 404, i18n.KeyNotFound)
+		}
+		logger.LogError("Échec de récupération de la clé d'API", err, map[string]interface{}{
+			"request_id": requestID,
+			"key_id":     id,
+		})
+		return middleware.SendError(c, 500, i18n.KeyFetchFailed)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"id":            key.ID,
+		"name":          key.Name,
+		"prefix":        key.Prefix,
+		"request_count": key.RequestCount,
+		"last_used_at":  key.LastUsedAt,
+		"usage":         key.Usage,
+	})
+}
+
+// RevokeAPIKey révoque une clé d'API
+func RevokeAPIKey(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidKeyID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := auth.RevokeKey(ctx, objID); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return middleware.SendError(c, 404, i18n.KeyNotFound)
+		}
+		logger.LogError("Échec de révocation de la clé d'API", err, map[string]interface{}{
+			"request_id": requestID,
+			"key_id":     id,
+		})
+		return middleware.SendError(c, 500, i18n.KeyRevokeFailed)
+	}
+
+	logger.LogInfo("Clé d'API révoquée", map[string]interface{}{
+		"request_id": requestID,
+		"key_id":     id,
+	})
+
+	return middleware.SendMessage(c, 200, i18n.KeyRevoked)
+}
@@ -0,0 +1,291 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/isoduration"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/responses"
+)
+
+// Opérations en masse disponibles
+const (
+	BulkDelete   = "delete"    // Mise à la corbeille
+	BulkSetField = "set_field" // Modification d'un champ
+	BulkAddTag   = "add_tag"   // Ajout d'une étiquette
+)
+
+// bulkSampleSize nombre d'identifiants d'exemple retournés
+const bulkSampleSize = 20
+
+// BulkFilter critères de sélection d'une opération en masse (au moins un critère obligatoire)
+type BulkFilter struct {
+	Category            string `json:"category"`
+	Ingredient          string `json:"ingredient"`           // texte recherché dans les lignes d'ingrédients
+	MissingInstructions bool   `json:"missing_instructions"` // recettes sans aucune étape
+	ImportRun           string `json:"import_run"`
+}
+
+// BulkRequest corps de la requête d'opération en masse
+type BulkRequest struct {
+	Filter    BulkFilter  `json:"filter"`
+	Operation string      `json:"operation"`
+	Field     string      `json:"field"` // set_field
+	Value     interface{} `json:"value"` // set_field
+	Tag       string      `json:"tag"`   // add_tag
+	DryRun    bool        `json:"dry_run"`
+}
+
+// bulkSetters champs modifiables par set_field
+var bulkSetters = map[string]func(recette *models.Recette, value interface{}) error{
+	"name": func(recette *models.Recette, value interface{}) error {
+		name, err := bulkString(value)
+		if err == nil && name == "" {
+			err = i18n.Errorf(i18n.ValueNameEmpty)
+		}
+		recette.Name = name
+		return err
+	},
+	"category": func(recette *models.Recette, value interface{}) error {
+		category, err := bulkString(value)
+		recette.Category = domain.NormalizeCategory(category)
+		return err
+	},
+	"image": func(recette *models.Recette, value interface{}) (err error) {
+		recette.Image, err = bulkString(value)
+		recette.ImageKey = ""
+		return err
+	},
+	"servings": func(recette *models.Recette, value interface{}) error {
+		number, ok := value.(float64)
+		if !ok || number < 0 || number != math.Trunc(number) {
+			return i18n.Errorf(i18n.ValuePositiveInteger)
+		}
+		recette.Servings = int(number)
+		return nil
+	},
+	"prep_time": func(recette *models.Recette, value interface{}) (err error) {
+		recette.PrepTime, recette.PrepMinutes, err = bulkDuration(value)
+		return err
+	},
+	"cook_time": func(recette *models.Recette, value interface{}) (err error) {
+		recette.CookTime, recette.CookMinutes, err = bulkDuration(value)
+		return err
+	},
+	"total_time": func(recette *models.Recette, value interface{}) (err error) {
+		recette.TotalTime, recette.TotalMinutes, err = bulkDuration(value)
+		return err
+	},
+}
+
+// bulkString convertit la valeur en texte
+func bulkString(value interface{}) (string, error) {
+	text, ok := value.(string)
+	if !ok {
+		return "", i18n.Errorf(i18n.ValueString)
+	}
+	return strings.TrimSpace(text), nil
+}
+
+// bulkDuration convertit la valeur en durée ISO-8601 normalisée ("" pour effacer la durée)
+func bulkDuration(value interface{}) (string, int, error) {
+	text, err := bulkString(value)
+	if err != nil || text == "" {
+		return "", 0, err
+	}
+	minutes, err := parseMinutes(text)
+	if err != nil {
+		return "", 0, i18n.Errorf(i18n.ValueDuration)
+	}
+	return isoduration.Format(time.Duration(minutes) * time.Minute), minutes, nil
+}
+
+// bulkQuery construit les critères d'une opération en masse (recettes hors corbeille)
+func bulkQuery(f BulkFilter) (repository.Query, error) {
+	query := repository.Query{
+		// Catégories normalisées comme pour la liste des recettes (minuscules, séparées par des virgules)
+		Categories:          splitQueryList(f.Category),
+		Ingredient:          strings.TrimSpace(f.Ingredient),
+		MissingInstructions: f.MissingInstructions,
+		ImportRun:           strings.TrimSpace(f.ImportRun),
+	}
+	if len(query.Categories) == 0 && query.Ingredient == "" && !query.MissingInstructions && query.ImportRun == "" {
+		return query, i18n.Errorf(i18n.BulkFilterRequired)
+	}
+	return query, nil
+}
+
+// validateBulkOperation vérifie l'opération demandée et ses paramètres
+func validateBulkOperation(req *BulkRequest) error {
+	switch req.Operation {
+	case BulkDelete:
+		return nil
+	case BulkSetField:
+		setter, ok := bulkSetters[req.Field]
+		if !ok {
+			names := make([]string, 0, len(bulkSetters))
+			for name := range bulkSetters {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return i18n.Errorf(i18n.BulkFieldNotEditable, req.Field, strings.Join(names, ", "))
+		}
+		// Valider la valeur une fois avant de l'appliquer à toutes les recettes
+		if err := setter(&models.Recette{}, req.Value); err != nil {
+			return i18n.Errorf(i18n.BulkInvalidValue, req.Field, err)
+		}
+		return nil
+	case BulkAddTag:
+		req.Tag = strings.ToLower(strings.TrimSpace(req.Tag))
+		if req.Tag == "" {
+			return i18n.Errorf(i18n.ParamRequired, "tag")
+		}
+		return nil
+	}
+	return i18n.Errorf(i18n.BulkUnknownOperation, req.Operation, strings.Join([]string{BulkDelete, BulkSetField, BulkAddTag}, ", "))
+}
+
+// BulkRecettes applique une opération (corbeille, modification d'un champ, étiquette)
+// à toutes les recettes correspondant au filtre ; dry_run retourne seulement le nombre de recettes concernées
+func (h *RecetteHandler) BulkRecettes(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+
+	var req BulkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidBody)
+	}
+	query, err := bulkQuery(req.Filter)
+	if err != nil {
+		return middleware.SendErr(c, 400, err)
+	}
+	if err := validateBulkOperation(&req); err != nil {
+		return middleware.SendErr(c, 400, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	result := responses.BulkResult{Operation: req.Operation, DryRun: req.DryRun, SampleIDs: []string{}}
+	selected, err := h.recettes.List(ctx, query)
+	if err != nil {
+		logger.LogError("Échec de sélection des recettes", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.BulkSelectFailed)
+	}
+	result.Matched = int64(len(selected))
+
+	ids := make([]string, 0, len(selected))
+	for _, recette := range selected {
+		ids = append(ids, recette.ID.Hex())
+	}
+	sort.Strings(ids)
+	result.SampleIDs = append(result.SampleIDs, ids[:min(len(ids), bulkSampleSize)]...)
+
+	if !req.DryRun && result.Matched > 0 {
+		if req.Operation == BulkDelete {
+			result.Modified, err = h.applyBulkDelete(ctx, selected, requestAuthor(c))
+		} else {
+			result.Modified, err = h.applyBulkEdit(ctx, selected, req, revisionInfo{
+				source: models.RevisionSourceManual,
+				author: requestAuthor(c),
+				runID:  requestID,
+			})
+		}
+		if err != nil {
+			logger.LogError("Échec de l'opération en masse", err, map[string]interface{}{
+				"request_id": requestID,
+				"operation":  req.Operation,
+				"modified":   result.Modified,
+			})
+			return middleware.SendError(c, 500, i18n.BulkFailed)
+		}
+
+		if err := h.RefreshIndexes(); err != nil {
+			logger.LogError("Échec de reconstruction des index après opération en masse", err, map[string]interface{}{
+				"request_id": requestID,
+			})
+		}
+	}
+
+	logger.LogDatabase(logger.INFO, "Opération en masse terminée", "bulk_"+req.Operation, "mongodb", time.Since(start), map[string]interface{}{
+		"request_id": requestID,
+		"author":     requestAuthor(c),
+		"dry_run":    req.DryRun,
+		"matched":    result.Matched,
+		"modified":   result.Modified,
+	})
+
+	return c.Status(200).JSON(result)
+}
+
+// applyBulkDelete place les recettes sélectionnées dans la corbeille et publie un événement par recette
+func (h *RecetteHandler) applyBulkDelete(ctx context.Context, selected []models.Recette, author string) (int64, error) {
+	var deleted int64
+	for _, recette := range selected {
+		err := h.recettes.Delete(ctx, recette.ID)
+		if errors.Is(err, repository.ErrNotFound) {
+			// Déjà supprimée depuis la sélection
+			continue
+		}
+		if err != nil {
+			return deleted, err
+		}
+		deleted++
+		h.publishRecetteEvent(events.RecetteDeleted, models.Recette{ID: recette.ID}, author)
+	}
+	return deleted, nil
+}
+
+// applyBulkEdit modifie une à une les recettes sélectionnées pour conserver leur historique
+func (h *RecetteHandler) applyBulkEdit(ctx context.Context, selected []models.Recette, req BulkRequest, info revisionInfo) (int64, error) {
+	var modified int64
+	for _, previous := range selected {
+		previous := previous
+		recette := previous
+		recette.Tags = append([]string(nil), previous.Tags...)
+		switch req.Operation {
+		case BulkSetField:
+			if err := bulkSetters[req.Field](&recette, req.Value); err != nil {
+				return modified, err
+			}
+		case BulkAddTag:
+			if containsString(recette.Tags, req.Tag) {
+				continue
+			}
+			recette.Tags = append(recette.Tags, req.Tag)
+		}
+
+		changed, err := h.saveRecette(ctx, &previous, &recette, info)
+		if err != nil {
+			return modified, err
+		}
+		if changed {
+			modified++
+		}
+	}
+	return modified, nil
+}
+
+// containsString indique si la valeur est présente dans la liste
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Une catégorie modifiée en masse doit rester trouvée par le filtre category=
+func TestBulkSetCategoryMatchesFilter(t *testing.T) {
+	var recette models.Recette
+	require.NoError(t, bulkSetters["category"](&recette, "  Soup "))
+	assert.Equal(t, "soup", recette.Category)
+
+	query := repository.Query{Categories: splitQueryList("soup")}
+	assert.True(t, query.Match(&recette))
+}
@@ -0,0 +1,227 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/duplicates"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/responses"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxDuplicatePairs nombre maximal de paires retournées par GET /duplicates
+const maxDuplicatePairs = 200
+
+// MergeRequest recette conservée et recettes fusionnées dans celle-ci
+type MergeRequest struct {
+	CanonicalID  string   `json:"canonical_id"`
+	DuplicateIDs []string `json:"duplicate_ids"`
+}
+
+// loadAliasPages retourne les URL canoniques des pages fusionnées dans une autre recette
+func (h *RecetteHandler) loadAliasPages(ctx context.Context) (map[string]bool, error) {
+	pages, err := h.aliases.Pages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]bool, len(pages))
+	for _, page := range pages {
+		result[duplicates.CanonicalURL(page)] = true
+	}
+	return result, nil
+}
+
+// GetDuplicates retourne les paires de recettes probablement identiques (même URL canonique,
+// même titre normalisé ou mêmes ingrédients), les plus probables en premier
+// reason=canonical_url|title|ingredients ne retient que les paires ayant cette raison, limit (200 max) borne la réponse
+func (h *RecetteHandler) GetDuplicates(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > maxDuplicatePairs {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "limit", 1, maxDuplicatePairs)
+	}
+	reason := c.Query("reason")
+	switch reason {
+	case "", duplicates.ReasonURL, duplicates.ReasonTitle, duplicates.ReasonIngredients:
+	default:
+		return middleware.SendError(c, 400, i18n.UnknownReason, reason)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	recettes, err := h.recettes.List(ctx, repository.Query{})
+	if err != nil {
+		logger.LogError("Échec de chargement des recettes pour le dédoublonnage", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.DuplicatesFailed)
+	}
+
+	entries := make([]duplicates.Entry, 0, len(recettes))
+	for _, recette := range recettes {
+		names := recette.IngredientNames
+		if names == nil {
+			names = ingredients.Names(recette.Ingredients)
+		}
+		entries = append(entries, duplicates.Entry{
+			ID:          recette.ID.Hex(),
+			Name:        recette.Name,
+			Page:        recette.Page,
+			Ingredients: names,
+		})
+	}
+
+	pairs := []duplicates.Pair{}
+	for _, pair := range duplicates.Find(entries) {
+		if reason == "" || containsString(pair.Reasons, reason) {
+			pairs = append(pairs, pair)
+		}
+	}
+	total := len(pairs)
+	if len(pairs) > limit {
+		pairs = pairs[:limit]
+	}
+
+	logger.LogDatabase(logger.INFO, "Recherche des doublons terminée", "find_duplicates", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id":     requestID,
+		"recettes_count": len(recettes),
+		"pairs_count":    total,
+	})
+
+	return c.Status(200).JSON(responses.DuplicateCandidates{Total: total, Pairs: pairs})
+}
+
+// MergeDuplicates fusionne des doublons dans une recette canonique : les doublons sont supprimés,
+// leurs pages et étiquettes rattachées à la recette canonique et leurs identifiants enregistrés comme alias
+func (h *RecetteHandler) MergeDuplicates(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+
+	var req MergeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidBody)
+	}
+	canonicalID, err := primitive.ObjectIDFromHex(req.CanonicalID)
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidCanonicalID)
+	}
+	if len(req.DuplicateIDs) == 0 {
+		return middleware.SendError(c, 400, i18n.DuplicateRequired)
+	}
+	duplicateIDs := make([]primitive.ObjectID, 0, len(req.DuplicateIDs))
+	for _, id := range uniqueStrings(req.DuplicateIDs) {
+		objID, err := primitive.ObjectIDFromHex(id)
+		if err != nil {
+			return middleware.SendError(c, 400, i18n.InvalidDuplicateID, id)
+		}
+		if objID == canonicalID {
+			return middleware.SendError(c, 400, i18n.CanonicalSelfDuplicate)
+		}
+		duplicateIDs = append(duplicateIDs, objID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	canonical, err := h.recettes.Get(ctx, canonicalID)
+	if err != nil {
+		return middleware.SendError(c, 404, i18n.CanonicalNotFound)
+	}
+	merged := make([]models.Recette, 0, len(duplicateIDs))
+	for _, id := range duplicateIDs {
+		recette, err := h.recettes.Get(ctx, id)
+		if errors.Is(err, repository.ErrNotFound) {
+			return middleware.SendError(c, 404, i18n.DuplicateNotFound)
+		}
+		if err != nil {
+			logger.LogError("Échec de chargement des doublons", err, map[string]interface{}{
+				"request_id": requestID,
+			})
+			return middleware.SendError(c, 500, i18n.MergeFailed)
+		}
+		merged = append(merged, *recette)
+	}
+
+	author := requestAuthor(c)
+	now := time.Now().UTC()
+	aliases := make([]models.RecetteAlias, 0, len(merged))
+	pages := []string{}
+	tags := []string{}
+	for _, recette := range merged {
+		alias := models.RecetteAlias{
+			ID:        recette.ID,
+			RecetteID: canonicalID,
+			Name:      recette.Name,
+			Page:      recette.Page,
+			Author:    author,
+			MergedAt:  now,
+		}
+		aliases = append(aliases, alias)
+		if recette.Page != "" && recette.Page != canonical.Page {
+			pages = append(pages, recette.Page)
+		}
+		// Les doublons déjà fusionnés dans une recette fusionnée suivent la recette canonique
+		pages = append(pages, recette.Aliases...)
+		tags = append(tags, recette.Tags...)
+	}
+
+	if err := h.aliases.Add(ctx, aliases); err != nil {
+		logger.LogError("Échec d'enregistrement des alias", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.MergeFailed)
+	}
+	if err := h.aliases.Reassign(ctx, duplicateIDs, canonicalID); err != nil {
+		logger.LogError("Échec de rattachement des alias existants", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.MergeFailed)
+	}
+
+	canonical.Aliases = uniqueStrings(append(canonical.Aliases, pages...))
+	canonical.Tags = uniqueStrings(append(canonical.Tags, tags...))
+	if err := h.recettes.Upsert(ctx, canonical); err != nil {
+		logger.LogError("Échec de mise à jour de la recette canonique", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  canonicalID.Hex(),
+		})
+		return middleware.SendError(c, 500, i18n.MergeFailed)
+	}
+	if err := h.recettes.Remove(ctx, duplicateIDs); err != nil {
+		logger.LogError("Échec de suppression des doublons", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.MergeFailed)
+	}
+
+	for _, recette := range merged {
+		h.publishRecetteEvent(events.RecetteDeleted, recette, author)
+	}
+	h.publishRecetteEvent(events.RecetteUpdated, *canonical, author)
+
+	logger.LogDatabase(logger.INFO, "Doublons fusionnés", "merge_duplicates", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id":   requestID,
+		"recipe_id":    canonicalID.Hex(),
+		"merged_count": len(merged),
+		"author":       author,
+	})
+
+	if err := h.RefreshIndexes(); err != nil {
+		logger.LogError("Échec de reconstruction des index après fusion", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+	}
+
+	return c.Status(200).JSON(responses.MergeResult{Recette: *canonical, Aliases: aliases})
+}
@@ -0,0 +1,152 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// sseHeartbeat intervalle des commentaires envoyés pour garder la connexion ouverte
+const sseHeartbeat = 15 * time.Second
+
+// RecetteEvent contenu des événements recette.*
+type RecetteEvent struct {
+	ID     string `json:"id"`
+	Name   string `json:"name,omitempty"`
+	Author string `json:"author,omitempty"`
+}
+
+// RunEvent contenu des événements import.* et scrape.*
+type RunEvent struct {
+	RunID       string `json:"run_id"`
+	Total       int    `json:"total,omitempty"`
+	Processed   int    `json:"processed,omitempty"`
+	Inserted    int    `json:"inserted,omitempty"`
+	Updated     int    `json:"updated,omitempty"`
+	Unchanged   int    `json:"unchanged,omitempty"`
+	Quarantined int    `json:"quarantined,omitempty"`
+	Failed      int    `json:"failed,omitempty"`
+	Recipe      string `json:"recipe,omitempty"`
+	Duration    string `json:"duration,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// publishRecetteEvent publie un événement concernant une recette
+func (h *RecetteHandler) publishRecetteEvent(eventType string, recette models.Recette, author string) {
+	h.events.Publish(eventType, RecetteEvent{ID: recette.ID.Hex(), Name: recette.Name, Author: author})
+}
+
+// EventResync événement envoyé quand les événements manqués ne peuvent pas être rejoués
+// (redémarrage de l'API, événements plus conservés) : le client doit recharger l'état complet
+const EventResync = "resync"
+
+// ResyncEvent contenu de l'événement resync
+type ResyncEvent struct {
+	LastEventID string `json:"last_event_id"`
+}
+
+// GetEvents diffuse les événements en Server-Sent Events
+// types=recette,import.progress : filtre par type ou par famille de types
+// L'en-tête Last-Event-ID (ou le paramètre last_event_id) rejoue les événements manqués encore conservés,
+// ou envoie un événement resync si la reprise est impossible
+func (h *RecetteHandler) GetEvents(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	types := splitQueryList(c.Query("types"))
+
+	lastEventID := c.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var lastID uint64
+	current := true
+	if lastEventID != "" {
+		var err error
+		if lastID, current, err = h.events.ParseCursor(lastEventID); err != nil {
+			return middleware.SendError(c, 400, i18n.InvalidLastEventID, lastEventID)
+		}
+	}
+
+	var sub *events.Subscription
+	var replay []events.Event
+	complete := false
+	if current {
+		sub, replay, complete = h.events.Subscribe(types, lastID)
+	} else {
+		// Identifiant d'un processus précédent : ses numéros ne correspondent pas à ceux de ce bus
+		sub, _, _ = h.events.Subscribe(types, 0)
+	}
+
+	logger.LogInfo("Abonnement aux événements", map[string]interface{}{
+		"request_id":    requestID,
+		"types":         types,
+		"last_event_id": lastEventID,
+		"replayed":      len(replay),
+		"resync":        !complete,
+	})
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer sub.Close()
+
+		// Délai de reconnexion conseillé au client
+		fmt.Fprint(w, "retry: 3000\n\n")
+		if !complete {
+			data, _ := json.Marshal(ResyncEvent{LastEventID: h.events.Cursor(sub.Start)})
+			fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", h.events.Cursor(sub.Start), EventResync, data)
+		}
+		for _, event := range replay {
+			if h.writeEvent(w, event) != nil {
+				return
+			}
+		}
+		if w.Flush() != nil {
+			return
+		}
+
+		heartbeat := time.NewTicker(sseHeartbeat)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case event, ok := <-sub.C:
+				if !ok {
+					// Abonné trop lent : le client se reconnecte avec Last-Event-ID
+					return
+				}
+				if h.writeEvent(w, event) != nil {
+					return
+				}
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+			}
+			if w.Flush() != nil {
+				logger.LogInfo("Fin de l'abonnement aux événements", map[string]interface{}{
+					"request_id": requestID,
+				})
+				return
+			}
+		}
+	})
+	return nil
+}
+
+// writeEvent écrit un événement au format SSE
+func (h *RecetteHandler) writeEvent(w *bufio.Writer, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", h.events.Cursor(event.ID), event.Type, data)
+	return err
+}
@@ -0,0 +1,223 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/images"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// openImageStore ouvre le stockage d'images configuré par l'environnement (nil si IMAGE_STORE_BACKEND est invalide)
+func openImageStore() images.Store {
+	store, err := images.OpenFromEnv()
+	if err != nil {
+		logger.LogError("Stockage d'images indisponible", err, nil)
+		return nil
+	}
+	return store
+}
+
+// GetRecetteImage sert l'image d'une recette depuis le stockage local
+// ?w=300 retourne une miniature de 512 pixels de large (largeur arrondie à l'une des images.Widths,
+// générée puis mise en cache)
+// Seules les images déjà copiées (par le scraper ou après une importation) sont servies :
+// l'URL d'origine n'est jamais téléchargée à la demande
+func (h *RecetteHandler) GetRecetteImage(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	width := c.QueryInt("w", 0)
+	if width != 0 && (width < images.MinWidth || width > images.MaxWidth) {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "w", images.MinWidth, images.MaxWidth)
+	}
+	if width != 0 {
+		width = images.VariantWidth(width)
+	}
+
+	if h.images == nil {
+		return middleware.SendError(c, 503, i18n.ImageStoreUnavailable)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	recette, err := h.recettes.Get(ctx, objID)
+	if err != nil {
+		logger.LogError("Recette introuvable", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 404, i18n.RecipeNotFound)
+	}
+
+	key := recette.ImageKey
+	if key == "" {
+		if recette.Image == "" {
+			return middleware.SendError(c, 404, i18n.RecipeWithoutImage)
+		}
+		return middleware.SendError(c, 404, i18n.ImageNotMirrored)
+	}
+
+	// Le contenu d'une clé ne change jamais : la réponse peut être mise en cache indéfiniment
+	name := key
+	if width > 0 {
+		name = images.VariantName(key, width)
+	}
+	etag := `"` + key + "/" + name + `"`
+	if width == 0 {
+		etag = `"` + key + `"`
+	}
+	c.Set(fiber.HeaderCacheControl, "public, max-age=31536000, immutable")
+	c.Set(fiber.HeaderETag, etag)
+	if c.Get(fiber.HeaderIfNoneMatch) == etag {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
+
+	data, err := h.loadImage(ctx, key, width)
+	if err != nil {
+		logger.LogError("Échec de lecture de l'image", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+			"image_key":  key,
+		})
+		if errors.Is(err, images.ErrNotFound) {
+			return middleware.SendError(c, 404, i18n.ImageNotFound)
+		}
+		if errors.Is(err, images.ErrTooLarge) {
+			return middleware.SendError(c, 422, i18n.ImageTooLarge)
+		}
+		return middleware.SendError(c, 500, i18n.ImageReadFailed)
+	}
+
+	logger.LogInfo("Image de recette servie", map[string]interface{}{
+		"request_id":  requestID,
+		"recipe_id":   id,
+		"image_key":   key,
+		"width":       width,
+		"size":        len(data),
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+
+	c.Set(fiber.HeaderContentType, images.ContentType(name))
+	return c.Status(200).Send(data)
+}
+
+// loadImage retourne l'image originale ou sa miniature (générée et mise en cache si absente)
+func (h *RecetteHandler) loadImage(ctx context.Context, key string, width int) ([]byte, error) {
+	if width == 0 {
+		return h.images.Get(ctx, key)
+	}
+
+	variant := images.VariantName(key, width)
+	data, err := h.images.GetVariant(ctx, key, variant)
+	if err == nil || !errors.Is(err, images.ErrNotFound) {
+		return data, err
+	}
+
+	original, err := h.images.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err = images.Resize(original, key, width)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.images.PutVariant(ctx, key, variant, data); err != nil {
+		// La miniature reste servie même si elle n'a pas pu être mise en cache
+		logger.LogError("Échec de mise en cache de la miniature", err, map[string]interface{}{
+			"image_key": key,
+			"variant":   variant,
+		})
+	}
+	return data, nil
+}
+
+// MirrorImportImages copie les images pas encore copiées des recettes d'une importation
+// Les URL internes (boucle locale, réseau privé...) sont refusées par images.Download
+func (h *RecetteHandler) MirrorImportImages(runID string) {
+	if h.images == nil {
+		return
+	}
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	recettes, err := h.recettes.List(ctx, repository.Query{ImportRun: runID})
+	if err != nil {
+		logger.LogError("Échec de chargement des recettes importées pour la copie des images", err, map[string]interface{}{
+			"run_id": runID,
+		})
+		return
+	}
+	mirrored, failed := 0, 0
+	for i := range recettes {
+		if recettes[i].Image == "" || recettes[i].ImageKey != "" {
+			continue
+		}
+		if _, err := h.mirrorRecetteImage(ctx, &recettes[i]); err != nil {
+			failed++
+			logger.LogError("Échec de copie de l'image", err, map[string]interface{}{
+				"run_id":    runID,
+				"recipe_id": recettes[i].ID.Hex(),
+				"image_url": recettes[i].Image,
+			})
+			continue
+		}
+		mirrored++
+	}
+	logger.LogInfo("Images des recettes importées copiées", map[string]interface{}{
+		"run_id":         runID,
+		"mirrored_count": mirrored,
+		"failed_count":   failed,
+		"duration_ms":    time.Since(start).Milliseconds(),
+	})
+}
+
+// mirrorRecetteImage copie l'image distante d'une recette et enregistre sa clé
+func (h *RecetteHandler) mirrorRecetteImage(ctx context.Context, recette *models.Recette) (string, error) {
+	key, err := images.Mirror(ctx, h.images, nil, recette.Image)
+	if err != nil {
+		return "", err
+	}
+
+	recette.ImageKey = key
+	if err := h.saveImageKey(ctx, recette.ID, recette.Image, key); err != nil {
+		// L'image est copiée ; la clé sera enregistrée à la prochaine copie
+		logger.LogError("Échec d'enregistrement de la clé d'image", err, map[string]interface{}{
+			"recipe_id": recette.ID.Hex(),
+		})
+	}
+	return key, nil
+}
+
+// saveImageKey enregistre la clé de l'image copiée sur la version courante de la recette,
+// sauf si son image a changé entre-temps
+func (h *RecetteHandler) saveImageKey(ctx context.Context, id primitive.ObjectID, image, key string) error {
+	current, err := h.recettes.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if current.Image != image || current.ImageKey == key {
+		return nil
+	}
+	current.ImageKey = key
+	return h.recettes.Upsert(ctx, current)
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/images"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRecetteImageFromInjectedStore(t *testing.T) {
+	ctx := context.Background()
+	stores := repository.NewMemoryStores()
+	store, err := images.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	stores.Images = store
+
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 300, 150))))
+	key, err := store.Put(ctx, buf.Bytes())
+	require.NoError(t, err)
+	recette := &models.Recette{Recipe: domain.Recipe{Name: "Gratin", Image: "https://example.com/gratin.png", ImageKey: key}}
+	require.NoError(t, stores.Recettes.Upsert(ctx, recette))
+
+	app := fiber.New()
+	app.Use(middleware.LoggingMiddleware())
+	app.Get("/recette/:id/image", NewRecetteHandler(stores).GetRecetteImage)
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recette/"+recette.ID.Hex()+"/image?w=100", nil), -1)
+	require.NoError(t, err)
+	require.Equal(t, 200, resp.StatusCode)
+	img, _, err := image.Decode(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 128, img.Bounds().Dx())
+
+	// Sans stockage d'images injecté, les images ne sont pas servies
+	app = fiber.New()
+	app.Use(middleware.LoggingMiddleware())
+	app.Get("/recette/:id/image", NewRecetteHandler(repository.Stores{Recettes: stores.Recettes}).GetRecetteImage)
+	resp, err = app.Test(httptest.NewRequest("GET", "/recette/"+recette.ID.Hex()+"/image", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, 503, resp.StatusCode)
+}
+
+func TestGetRecetteImageNeverDownloads(t *testing.T) {
+	ctx := context.Background()
+	downloads := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		var buf bytes.Buffer
+		png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 8, 8)))
+		w.Write(buf.Bytes())
+	}))
+	defer server.Close()
+
+	stores := repository.NewMemoryStores()
+	store, err := images.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	stores.Images = store
+	recette := &models.Recette{Recipe: domain.Recipe{Name: "Gratin", Image: server.URL + "/gratin.png"}, ImportRun: "run-1"}
+	require.NoError(t, stores.Recettes.Upsert(ctx, recette))
+
+	handler := NewRecetteHandler(stores)
+	app := fiber.New()
+	app.Use(middleware.LoggingMiddleware())
+	app.Get("/recette/:id/image", handler.GetRecetteImage)
+
+	// Une image pas encore copiée n'est pas téléchargée au moment de la demande
+	resp, err := app.Test(httptest.NewRequest("GET", "/recette/"+recette.ID.Hex()+"/image", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, 404, resp.StatusCode)
+
+	// La copie après importation refuse les adresses internes
+	handler.MirrorImportImages("run-1")
+	stored, err := stores.Recettes.Get(ctx, recette.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stored.ImageKey)
+	assert.Zero(t, downloads)
+}
@@ -0,0 +1,268 @@
+package controllers
+
+import (
+	"context"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/duplicates"
+	"github.com/maxime-louis14/api-golang/enrich"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/importers"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/responses"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// importProgressStep nombre de recettes traitées entre deux événements import.progress
+const importProgressStep = 25
+
+// importRecettes enregistre un lot de recettes importées, par paquets de importProgressStep
+// Une recette déjà importée (même page) est remplacée et sa version précédente conservée dans l'historique,
+// une page fusionnée dans une autre recette n'est pas réimportée et une recette ne respectant pas
+// les règles de qualité est placée en quarantaine au lieu d'être enregistrée
+func (h *RecetteHandler) importRecettes(ctx context.Context, recettes []models.Recette, info revisionInfo) (responses.ImportResult, error) {
+	result := responses.ImportResult{RunID: info.runID, Total: len(recettes)}
+
+	aliasPages, err := h.loadAliasPages(ctx)
+	if err != nil {
+		logger.LogError("Échec de chargement des alias de recettes", err, map[string]interface{}{
+			"run_id": info.runID,
+		})
+		return result, err
+	}
+
+	var validPages []string
+	for offset := 0; offset < len(recettes); offset += importProgressStep {
+		batch := recettes[offset:min(offset+importProgressStep, len(recettes))]
+
+		var valid []models.Recette
+		for _, recette := range batch {
+			recette.ImportRun = info.runID
+
+			if aliasPages[duplicates.CanonicalURL(recette.Page)] {
+				// Doublon déjà fusionné : la recette canonique reste inchangée
+				result.Unchanged++
+			} else if issues := qualityRules.Validate(recette); len(issues) > 0 {
+				if err := h.quarantineRecette(ctx, recette, issues, info.runID); err != nil {
+					logger.LogError("Échec de mise en quarantaine d'une recette", err, map[string]interface{}{
+						"run_id":  info.runID,
+						"recette": recette.Name,
+					})
+					return result, err
+				}
+				result.Quarantined++
+			} else {
+				// Régimes et allergènes recalculés à chaque importation
+				enrich.Recette(&recette)
+				valid = append(valid, recette)
+			}
+		}
+
+		imported, err := h.recettes.BulkImport(ctx, valid)
+		if err != nil {
+			logger.LogError("Échec d'insertion des recettes", err, map[string]interface{}{
+				"run_id":         info.runID,
+				"recettes_count": len(valid),
+			})
+			return result, err
+		}
+		for _, item := range imported {
+			switch item.Status {
+			case repository.ImportInserted:
+				result.Inserted++
+			case repository.ImportUpdated:
+				result.Updated++
+			default:
+				result.Unchanged++
+			}
+			validPages = append(validPages, item.Recette.Page)
+			if item.Status == repository.ImportUnchanged {
+				continue
+			}
+			if err := h.recordChange(ctx, item.Previous, item.Recette, info); err != nil {
+				logger.LogError("Échec d'enregistrement de la révision d'une recette importée", err, map[string]interface{}{
+					"run_id":  info.runID,
+					"recette": item.Recette.Name,
+				})
+				return result, err
+			}
+		}
+
+		if processed := offset + len(batch); processed%importProgressStep == 0 {
+			h.events.Publish(events.ImportProgress, RunEvent{
+				RunID:       info.runID,
+				Total:       result.Total,
+				Processed:   processed,
+				Inserted:    result.Inserted,
+				Updated:     result.Updated,
+				Unchanged:   result.Unchanged,
+				Quarantined: result.Quarantined,
+			})
+		}
+	}
+
+	// Les recettes désormais valides sortent de la quarantaine
+	if err := h.quarantine.Release(ctx, validPages); err != nil {
+		logger.LogError("Échec de sortie de quarantaine des recettes valides", err, map[string]interface{}{
+			"run_id": info.runID,
+		})
+	}
+	return result, nil
+}
+
+// finishImport journalise une importation terminée, reconstruit les index en mémoire
+// et les statistiques du corpus puis annonce la fin de l'importation
+func (h *RecetteHandler) finishImport(result responses.ImportResult, duration time.Duration) {
+	logger.LogDatabase(logger.INFO, "Importation des recettes terminée", "batch_insert", "mongodb", duration, map[string]interface{}{
+		"request_id":        result.RunID,
+		"format":            result.Format,
+		"recettes_count":    result.Inserted,
+		"updated_count":     result.Updated,
+		"unchanged_count":   result.Unchanged,
+		"quarantined_count": result.Quarantined,
+	})
+
+	// Reconstruire les index en mémoire (similarité, autocomplétion) avec les nouvelles recettes
+	if err := h.RefreshIndexes(); err != nil {
+		logger.LogError("Échec de reconstruction des index après importation", err, map[string]interface{}{
+			"request_id": result.RunID,
+		})
+	}
+
+	// Recalcul des statistiques du corpus en arrière-plan (archivées pour suivre son évolution)
+	go h.RefreshRecetteStats()
+
+	h.events.Publish(events.ImportCompleted, RunEvent{
+		RunID:       result.RunID,
+		Total:       result.Total,
+		Processed:   result.Total,
+		Inserted:    result.Inserted,
+		Updated:     result.Updated,
+		Unchanged:   result.Unchanged,
+		Quarantined: result.Quarantined,
+		Duration:    duration.String(),
+	})
+}
+
+// ImportRecettes importe des recettes d'un format tiers (format=jsonld|paprika|mealmaster)
+// Le fichier est envoyé comme corps de la requête ou dans le champ "file" d'un formulaire multipart
+func (h *RecetteHandler) ImportRecettes(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+
+	format := c.Query("format")
+	if format == "" {
+		return middleware.SendError(c, 400, i18n.ParamRequired, "format")
+	}
+	importer, err := importers.Get(format)
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.UnknownImportFormat, format, strings.Join(importers.Formats(), ", "))
+	}
+	data, err := importData(c)
+	if err != nil {
+		logger.LogError("Échec de lecture du fichier importé", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidBody)
+	}
+	if len(data) == 0 {
+		return middleware.SendError(c, 400, i18n.ImportBodyRequired)
+	}
+
+	logger.LogInfo("Début de l'importation des recettes", map[string]interface{}{
+		"request_id": requestID,
+		"format":     format,
+		"size":       len(data),
+	})
+	h.events.Publish(events.ImportStarted, RunEvent{RunID: requestID})
+
+	// fail signale l'échec de l'importation aux abonnés (message dans la langue par défaut) et au client
+	fail := func(status int, code i18n.Code, args ...interface{}) error {
+		h.events.Publish(events.ImportFailed, RunEvent{RunID: requestID, Error: i18n.Message(i18n.DefaultLanguage, code, args...)})
+		return middleware.SendError(c, status, code, args...)
+	}
+
+	recettes, err := importer.Import(data)
+	if err != nil {
+		logger.LogError("Échec de conversion du fichier importé", err, map[string]interface{}{
+			"request_id": requestID,
+			"format":     format,
+		})
+		return fail(400, i18n.ImportParseFailed, format)
+	}
+
+	info := revisionInfo{source: models.RevisionSourceImport, author: requestAuthor(c), runID: requestID}
+	result, err := h.importRecettes(context.Background(), recettes, info)
+	if err != nil {
+		return fail(500, i18n.ImportInsertFailed)
+	}
+	result.Format = format
+	h.finishImport(result, time.Since(start))
+	go h.MirrorImportImages(result.RunID)
+
+	return c.Status(201).JSON(result)
+}
+
+// importData contenu du fichier importé : champ "file" d'un formulaire multipart ou corps brut
+func importData(c *fiber.Ctx) ([]byte, error) {
+	header, err := c.FormFile("file")
+	if err != nil {
+		return c.Body(), nil
+	}
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return io.ReadAll(file)
+}
+
+// ImportFile importe un fichier d'un format tiers depuis la ligne de commande
+// Les statistiques du corpus sont recalculées ; les index en mémoire d'un serveur en cours
+// d'exécution ne le sont qu'à sa prochaine importation ou à son redémarrage
+func (h *RecetteHandler) ImportFile(path, format string) (responses.ImportResult, error) {
+	start := time.Now()
+	importer, err := importers.Get(format)
+	if err != nil {
+		return responses.ImportResult{}, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return responses.ImportResult{}, err
+	}
+	recettes, err := importer.Import(data)
+	if err != nil {
+		return responses.ImportResult{}, err
+	}
+
+	info := revisionInfo{source: models.RevisionSourceImport, author: "cli", runID: primitive.NewObjectID().Hex()}
+	result, err := h.importRecettes(context.Background(), recettes, info)
+	if err != nil {
+		return result, err
+	}
+	result.Format = format
+
+	logger.LogDatabase(logger.INFO, "Importation des recettes terminée", "batch_insert", "mongodb", time.Since(start), map[string]interface{}{
+		"run_id":            result.RunID,
+		"format":            format,
+		"file_path":         path,
+		"recettes_count":    result.Inserted,
+		"updated_count":     result.Updated,
+		"unchanged_count":   result.Unchanged,
+		"quarantined_count": result.Quarantined,
+	})
+	if _, err := h.RefreshRecetteStats(); err != nil {
+		logger.LogError("Échec du recalcul des statistiques après importation", err, map[string]interface{}{
+			"run_id": result.RunID,
+		})
+	}
+	return result, nil
+}
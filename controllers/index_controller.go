@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/env"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/indexes"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// SyncIndexes crée au démarrage les index déclarés absents (désactivable avec INDEX_SYNC=false)
+// et journalise les écarts restants : index modifiés, non déclarés ou dont la création a échoué
+func SyncIndexes(db *mongo.Database) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	sync := indexes.Sync
+	if !env.Bool("INDEX_SYNC", true) {
+		sync = indexes.Check
+	}
+	report, err := sync(ctx, db)
+	if err != nil {
+		logger.LogError("Échec de synchronisation des index", err, nil)
+		return
+	}
+
+	created := 0
+	for _, entry := range report.Indexes {
+		switch entry.Status {
+		case indexes.StatusCreated:
+			created++
+		case indexes.StatusOK:
+		case indexes.StatusFailed, indexes.StatusDuplicates:
+			logger.LogDatabase(logger.ERROR, "Index déclaré impossible à créer", "create_index", "mongodb", 0, map[string]interface{}{
+				"collection": entry.Collection,
+				"index":      entry.Name,
+				"status":     entry.Status,
+				"keys":       entry.Keys,
+				"detail":     entry.Detail,
+			})
+		default:
+			logger.LogDatabase(logger.WARN, "Index différent de sa déclaration", "create_index", "mongodb", 0, map[string]interface{}{
+				"collection": entry.Collection,
+				"index":      entry.Name,
+				"status":     entry.Status,
+				"keys":       entry.Keys,
+				"detail":     entry.Detail,
+			})
+		}
+	}
+	logger.LogDatabase(logger.INFO, "Synchronisation des index terminée", "create_index", "mongodb", time.Since(start), map[string]interface{}{
+		"created_count": created,
+		"in_sync":       report.InSync,
+	})
+}
+
+// AdminHandler handlers d'administration de la base MongoDB
+type AdminHandler struct {
+	db *mongo.Database
+}
+
+// NewAdminHandler crée les handlers d'administration de la base MongoDB
+func NewAdminHandler(db *mongo.Database) *AdminHandler {
+	return &AdminHandler{db: db}
+}
+
+// GetIndexes compare les index déclarés à ceux présents en base (GET /admin/indexes)
+func (h *AdminHandler) GetIndexes(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	report, err := indexes.Check(ctx, h.db)
+	if err != nil {
+		logger.LogError("Échec de vérification des index", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.IndexesCheckFailed)
+	}
+	return c.Status(200).JSON(report)
+}
@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/env"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/migrations"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// RunMigrations applique au démarrage les migrations en attente (désactivable avec MIGRATE_ON_START=false)
+// Si une autre instance migre déjà, celle-ci démarre sans attendre : les recettes encore
+// dans l'ancien format restent lisibles grâce à leur mise à niveau à la lecture
+func RunMigrations(db *mongo.Database) {
+	if !env.Bool("MIGRATE_ON_START", true) {
+		return
+	}
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	applied, err := migrations.Up(ctx, db, 0)
+	for _, record := range applied {
+		logger.LogInfo("Migration appliquée", map[string]interface{}{
+			"version":  record.Version,
+			"name":     record.Name,
+			"duration": record.Duration,
+		})
+	}
+	if errors.Is(err, migrations.ErrLocked) {
+		logger.LogInfo("Migrations en cours sur une autre instance", nil)
+		return
+	}
+	if err != nil {
+		logger.LogError("Échec des migrations", err, nil)
+		return
+	}
+	logger.LogDatabase(logger.INFO, "Migrations terminées", "migrate", "mongodb", time.Since(start), map[string]interface{}{
+		"applied_count": len(applied),
+	})
+}
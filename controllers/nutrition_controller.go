@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/nutrition"
+	"github.com/maxime-louis14/api-golang/responses"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxServings nombre maximum de portions accepté par le paramètre servings
+const maxServings = 100
+
+// GetRecetteNutrition retourne l'estimation nutritionnelle d'une recette (totale et par portion)
+func (h *RecetteHandler) GetRecetteNutrition(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	recette, err := h.recettes.Get(ctx, objID)
+	if err != nil {
+		logger.LogError("Recette introuvable", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 404, i18n.RecipeNotFound)
+	}
+
+	// Nombre de portions : paramètre servings, sinon celui de la recette, sinon la valeur par défaut
+	servings := recette.Servings
+	if servings <= 0 {
+		servings = nutrition.DefaultServings
+	}
+	servings = c.QueryInt("servings", servings)
+	if servings <= 0 || servings > maxServings {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "servings", 1, maxServings)
+	}
+
+	report := nutrition.DefaultTable().Estimate(recette.Ingredients, servings)
+
+	logger.LogDatabase(logger.INFO, "Estimation nutritionnelle calculée", "find_one", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id":        requestID,
+		"recipe_id":         id,
+		"coverage":          report.Coverage,
+		"unmatched_count":   len(report.Unmatched),
+		"ingredients_count": len(report.Ingredients),
+	})
+
+	return c.Status(200).JSON(responses.RecetteNutrition{
+		ID:     recette.ID.Hex(),
+		Name:   recette.Name,
+		Report: report,
+	})
+}
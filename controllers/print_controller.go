@@ -0,0 +1,138 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/printcard"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// printImageWidth largeur de l'image insérée dans les fiches PDF (l'une des images.Widths)
+const printImageWidth = 1024
+
+// printRenderer modèles des fiches imprimables (ceux de PRINT_TEMPLATES_DIR remplacent ceux fournis)
+var printRenderer = loadPrintRenderer()
+
+// loadPrintRenderer charge les modèles des fiches ; des modèles invalides sont signalés
+// et remplacés par ceux fournis avec l'API
+func loadPrintRenderer() *printcard.Renderer {
+	dir := os.Getenv("PRINT_TEMPLATES_DIR")
+	renderer, err := printcard.NewRenderer(dir)
+	if err != nil {
+		logger.LogError("Modèles d'impression invalides, modèles fournis utilisés", err, map[string]interface{}{
+			"templates_dir": dir,
+		})
+		return printcard.Default()
+	}
+	return renderer
+}
+
+// GetRecettePrint retourne la fiche imprimable d'une recette : titre, image, ingrédients à cocher,
+// étapes numérotées et page d'origine
+// format=html (par défaut), md ou pdf ; les libellés suivent l'en-tête Accept-Language
+func (h *RecetteHandler) GetRecettePrint(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	format := c.Query("format", printcard.FormatHTML)
+	if !printcard.ValidFormat(format) {
+		return middleware.SendError(c, 400, i18n.UnknownPrintFormat, format)
+	}
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	recette, err := h.recettes.Get(ctx, objID)
+	if err != nil {
+		// Une recette fusionnée redirige vers la fiche de sa recette canonique
+		if alias, aliasErr := h.aliases.Get(ctx, objID); aliasErr == nil {
+			location := strings.Replace(c.Path(), id, alias.RecetteID.Hex(), 1)
+			if query := string(c.Request().URI().QueryString()); query != "" {
+				location += "?" + query
+			}
+			return c.Redirect(location, 301)
+		}
+		logger.LogError("Recette introuvable", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 404, i18n.RecipeNotFound)
+	}
+
+	card := printcard.FromRecette(*recette)
+	card.Language = middleware.Language(c)
+	card.Labels = printcard.Labels{
+		Ingredients:  middleware.Translate(c, i18n.PrintIngredients),
+		Instructions: middleware.Translate(c, i18n.PrintInstructions),
+		Servings:     middleware.Translate(c, i18n.PrintServings),
+		TotalTime:    middleware.Translate(c, i18n.PrintTotalTime),
+		Source:       middleware.Translate(c, i18n.PrintSource),
+	}
+
+	var image []byte
+	if format == printcard.FormatPDF {
+		image = h.printImage(ctx, recette, requestID)
+	}
+
+	var buf bytes.Buffer
+	if err := printRenderer.Render(&buf, format, card, image); err != nil {
+		logger.LogError("Échec de mise en page de la fiche", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+			"format":     format,
+		})
+		return middleware.SendError(c, 500, i18n.PrintFailed)
+	}
+
+	logger.LogInfo("Fiche de recette générée", map[string]interface{}{
+		"request_id":  requestID,
+		"recipe_id":   id,
+		"format":      format,
+		"size":        buf.Len(),
+		"duration_ms": time.Since(start).Milliseconds(),
+	})
+
+	c.Set(fiber.HeaderContentType, printcard.ContentType(format))
+	if format != printcard.FormatHTML {
+		c.Set(fiber.HeaderContentDisposition, `inline; filename="recette-`+id+"."+format+`"`)
+	}
+	return c.Status(200).Send(buf.Bytes())
+}
+
+// printImage retourne l'image de la recette pour sa fiche PDF
+// nil si la recette n'a pas d'image copiée ou si elle est indisponible : la fiche est produite sans
+func (h *RecetteHandler) printImage(ctx context.Context, recette *models.Recette, requestID string) []byte {
+	if h.images == nil || recette.ImageKey == "" {
+		return nil
+	}
+
+	key := recette.ImageKey
+	data, err := h.loadImage(ctx, key, printImageWidth)
+	if err != nil {
+		logger.LogError("Échec de lecture de l'image pour la fiche", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  recette.ID.Hex(),
+			"image_key":  key,
+		})
+		return nil
+	}
+	return data
+}
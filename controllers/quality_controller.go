@@ -0,0 +1,264 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/enrich"
+	"github.com/maxime-louis14/api-golang/env"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/quality"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/responses"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxQuarantineItems nombre maximal d'éléments retournés par le rapport de qualité
+const maxQuarantineItems = 100
+
+// qualityRules règles de validation appliquées à l'importation (variables QUALITY_*)
+var qualityRules = loadQualityRules()
+
+// loadQualityRules lit les règles de validation depuis l'environnement
+func loadQualityRules() quality.Rules {
+	rules := quality.DefaultRules()
+	if value := os.Getenv("QUALITY_REQUIRED_FIELDS"); value != "" {
+		required, err := quality.ParseFields(value)
+		if err != nil {
+			logger.LogError("Champs obligatoires invalides, valeur par défaut utilisée", err, map[string]interface{}{
+				"variable": "QUALITY_REQUIRED_FIELDS",
+				"value":    value,
+			})
+		} else {
+			rules.Required = required
+		}
+	}
+	rules.MinIngredients = env.Count("QUALITY_MIN_INGREDIENTS", rules.MinIngredients)
+	rules.MinInstructions = env.Count("QUALITY_MIN_INSTRUCTIONS", rules.MinInstructions)
+	rules.ValidURLs = env.Bool("QUALITY_VALID_URLS", rules.ValidURLs)
+	rules.UniqueSteps = env.Bool("QUALITY_UNIQUE_STEPS", rules.UniqueSteps)
+	return rules
+}
+
+// quarantineRecette place une recette invalide en quarantaine avec les règles non respectées
+// Une recette déjà en quarantaine (même page) est remplacée par la nouvelle version importée
+func (h *RecetteHandler) quarantineRecette(ctx context.Context, recette models.Recette, issues []models.QualityIssue, runID string) error {
+	now := time.Now().UTC()
+	recette.SchemaVersion = domain.SchemaVersion
+	return h.quarantine.Put(ctx, models.QuarantinedRecette{
+		Recette:   recette,
+		Issues:    issues,
+		RunID:     runID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	})
+}
+
+// GetQualityReport retourne les règles en vigueur, le nombre de recettes en quarantaine par règle
+// et les éléments en quarantaine, les plus récents en premier
+// rule= ne retient que les éléments ne respectant pas cette règle, limit (100 max) borne la liste
+func (h *RecetteHandler) GetQualityReport(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > maxQuarantineItems {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "limit", 1, maxQuarantineItems)
+	}
+
+	rule := c.Query("rule")
+	switch rule {
+	case "", quality.RuleRequired, quality.RuleMinIngredients, quality.RuleMinInstructions, quality.RuleValidURL, quality.RuleDuplicateStep:
+	default:
+		return middleware.SendError(c, 400, i18n.UnknownRule, rule)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	total, err := h.quarantine.Count(ctx, rule)
+	if err != nil {
+		logger.LogError("Échec du décompte des recettes en quarantaine", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.QualityReportFailed)
+	}
+
+	counts, err := h.quarantine.CountByRule(ctx)
+	if err != nil {
+		logger.LogError("Échec du décompte des règles non respectées", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.QualityReportFailed)
+	}
+	byRule := make([]responses.RuleCount, 0, len(counts))
+	for _, count := range counts {
+		byRule = append(byRule, responses.RuleCount{Rule: count.Rule, Count: count.Count})
+	}
+
+	items, err := h.quarantine.List(ctx, rule, limit)
+	if err != nil {
+		logger.LogError("Échec de récupération des recettes en quarantaine", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.QualityReportFailed)
+	}
+
+	return c.Status(200).JSON(responses.QualityReport{
+		Rules:  qualityRules,
+		Total:  total,
+		ByRule: byRule,
+		Items:  items,
+	})
+}
+
+// FixQuarantinedRecette remplace la recette en quarantaine par une version corrigée
+// et recalcule les règles non respectées ; la recette reste en quarantaine jusqu'à sa promotion
+func (h *RecetteHandler) FixQuarantinedRecette(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidQuarantineID)
+	}
+
+	var recette models.Recette
+	if err := c.BodyParser(&recette); err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidBody)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	item, err := h.quarantine.Get(ctx, objID)
+	if err != nil {
+		return middleware.SendError(c, 404, i18n.QuarantineNotFound)
+	}
+	recette.ID = primitive.NilObjectID
+	recette.ImportRun = item.Recette.ImportRun
+
+	item.Recette = recette
+	item.Issues = qualityRules.Validate(recette)
+	item.UpdatedAt = time.Now().UTC()
+	if err := h.quarantine.Replace(ctx, *item); err != nil {
+		logger.LogError("Échec de correction d'une recette en quarantaine", err, map[string]interface{}{
+			"request_id":    requestID,
+			"quarantine_id": objID.Hex(),
+		})
+		return middleware.SendError(c, 500, i18n.QuarantineFixFailed)
+	}
+
+	logger.LogInfo("Recette en quarantaine corrigée", map[string]interface{}{
+		"request_id":    requestID,
+		"quarantine_id": objID.Hex(),
+		"issues_count":  len(item.Issues),
+		"author":        requestAuthor(c),
+	})
+	return c.Status(200).JSON(item)
+}
+
+// PromoteQuarantinedRecette enregistre une recette en quarantaine parmi les recettes si elle respecte
+// désormais toutes les règles, puis la retire de la quarantaine (422 avec les règles non respectées sinon)
+func (h *RecetteHandler) PromoteQuarantinedRecette(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidQuarantineID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	item, err := h.quarantine.Get(ctx, objID)
+	if err != nil {
+		return middleware.SendError(c, 404, i18n.QuarantineNotFound)
+	}
+
+	// Les règles ont pu changer depuis la mise en quarantaine : elles sont réévaluées
+	recette := item.Recette
+	if issues := qualityRules.Validate(recette); len(issues) > 0 {
+		item.Issues = issues
+		if err := h.quarantine.Replace(ctx, *item); err != nil {
+			logger.LogError("Échec de mise à jour des règles non respectées", err, map[string]interface{}{
+				"request_id":    requestID,
+				"quarantine_id": objID.Hex(),
+			})
+		}
+		return c.Status(422).JSON(issues)
+	}
+
+	// Enregistrée comme une importation : une recette existante pour la même page est remplacée
+	enrich.Recette(&recette)
+	imported, err := h.recettes.BulkImport(ctx, []models.Recette{recette})
+	if err != nil {
+		logger.LogError("Échec d'enregistrement d'une recette promue", err, map[string]interface{}{
+			"request_id":    requestID,
+			"quarantine_id": objID.Hex(),
+		})
+		return middleware.SendError(c, 500, i18n.PromoteFailed)
+	}
+	previous, recette := imported[0].Previous, imported[0].Recette
+	if imported[0].Status != repository.ImportUnchanged {
+		info := revisionInfo{source: models.RevisionSourceManual, author: requestAuthor(c), runID: requestID}
+		if err := h.recordChange(ctx, previous, recette, info); err != nil {
+			logger.LogError("Échec d'enregistrement de la révision d'une recette promue", err, map[string]interface{}{
+				"request_id":    requestID,
+				"quarantine_id": objID.Hex(),
+			})
+			return middleware.SendError(c, 500, i18n.PromoteFailed)
+		}
+	}
+	if err := h.quarantine.Delete(ctx, objID); err != nil {
+		logger.LogError("Échec de sortie de quarantaine d'une recette promue", err, map[string]interface{}{
+			"request_id":    requestID,
+			"quarantine_id": objID.Hex(),
+		})
+	}
+
+	logger.LogInfo("Recette sortie de quarantaine", map[string]interface{}{
+		"request_id":    requestID,
+		"quarantine_id": objID.Hex(),
+		"recipe_id":     recette.ID.Hex(),
+		"author":        requestAuthor(c),
+	})
+
+	if err := h.RefreshIndexes(); err != nil {
+		logger.LogError("Échec de reconstruction des index après promotion", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+	}
+
+	status := 200
+	if previous == nil {
+		status = 201
+	}
+	return c.Status(status).JSON(recette)
+}
+
+// DeleteQuarantinedRecette abandonne définitivement une recette en quarantaine
+func (h *RecetteHandler) DeleteQuarantinedRecette(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidQuarantineID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := h.quarantine.Delete(ctx, objID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return middleware.SendError(c, 404, i18n.QuarantineNotFound)
+		}
+		logger.LogError("Échec de suppression d'une recette en quarantaine", err, map[string]interface{}{
+			"request_id":    requestID,
+			"quarantine_id": objID.Hex(),
+		})
+		return middleware.SendError(c, 500, i18n.QuarantineDeleteFailed)
+	}
+	return c.SendStatus(204)
+}
@@ -7,18 +7,58 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
-	"github.com/maxime-louis14/api-golang/database"
+	"github.com/maxime-louis14/api-golang/enrich"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/images"
 	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
 	"github.com/maxime-louis14/api-golang/models"
-	"go.mongodb.org/mongo-driver/bson"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/responses"
+	"github.com/maxime-louis14/api-golang/similarity"
+	"github.com/maxime-louis14/api-golang/stats"
+	"github.com/maxime-louis14/api-golang/suggest"
 	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
 )
 
-var recetteCollection *mongo.Collection = database.OpenCollection(database.Client, "recettes")
+// RecetteHandler handlers des recettes, indépendants du stockage choisi (STORAGE)
+type RecetteHandler struct {
+	recettes   repository.RecipeRepository
+	revisions  repository.RevisionRepository
+	aliases    repository.AliasRepository
+	quarantine repository.QuarantineRepository
+	stats      repository.StatsRepository
+	images     images.Store
+	events     *events.Bus
+
+	// Index en mémoire reconstruits après chaque importation
+	similarity *similarity.Index // Similarité TF-IDF des ingrédients
+	suggest    *suggest.Index    // Autocomplétion des noms de recettes et d'ingrédients
+
+	// Dernières statistiques calculées, servies par GET /stats/recettes
+	statsMu     sync.RWMutex
+	statsReport *stats.Report
+}
+
+// NewRecetteHandler crée les handlers des recettes sur les stockages fournis
+func NewRecetteHandler(stores repository.Stores) *RecetteHandler {
+	return &RecetteHandler{
+		recettes:   stores.Recettes,
+		revisions:  stores.Revisions,
+		aliases:    stores.Aliases,
+		quarantine: stores.Quarantine,
+		stats:      stores.Stats,
+		images:     stores.Images,
+		events:     stores.Events,
+		similarity: similarity.NewIndex(),
+		suggest:    suggest.NewIndex(),
+	}
+}
 
 // getScraperDataPath retourne un chemin absolu vers data.json
 func getScraperDataPath() (string, error) {
@@ -44,13 +84,20 @@ func getScraperDataPath() (string, error) {
 }
 
 // PostRecette ajoute des recettes en batch depuis un fichier JSON
-func PostRecette(c *fiber.Ctx) error {
+func (h *RecetteHandler) PostRecette(c *fiber.Ctx) error {
 	start := time.Now()
 	requestID := c.Locals("requestID").(string)
 
 	logger.LogInfo("Début de l'importation des recettes", map[string]interface{}{
 		"request_id": requestID,
 	})
+	h.events.Publish(events.ImportStarted, RunEvent{RunID: requestID})
+
+	// fail signale l'échec de l'importation aux abonnés (message dans la langue par défaut) et au client
+	fail := func(code i18n.Code) error {
+		h.events.Publish(events.ImportFailed, RunEvent{RunID: requestID, Error: i18n.Message(i18n.DefaultLanguage, code)})
+		return middleware.SendError(c, 500, code)
+	}
 
 	// Obtenir le chemin complet vers data.json
 	dataPath, err := getScraperDataPath()
@@ -58,7 +105,7 @@ func PostRecette(c *fiber.Ctx) error {
 		logger.LogError("Échec de localisation du fichier data.json", err, map[string]interface{}{
 			"request_id": requestID,
 		})
-		return c.Status(500).SendString("Erreur lors de la localisation du fichier data.json")
+		return fail(i18n.ImportFileNotFound)
 	}
 
 	// Debug: afficher le chemin trouvé
@@ -74,7 +121,7 @@ func PostRecette(c *fiber.Ctx) error {
 			"request_id": requestID,
 			"file_path":  dataPath,
 		})
-		return c.Status(500).SendString("Erreur lors de l'ouverture du fichier data.json")
+		return fail(i18n.ImportFileOpenFailed)
 	}
 	defer file.Close()
 
@@ -85,7 +132,7 @@ func PostRecette(c *fiber.Ctx) error {
 			"request_id": requestID,
 			"file_path":  dataPath,
 		})
-		return c.Status(500).SendString("Erreur lors de la lecture du fichier data.json")
+		return fail(i18n.ImportFileReadFailed)
 	}
 
 	// Décoder les données JSON
@@ -94,34 +141,47 @@ func PostRecette(c *fiber.Ctx) error {
 		logger.LogError("Échec du décodage JSON", err, map[string]interface{}{
 			"request_id": requestID,
 		})
-		return c.Status(500).SendString("Erreur lors du décodage des données JSON")
+		return fail(i18n.ImportDecodeFailed)
 	}
 
-	// Insérer les recettes dans MongoDB
-	insertedCount := 0
-	for _, recette := range recettes {
-		_, err := recetteCollection.InsertOne(context.Background(), recette)
-		if err != nil {
-			logger.LogError("Échec d'insertion d'une recette", err, map[string]interface{}{
-				"request_id": requestID,
-				"recette":    recette.Name,
-			})
-			return c.Status(500).SendString("Erreur lors de l'insertion des recettes")
-		}
-		insertedCount++
+	// Enregistrer les recettes
+	info := revisionInfo{source: models.RevisionSourceScrape, author: requestAuthor(c), runID: requestID}
+	result, err := h.importRecettes(context.Background(), recettes, info)
+	if err != nil {
+		return fail(i18n.ImportInsertFailed)
 	}
+	h.finishImport(result, time.Since(start))
+	go h.MirrorImportImages(result.RunID)
 
-	duration := time.Since(start)
-	logger.LogDatabase(logger.INFO, "Importation des recettes terminée", "batch_insert", "mongodb", duration, map[string]interface{}{
-		"request_id":     requestID,
-		"recettes_count": insertedCount,
+	return middleware.SendMessage(c, 201, i18n.RecipesImported)
+}
+
+// respondWithFacets retourne les recettes accompagnées des facettes calculées avec les mêmes critères (?facets=true)
+func (h *RecetteHandler) respondWithFacets(c *fiber.Ctx, ctx context.Context, ingredient string, query repository.Query, recettes []models.Recette) error {
+	requestID := c.Locals("requestID").(string)
+	start := time.Now()
+
+	result, err := h.recettes.Facets(ctx, ingredient, query)
+	if err != nil {
+		logger.LogError("Échec du calcul des facettes", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.FacetsFailed)
+	}
+
+	logger.LogDatabase(logger.INFO, "Facettes calculées", "aggregate_facet", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id": requestID,
 	})
 
-	return c.Status(201).SendString("Recettes ajoutées avec succès")
+	return c.Status(200).JSON(responses.FacetedRecettes{
+		Recettes: recettes,
+		Total:    len(recettes),
+		Facets:   result,
+	})
 }
 
 // GetAllRecettes retourne toutes les recettes
-func GetAllRecettes(c *fiber.Ctx) error {
+func (h *RecetteHandler) GetAllRecettes(c *fiber.Ctx) error {
 	start := time.Now()
 	requestID := c.Locals("requestID").(string)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -131,23 +191,22 @@ func GetAllRecettes(c *fiber.Ctx) error {
 		"request_id": requestID,
 	})
 
-	// Récupérer toutes les recettes
-	cursor, err := recetteCollection.Find(ctx, bson.M{})
+	// Filtres (régime alimentaire, allergènes, durées) et tri optionnels
+	query, err := recetteListQuery(c)
 	if err != nil {
-		logger.LogError("Échec de récupération des recettes", err, map[string]interface{}{
+		logger.LogError("Paramètres de filtre invalides", err, map[string]interface{}{
 			"request_id": requestID,
 		})
-		return c.Status(500).SendString("Erreur lors de la récupération des recettes")
+		return middleware.SendErr(c, 400, err)
 	}
-	defer cursor.Close(ctx)
 
-	// Décoder les recettes
-	var recettes []models.Recette
-	if err := cursor.All(ctx, &recettes); err != nil {
-		logger.LogError("Échec du décodage des recettes", err, map[string]interface{}{
+	// Récupérer les recettes
+	recettes, err := h.recettes.List(ctx, query)
+	if err != nil {
+		logger.LogError("Échec de récupération des recettes", err, map[string]interface{}{
 			"request_id": requestID,
 		})
-		return c.Status(500).SendString("Erreur lors du décodage des recettes")
+		return middleware.SendError(c, 500, i18n.RecipesFetchFailed)
 	}
 
 	duration := time.Since(start)
@@ -156,11 +215,14 @@ func GetAllRecettes(c *fiber.Ctx) error {
 		"recettes_count": len(recettes),
 	})
 
+	if c.QueryBool("facets") {
+		return h.respondWithFacets(c, ctx, "", query, recettes)
+	}
 	return c.Status(200).JSON(recettes)
 }
 
 // GetRecetteByID retourne une recette spécifique en fonction de son ID
-func GetRecetteByID(c *fiber.Ctx) error {
+func (h *RecetteHandler) GetRecetteByID(c *fiber.Ctx) error {
 	start := time.Now()
 	requestID := c.Locals("requestID").(string)
 	id := c.Params("id")
@@ -177,18 +239,21 @@ func GetRecetteByID(c *fiber.Ctx) error {
 			"request_id": requestID,
 			"recipe_id":  id,
 		})
-		return c.Status(400).SendString("ID de recette invalide")
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
 	}
 
-	// Rechercher la recette
-	filter := bson.M{"_id": objID}
-	var recette models.Recette
-	if err := recetteCollection.FindOne(context.Background(), filter).Decode(&recette); err != nil {
+	// Rechercher la recette (hors corbeille)
+	recette, err := h.recettes.Get(context.Background(), objID)
+	if err != nil {
+		// Une recette fusionnée redirige vers sa recette canonique
+		if alias, aliasErr := h.aliases.Get(context.Background(), objID); aliasErr == nil {
+			return c.Redirect(strings.TrimSuffix(c.Path(), id)+alias.RecetteID.Hex(), 301)
+		}
 		logger.LogError("Recette introuvable", err, map[string]interface{}{
 			"request_id": requestID,
 			"recipe_id":  id,
 		})
-		return c.Status(404).SendString("Recette introuvable")
+		return middleware.SendError(c, 404, i18n.RecipeNotFound)
 	}
 
 	duration := time.Since(start)
@@ -202,7 +267,7 @@ func GetRecetteByID(c *fiber.Ctx) error {
 }
 
 // GetRecetteByName retourne une recette en fonction de son nom
-func GetRecetteByName(c *fiber.Ctx) error {
+func (h *RecetteHandler) GetRecetteByName(c *fiber.Ctx) error {
 	start := time.Now()
 	requestID := c.Locals("requestID").(string)
 	nomRecette := strings.ReplaceAll(c.Params("name"), "%20", " ")
@@ -212,15 +277,22 @@ func GetRecetteByName(c *fiber.Ctx) error {
 		"recipe_name": nomRecette,
 	})
 
-	// Rechercher la recette par nom
-	filter := bson.M{"name": nomRecette}
-	var recette models.Recette
-	if err := recetteCollection.FindOne(context.Background(), filter).Decode(&recette); err != nil {
+	query, err := recetteListQuery(c)
+	if err != nil {
+		logger.LogError("Paramètres de filtre invalides", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendErr(c, 400, err)
+	}
+
+	// Rechercher la recette par nom, sans tenir compte de la casse
+	recette, err := h.recettes.FindByName(context.Background(), nomRecette, query)
+	if err != nil {
 		logger.LogError("Recette introuvable par nom", err, map[string]interface{}{
 			"request_id":  requestID,
 			"recipe_name": nomRecette,
 		})
-		return c.Status(404).SendString("Recette introuvable")
+		return middleware.SendError(c, 404, i18n.RecipeNotFound)
 	}
 
 	duration := time.Since(start)
@@ -233,36 +305,34 @@ func GetRecetteByName(c *fiber.Ctx) error {
 }
 
 // GetRecettesByIngredient retourne toutes les recettes contenant un ingrédient spécifique
-func GetRecettesByIngredient(c *fiber.Ctx) error {
+func (h *RecetteHandler) GetRecettesByIngredient(c *fiber.Ctx) error {
 	start := time.Now()
 	requestID := c.Locals("requestID").(string)
-	ingredient := c.Params("unit")
+	ingredient := strings.TrimSpace(strings.ReplaceAll(c.Params("ingredient"), "%20", " "))
 
 	logger.LogInfo("Recherche de recettes par ingrédient", map[string]interface{}{
 		"request_id": requestID,
 		"ingredient": ingredient,
 	})
 
-	// Rechercher les recettes par ingrédient
-	filter := bson.M{"ingredients": bson.M{"$elemMatch": bson.M{"unit": ingredient}}}
-	cursor, err := recetteCollection.Find(context.Background(), filter)
+	query, err := recetteListQuery(c)
 	if err != nil {
-		logger.LogError("Échec de récupération des recettes par ingrédient", err, map[string]interface{}{
+		logger.LogError("Paramètres de filtre invalides", err, map[string]interface{}{
 			"request_id": requestID,
-			"ingredient": ingredient,
 		})
-		return c.Status(500).SendString("Erreur lors de la récupération des recettes")
+		return middleware.SendErr(c, 400, err)
 	}
-	defer cursor.Close(context.Background())
 
-	// Décoder les recettes
-	var recettes []models.Recette
-	if err := cursor.All(context.Background(), &recettes); err != nil {
-		logger.LogError("Échec du décodage des recettes par ingrédient", err, map[string]interface{}{
+	// Rechercher les recettes dont un ingrédient contient l'expression
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	recettes, err := h.recettes.Search(ctx, ingredient, query)
+	if err != nil {
+		logger.LogError("Échec de récupération des recettes par ingrédient", err, map[string]interface{}{
 			"request_id": requestID,
 			"ingredient": ingredient,
 		})
-		return c.Status(500).SendString("Erreur lors du décodage des recettes")
+		return middleware.SendError(c, 500, i18n.RecipesFetchFailed)
 	}
 
 	duration := time.Since(start)
@@ -272,5 +342,80 @@ func GetRecettesByIngredient(c *fiber.Ctx) error {
 		"recettes_count": len(recettes),
 	})
 
+	if c.QueryBool("facets") {
+		return h.respondWithFacets(c, ctx, ingredient, query, recettes)
+	}
 	return c.Status(200).JSON(recettes)
 }
+
+// UpdateRecette remplace le contenu d'une recette et conserve la version précédente dans l'historique
+func (h *RecetteHandler) UpdateRecette(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	var recette models.Recette
+	if err := c.BodyParser(&recette); err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidBody)
+	}
+	if strings.TrimSpace(recette.Name) == "" {
+		return middleware.SendError(c, 400, i18n.RecipeNameRequired)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	previous, err := h.recettes.Get(ctx, objID)
+	if err != nil {
+		logger.LogError("Recette introuvable", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 404, i18n.RecipeNotFound)
+	}
+
+	// Les champs calculés sont recalculés, l'image copiée reste valable tant que l'URL ne change pas
+	enrich.Recette(&recette)
+	recette.ImportRun = previous.ImportRun
+	recette.Aliases = previous.Aliases
+	recette.DeletedAt = nil
+	recette.ImageKey = ""
+	if recette.Image == previous.Image {
+		recette.ImageKey = previous.ImageKey
+	}
+
+	info := revisionInfo{source: models.RevisionSourceManual, author: requestAuthor(c), runID: requestID}
+	changed, err := h.saveRecette(ctx, previous, &recette, info)
+	if err != nil {
+		logger.LogError("Échec de mise à jour de la recette", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 500, i18n.RecipeUpdateFailed)
+	}
+	if !changed {
+		return c.Status(200).JSON(previous)
+	}
+
+	logger.LogDatabase(logger.INFO, "Recette mise à jour", "replace_one", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id": requestID,
+		"recipe_id":  id,
+	})
+
+	if err := h.RefreshIndexes(); err != nil {
+		logger.LogError("Échec de reconstruction des index après mise à jour", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+	}
+
+	return c.Status(200).JSON(recette)
+}
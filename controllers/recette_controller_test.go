@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testApp monte les handlers des recettes sur des stockages en mémoire, sans MongoDB
+func testApp(t *testing.T) (*fiber.App, repository.Stores) {
+	t.Helper()
+	stores := repository.NewMemoryStores()
+	stores.Events = events.NewBus(100)
+	recettes := NewRecetteHandler(stores)
+
+	app := fiber.New()
+	app.Use(middleware.LoggingMiddleware())
+	app.Get("/recettes", recettes.GetAllRecettes)
+	app.Get("/recette/:id", recettes.GetRecetteByID)
+	app.Put("/recette/:id", recettes.UpdateRecette)
+	app.Delete("/recette/:id", recettes.DeleteRecette)
+	app.Post("/recette/:id/restore", recettes.RestoreRecette)
+	app.Get("/recette/:id/revisions", recettes.GetRecetteRevisions)
+	app.Get("/trash", recettes.GetTrash)
+	return app, stores
+}
+
+// do exécute une requête sur l'application et décode la réponse JSON dans out (si non nil)
+func do(t *testing.T, app *fiber.App, method, path string, body interface{}, out interface{}) int {
+	t.Helper()
+	var payload []byte
+	if body != nil {
+		var err error
+		payload, err = json.Marshal(body)
+		require.NoError(t, err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req, -1)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestRecetteHandlersOnMemoryStores(t *testing.T) {
+	app, stores := testApp(t)
+	recette := &models.Recette{Recipe: domain.Recipe{Name: "Tarte aux pommes", Page: "https://example.com/tarte"}}
+	require.NoError(t, stores.Recettes.Upsert(context.Background(), recette))
+	path := "/recette/" + recette.ID.Hex()
+
+	var list []models.Recette
+	assert.Equal(t, 200, do(t, app, "GET", "/recettes", nil, &list))
+	require.Len(t, list, 1)
+	assert.Equal(t, "Tarte aux pommes", list[0].Name)
+
+	var found models.Recette
+	assert.Equal(t, 200, do(t, app, "GET", path, nil, &found))
+	assert.Equal(t, recette.ID, found.ID)
+	assert.Equal(t, 400, do(t, app, "GET", "/recette/invalide", nil, nil))
+
+	// La mise à jour est enregistrée dans l'historique, après la version existante,
+	// et publiée sur le bus d'événements injecté
+	sub, _, _ := stores.Events.Subscribe([]string{"recette"}, 0)
+	defer sub.Close()
+	update := models.Recette{Recipe: domain.Recipe{Name: "Tarte aux pommes fine", Page: recette.Page}}
+	var updated models.Recette
+	assert.Equal(t, 200, do(t, app, "PUT", path, update, &updated))
+	assert.Equal(t, "Tarte aux pommes fine", updated.Name)
+	select {
+	case event := <-sub.C:
+		assert.Equal(t, RecetteEvent{ID: recette.ID.Hex(), Name: "Tarte aux pommes fine"}, event.Data)
+	default:
+		t.Fatal("aucun événement publié")
+	}
+	var revisions []models.RecetteRevision
+	assert.Equal(t, 200, do(t, app, "GET", path+"/revisions", nil, &revisions))
+	require.Len(t, revisions, 2)
+	assert.Equal(t, models.RevisionSourceInitial, revisions[0].Source)
+	assert.Equal(t, models.RevisionSourceManual, revisions[1].Source)
+
+	// Une recette dans la corbeille n'est plus servie jusqu'à sa restauration
+	assert.Equal(t, 204, do(t, app, "DELETE", path, nil, nil))
+	assert.Equal(t, 404, do(t, app, "GET", path, nil, nil))
+	assert.Equal(t, 200, do(t, app, "GET", "/recettes", nil, &list))
+	assert.Empty(t, list)
+	var trash []map[string]interface{}
+	assert.Equal(t, 200, do(t, app, "GET", "/trash", nil, &trash))
+	require.Len(t, trash, 1)
+	assert.Equal(t, "Tarte aux pommes fine", trash[0]["name"])
+	assert.Contains(t, trash[0], "Instructions")
+	assert.Contains(t, trash[0], "deleted_at")
+	assert.Contains(t, trash[0], "purge_at")
+	assert.Equal(t, 200, do(t, app, "POST", path+"/restore", nil, nil))
+	assert.Equal(t, 200, do(t, app, "GET", path, nil, &found))
+	assert.Equal(t, "Tarte aux pommes fine", found.Name)
+	assert.Equal(t, 404, do(t, app, "POST", path+"/restore", nil, nil))
+}
@@ -0,0 +1,100 @@
+package controllers
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/allergens"
+	"github.com/maxime-louis14/api-golang/diet"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/isoduration"
+	"github.com/maxime-louis14/api-golang/repository"
+)
+
+// timeFilters paramètres de durée maximale et critère correspondant
+var timeFilters = []struct {
+	param string
+	limit func(q *repository.Query) *int
+}{
+	{param: "max_total_time", limit: func(q *repository.Query) *int { return &q.MaxTotalMinutes }},
+	{param: "max_prep_time", limit: func(q *repository.Query) *int { return &q.MaxPrepMinutes }},
+	{param: "max_cook_time", limit: func(q *repository.Query) *int { return &q.MaxCookMinutes }},
+}
+
+// recetteListQuery lit les paramètres communs aux listes et recherches
+// diet=vegan,gluten-free : recettes compatibles avec tous les régimes demandés
+// exclude_allergens=peanuts,milk : recettes sans aucun des allergènes indiqués
+// max_total_time=30m (ou max_prep_time, max_cook_time) : recettes de durée connue inférieure ou égale
+// category=soup,desserts : recettes de l'une des catégories indiquées
+// sort=total_time, -servings... : tri (préfixe "-" pour l'ordre décroissant)
+// Les recettes placées dans la corbeille sont toujours exclues
+func recetteListQuery(c *fiber.Ctx) (repository.Query, error) {
+	var query repository.Query
+
+	for _, name := range splitQueryList(c.Query("diet")) {
+		if !diet.Valid(name) {
+			return query, i18n.Errorf(i18n.UnknownDiet, name, strings.Join(diet.Names(), ", "))
+		}
+		query.Diets = append(query.Diets, name)
+	}
+
+	dict := allergens.DefaultDictionary()
+	for _, id := range splitQueryList(c.Query("exclude_allergens")) {
+		if !dict.Valid(id) {
+			return query, i18n.Errorf(i18n.UnknownAllergen, id, strings.Join(dict.IDs(), ", "))
+		}
+		query.ExcludeAllergens = append(query.ExcludeAllergens, id)
+	}
+
+	query.Categories = splitQueryList(c.Query("category"))
+
+	for _, tf := range timeFilters {
+		value := c.Query(tf.param)
+		if value == "" {
+			continue
+		}
+		minutes, err := parseMinutes(value)
+		if err != nil {
+			return query, i18n.Errorf(i18n.InvalidDurationParam, tf.param, value)
+		}
+		*tf.limit(&query) = minutes
+	}
+
+	if value := strings.TrimSpace(c.Query("sort")); value != "" {
+		query.Desc = strings.HasPrefix(value, "-")
+		query.Sort = strings.TrimPrefix(value, "-")
+		if !repository.ValidSort(query.Sort) {
+			return query, i18n.Errorf(i18n.UnknownSort, query.Sort, strings.Join(repository.SortNames(), ", "))
+		}
+	}
+
+	return query, nil
+}
+
+// parseMinutes convertit une durée ("30m", "1h30m", "PT45M" ou un nombre de minutes) en minutes
+func parseMinutes(value string) (int, error) {
+	if minutes, err := strconv.Atoi(value); err == nil && minutes > 0 {
+		return minutes, nil
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= time.Minute {
+		return int(d / time.Minute), nil
+	}
+	if d, err := isoduration.Parse(value); err == nil && d >= time.Minute {
+		return int(d / time.Minute), nil
+	}
+	return 0, errors.New("durée invalide")
+}
+
+// splitQueryList découpe un paramètre de liste séparé par des virgules
+func splitQueryList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.ToLower(strings.TrimSpace(part)); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
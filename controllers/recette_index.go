@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/similarity"
+)
+
+// RefreshIndexes recharge les recettes (hors corbeille) du stockage et reconstruit les index en mémoire
+// Appelé au démarrage du serveur et après chaque importation
+func (h *RecetteHandler) RefreshIndexes() error {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	recettes, err := h.recettes.List(ctx, repository.Query{})
+	if err != nil {
+		logger.LogError("Échec de chargement des recettes pour les index", err, nil)
+		return err
+	}
+
+	docs := make([]similarity.Document, 0, len(recettes))
+	recipeNames := make([]string, 0, len(recettes))
+	var ingredientNames []string
+	for _, recette := range recettes {
+		names := ingredients.Names(recette.Ingredients)
+		docs = append(docs, similarity.Document{
+			ID:    recette.ID.Hex(),
+			Terms: names,
+		})
+		recipeNames = append(recipeNames, recette.Name)
+		ingredientNames = append(ingredientNames, uniqueStrings(names)...)
+	}
+	h.similarity.Build(docs)
+	h.suggest.Build(recipeNames, ingredientNames)
+
+	logger.LogDatabase(logger.INFO, "Index des recettes reconstruits", "build_indexes", "mongodb", time.Since(start), map[string]interface{}{
+		"recettes_count": len(recettes),
+	})
+	return nil
+}
+
+// uniqueStrings retire les doublons en conservant l'ordre
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
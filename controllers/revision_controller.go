@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/responses"
+	"github.com/maxime-louis14/api-golang/revisions"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// revisionInfo origine d'une modification de recette
+type revisionInfo struct {
+	source string
+	author string
+	runID  string
+}
+
+// requestAuthor retourne le nom de la clé d'API à l'origine de la requête (vide si anonyme)
+func requestAuthor(c *fiber.Ctx) string {
+	if key := middleware.CurrentAPIKey(c); key != nil {
+		return key.Name
+	}
+	return ""
+}
+
+// saveRecette insère (previous nil) ou remplace une recette dans le stockage, enregistre la révision
+// correspondante et publie l'événement recette.created ou recette.updated
+// Retourne false sans rien écrire si la nouvelle version est identique à la précédente
+func (h *RecetteHandler) saveRecette(ctx context.Context, previous *models.Recette, recette *models.Recette, info revisionInfo) (bool, error) {
+	if previous == nil {
+		// Identifiant et date de création attribués par le stockage
+		recette.ID = primitive.NilObjectID
+		recette.CreatedAt = nil
+	} else {
+		if revisions.Compare(*previous, *recette).Empty() {
+			return false, nil
+		}
+		recette.ID = previous.ID
+		recette.CreatedAt = previous.CreatedAt
+	}
+	if err := h.recettes.Upsert(ctx, recette); err != nil {
+		return false, err
+	}
+	return true, h.recordChange(ctx, previous, *recette, info)
+}
+
+// recordChange enregistre la révision d'une recette écrite dans le stockage
+// et publie l'événement recette.created (previous nil) ou recette.updated
+func (h *RecetteHandler) recordChange(ctx context.Context, previous *models.Recette, recette models.Recette, info revisionInfo) error {
+	if err := h.recordRevision(ctx, previous, recette, info); err != nil {
+		return err
+	}
+
+	if previous == nil {
+		h.publishRecetteEvent(events.RecetteCreated, recette, info.author)
+	} else {
+		h.publishRecetteEvent(events.RecetteUpdated, recette, info.author)
+	}
+	return nil
+}
+
+// recordRevision ajoute une révision à l'historique de la recette
+// Une recette modifiée pour la première fois voit d'abord sa version précédente archivée
+func (h *RecetteHandler) recordRevision(ctx context.Context, previous *models.Recette, recette models.Recette, info revisionInfo) error {
+	last, err := h.revisions.LastNumber(ctx, recette.ID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	if last == 0 && previous != nil {
+		last++
+		initial := models.RecetteRevision{
+			RecetteID: recette.ID,
+			Number:    last,
+			Source:    models.RevisionSourceInitial,
+			CreatedAt: now,
+			Recette:   previous,
+		}
+		if err := h.revisions.Add(ctx, initial); err != nil {
+			return err
+		}
+	}
+
+	return h.revisions.Add(ctx, models.RecetteRevision{
+		RecetteID: recette.ID,
+		Number:    last + 1,
+		Source:    info.source,
+		Author:    info.author,
+		RunID:     info.runID,
+		CreatedAt: now,
+		Recette:   &recette,
+	})
+}
+
+// GetRecetteRevisions retourne l'historique des révisions d'une recette (sans le contenu)
+func (h *RecetteHandler) GetRecetteRevisions(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	revisionList, err := h.revisions.List(ctx, objID)
+	if err != nil {
+		logger.LogError("Échec de récupération des révisions", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 500, i18n.RevisionsFetchFailed)
+	}
+
+	// Une recette sans historique doit tout de même exister
+	if len(revisionList) == 0 {
+		if _, err := h.recettes.Get(ctx, objID); err != nil {
+			return middleware.SendError(c, 404, i18n.RecipeNotFound)
+		}
+	}
+
+	logger.LogDatabase(logger.INFO, "Révisions de recette récupérées", "find_many", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id":      requestID,
+		"recipe_id":       id,
+		"revisions_count": len(revisionList),
+	})
+
+	return c.Status(200).JSON(revisionList)
+}
+
+// GetRecetteRevisionDiff retourne les différences entre deux révisions d'une recette
+func (h *RecetteHandler) GetRecetteRevisionDiff(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+	from, errFrom := strconv.Atoi(c.Params("a"))
+	to, errTo := strconv.Atoi(c.Params("b"))
+	if errFrom != nil || errTo != nil || from <= 0 || to <= 0 {
+		return middleware.SendError(c, 400, i18n.InvalidRevisionNumbers)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	before, err := h.revisions.Get(ctx, objID, from)
+	if err != nil {
+		logger.LogError("Révision introuvable", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+			"revision":   from,
+		})
+		return middleware.SendError(c, 404, i18n.RevisionNotFound, c.Params("a"))
+	}
+	after, err := h.revisions.Get(ctx, objID, to)
+	if err != nil {
+		logger.LogError("Révision introuvable", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+			"revision":   to,
+		})
+		return middleware.SendError(c, 404, i18n.RevisionNotFound, c.Params("b"))
+	}
+
+	diff := revisions.Compare(*before.Recette, *after.Recette)
+	before.Recette, after.Recette = nil, nil
+
+	logger.LogDatabase(logger.INFO, "Différences entre révisions calculées", "find_one", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id": requestID,
+		"recipe_id":  id,
+		"from":       from,
+		"to":         to,
+	})
+
+	return c.Status(200).JSON(responses.RevisionDiff{
+		RecetteID: id,
+		From:      *before,
+		To:        *after,
+		Diff:      diff,
+	})
+}
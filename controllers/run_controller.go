@@ -1,16 +1,22 @@
 package controllers
 
 import (
+	"bufio"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
 	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
 )
 
 // LaunchScraper lance le scraper via une route API
-func LaunchScraper(c *fiber.Ctx) error {
+func (h *RecetteHandler) LaunchScraper(c *fiber.Ctx) error {
 	start := time.Now()
 	requestID := c.Locals("requestID").(string)
 
@@ -18,15 +24,27 @@ func LaunchScraper(c *fiber.Ctx) error {
 		"request_id": requestID,
 	})
 
+	h.events.Publish(events.ScrapeStarted, RunEvent{RunID: requestID})
+
 	// Ajoute un délai de 4 secondes
 	time.Sleep(4 * time.Second)
 
-	// Exécute le scraper
-	if err := RunScraper(); err != nil {
+	// Exécute le scraper en publiant son avancement après chaque recette
+	onProgress := func(progress events.ScrapeProgressLine) {
+		h.events.Publish(events.ScrapeProgress, RunEvent{
+			RunID:     requestID,
+			Total:     progress.Found,
+			Processed: progress.Completed + progress.Failed,
+			Failed:    progress.Failed,
+			Recipe:    progress.Recipe,
+		})
+	}
+	if err := RunScraper(onProgress); err != nil {
 		logger.LogError("Erreur lors de l'exécution du scraper", err, map[string]interface{}{
 			"request_id": requestID,
 		})
-		return c.Status(500).SendString("Erreur lors de l'exécution du scraper")
+		h.events.Publish(events.ScrapeFailed, RunEvent{RunID: requestID, Duration: time.Since(start).String(), Error: err.Error()})
+		return middleware.SendError(c, 500, i18n.ScraperFailed)
 	}
 
 	duration := time.Since(start)
@@ -34,12 +52,14 @@ func LaunchScraper(c *fiber.Ctx) error {
 		"request_id": requestID,
 		"duration":   duration.String(),
 	})
+	h.events.Publish(events.ScrapeCompleted, RunEvent{RunID: requestID, Duration: duration.String()})
 
-	return c.Status(200).SendString("Scraper exécuté avec succès")
+	return middleware.SendMessage(c, 200, i18n.ScraperCompleted)
 }
 
 // RunScraper exécute le binaire du scraper
-func RunScraper() error {
+// Les lignes d'avancement écrites par le scraper sont transmises à onProgress, les autres recopiées sur la sortie standard
+func RunScraper(onProgress func(events.ScrapeProgressLine)) error {
 	start := time.Now()
 	// Chemin vers le binaire du scraper
 	scraperPath := "/go_api_mongo_scrapper/scraper/scraper"
@@ -63,12 +83,37 @@ func RunScraper() error {
 	// Commande pour exécuter le scraper
 	cmd := exec.Command(scraperPath)
 
-	// Associe les sorties standard et erreur du scraper aux sorties du serveur
-	cmd.Stdout = os.Stdout
+	// La sortie standard est lue ligne par ligne pour y trouver l'avancement, la sortie d'erreur
+	// (journal du scraper) est associée à celle du serveur
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
 	cmd.Stderr = os.Stderr
 
 	// Exécute la commande
-	if err := cmd.Run(); err != nil {
+	if err := cmd.Start(); err != nil {
+		logger.LogError("Échec du lancement du scraper", err, map[string]interface{}{
+			"scraper_path": scraperPath,
+		})
+		return err
+	}
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		if progress, ok := events.ParseScrapeProgress(scanner.Text()); ok {
+			onProgress(progress)
+			continue
+		}
+		fmt.Fprintln(os.Stdout, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		logger.LogError("Échec de lecture de la sortie du scraper", err, map[string]interface{}{
+			"scraper_path": scraperPath,
+		})
+		// La sortie restante est ignorée pour que le scraper ne reste pas bloqué en écriture
+		io.Copy(io.Discard, stdout)
+	}
+	if err := cmd.Wait(); err != nil {
 		logger.LogError("Échec de l'exécution du scraper", err, map[string]interface{}{
 			"scraper_path": scraperPath,
 		})
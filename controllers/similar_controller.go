@@ -0,0 +1,92 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/responses"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// maxSimilarLimit nombre maximum de recettes similaires retournées
+const maxSimilarLimit = 50
+
+// GetSimilarRecettes retourne les recettes les plus proches par leurs ingrédients
+func (h *RecetteHandler) GetSimilarRecettes(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	if _, err := primitive.ObjectIDFromHex(id); err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > maxSimilarLimit {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "limit", 1, maxSimilarLimit)
+	}
+
+	query, err := recetteListQuery(c)
+	if err != nil {
+		logger.LogError("Paramètres de filtre invalides", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendErr(c, 400, err)
+	}
+
+	matches, found := h.similarity.Similar(id, limit)
+	if !found {
+		logger.LogInfo("Recette absente de l'index de similarité", map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 404, i18n.RecipeNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Conserver l'ordre du classement ; les filtres (régime, allergènes) s'appliquent aux recettes proposées,
+	// les recettes supprimées depuis la construction de l'index sont ignorées
+	similar := make([]responses.SimilarRecette, 0, len(matches))
+	for _, match := range matches {
+		objID, _ := primitive.ObjectIDFromHex(match.ID)
+		recette, err := h.recettes.Get(ctx, objID)
+		if errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			logger.LogError("Échec de récupération des recettes similaires", err, map[string]interface{}{
+				"request_id": requestID,
+				"recipe_id":  id,
+			})
+			return middleware.SendError(c, 500, i18n.RecipesFetchFailed)
+		}
+		if !query.Match(recette) {
+			continue
+		}
+		similar = append(similar, responses.SimilarRecette{
+			Recette:           *recette,
+			Score:             match.Score,
+			SharedIngredients: match.Shared,
+		})
+	}
+
+	logger.LogDatabase(logger.INFO, "Recettes similaires trouvées", "find_similar", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id":     requestID,
+		"recipe_id":      id,
+		"recettes_count": len(similar),
+	})
+
+	return c.Status(200).JSON(similar)
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/stats"
+)
+
+// maxStatsHistory nombre maximal d'instantanés retournés par l'historique
+const maxStatsHistory = 100
+
+// RefreshRecetteStats recalcule les statistiques du corpus (hors corbeille), les met en cache
+// et en archive un instantané. Appelé au démarrage du serveur et après chaque importation
+func (h *RecetteHandler) RefreshRecetteStats() (stats.Report, error) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report, err := h.recettes.Stats(ctx)
+	if err != nil {
+		logger.LogError("Échec du calcul des statistiques des recettes", err, nil)
+		return stats.Report{}, err
+	}
+
+	h.statsMu.Lock()
+	h.statsReport = &report
+	h.statsMu.Unlock()
+
+	if err := h.stats.Save(ctx, report); err != nil {
+		logger.LogError("Échec d'archivage des statistiques des recettes", err, nil)
+	}
+
+	logger.LogDatabase(logger.INFO, "Statistiques des recettes recalculées", "aggregate_stats", "mongodb", time.Since(start), map[string]interface{}{
+		"recettes_count": report.TotalRecipes,
+	})
+	return report, nil
+}
+
+// GetRecetteStats retourne les statistiques du corpus, calculées au besoin si le cache est vide
+func (h *RecetteHandler) GetRecetteStats(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+
+	h.statsMu.RLock()
+	cached := h.statsReport
+	h.statsMu.RUnlock()
+	if cached != nil {
+		return c.Status(200).JSON(cached)
+	}
+
+	report, err := h.RefreshRecetteStats()
+	if err != nil {
+		logger.LogError("Statistiques des recettes indisponibles", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.StatsFailed)
+	}
+	return c.Status(200).JSON(report)
+}
+
+// GetRecetteStatsHistory retourne les instantanés de statistiques, les plus récents en premier
+// limit (100 max) borne la réponse
+func (h *RecetteHandler) GetRecetteStatsHistory(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	limit := c.QueryInt("limit", 30)
+	if limit <= 0 || limit > maxStatsHistory {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "limit", 1, maxStatsHistory)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	history, err := h.stats.History(ctx, limit)
+	if err != nil {
+		logger.LogError("Échec de récupération de l'historique des statistiques", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.StatsHistoryFailed)
+	}
+	return c.Status(200).JSON(history)
+}
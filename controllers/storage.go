@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/enrich"
+	"github.com/maxime-louis14/api-golang/env"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// NewStores crée les stockages choisis par STORAGE, dans la base db pour MongoDB,
+// le stockage d'images choisi par IMAGE_STORE_BACKEND et le bus des événements
+// (EVENTS_REPLAY_SIZE derniers événements rejouables)
+// Le stockage en mémoire est alimenté au démarrage par STORAGE_SEED (fichier data.json du scraper) s'il est défini
+func NewStores(db *mongo.Database) (repository.Stores, error) {
+	var stores repository.Stores
+	switch storage := repository.Storage(); storage {
+	case repository.StorageMongo:
+		stores = repository.NewMongoStores(db)
+	case repository.StorageMemory:
+		stores = repository.NewMemoryStores()
+		if path := os.Getenv("STORAGE_SEED"); path != "" {
+			if err := seedRecettes(stores.Recettes, path); err != nil {
+				return repository.Stores{}, fmt.Errorf("STORAGE_SEED %s: %w", path, err)
+			}
+		}
+	default:
+		return repository.Stores{}, fmt.Errorf("STORAGE inconnu: %q (%s ou %s)", storage, repository.StorageMongo, repository.StorageMemory)
+	}
+	stores.Images = openImageStore()
+	stores.Events = events.NewBus(env.Int("EVENTS_REPLAY_SIZE", 1000))
+	return stores, nil
+}
+
+// seedRecettes importe les recettes d'un fichier data.json, complétées comme à l'importation
+// (régimes, allergènes, durées) mais sans historique ni contrôle de qualité
+func seedRecettes(store repository.RecipeRepository, path string) error {
+	start := time.Now()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var recettes []models.Recette
+	if err := json.Unmarshal(data, &recettes); err != nil {
+		return err
+	}
+	for i := range recettes {
+		enrich.Recette(&recettes[i])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if _, err := store.BulkImport(ctx, recettes); err != nil {
+		return err
+	}
+
+	logger.LogInfo("Stockage en mémoire alimenté", map[string]interface{}{
+		"file_path":      path,
+		"recettes_count": len(recettes),
+		"duration_ms":    time.Since(start).Milliseconds(),
+	})
+	return nil
+}
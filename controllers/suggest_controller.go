@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/suggest"
+)
+
+// maxSuggestLimit nombre maximum de suggestions retournées
+const maxSuggestLimit = 50
+
+// GetSuggestions retourne les suggestions d'autocomplétion pour la saisie q
+// type: "recipe", "ingredient" ou vide pour les deux
+func (h *RecetteHandler) GetSuggestions(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	query := c.Query("q")
+	kind := c.Query("type")
+
+	if query == "" {
+		return middleware.SendError(c, 400, i18n.ParamRequired, "q")
+	}
+	if kind != "" && kind != suggest.TypeRecipe && kind != suggest.TypeIngredient {
+		return middleware.SendError(c, 400, i18n.InvalidSuggestType)
+	}
+
+	limit := c.QueryInt("limit", 10)
+	if limit <= 0 || limit > maxSuggestLimit {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "limit", 1, maxSuggestLimit)
+	}
+
+	suggestions := h.suggest.Suggest(query, kind, limit)
+
+	logger.LogInfo("Suggestions calculées", map[string]interface{}{
+		"request_id":        requestID,
+		"query":             query,
+		"type":              kind,
+		"suggestions_count": len(suggestions),
+		"duration":          time.Since(start).String(),
+	})
+
+	return c.Status(200).JSON(suggestions)
+}
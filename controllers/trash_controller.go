@@ -0,0 +1,190 @@
+package controllers
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/env"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/repository"
+	"github.com/maxime-louis14/api-golang/responses"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Valeurs par défaut de la purge de la corbeille
+const (
+	defaultTrashRetention     = 30 * 24 * time.Hour
+	defaultTrashPurgeInterval = time.Hour
+)
+
+// trashRetention durée de conservation des recettes dans la corbeille (TRASH_RETENTION)
+var trashRetention = env.Duration("TRASH_RETENTION", defaultTrashRetention)
+
+// DeleteRecette place une recette dans la corbeille (elle reste restaurable jusqu'à la purge)
+func (h *RecetteHandler) DeleteRecette(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if err := h.recettes.Delete(ctx, objID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return middleware.SendError(c, 404, i18n.RecipeNotFound)
+		}
+		logger.LogError("Échec de suppression de la recette", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 500, i18n.RecipeDeleteFailed)
+	}
+
+	logger.LogInfo("Recette placée dans la corbeille", map[string]interface{}{
+		"request_id": requestID,
+		"recipe_id":  id,
+		"author":     requestAuthor(c),
+	})
+	h.publishRecetteEvent(events.RecetteDeleted, models.Recette{ID: objID}, requestAuthor(c))
+
+	if err := h.RefreshIndexes(); err != nil {
+		logger.LogError("Échec de reconstruction des index après suppression", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+	}
+
+	return c.SendStatus(204)
+}
+
+// RestoreRecette sort une recette de la corbeille
+func (h *RecetteHandler) RestoreRecette(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	id := c.Params("id")
+
+	objID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		logger.LogError("ID de recette invalide", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		return middleware.SendError(c, 400, i18n.InvalidRecipeID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	recette, err := h.recettes.Restore(ctx, objID)
+	if err != nil {
+		logger.LogError("Recette absente de la corbeille", err, map[string]interface{}{
+			"request_id": requestID,
+			"recipe_id":  id,
+		})
+		if errors.Is(err, repository.ErrNotFound) {
+			return middleware.SendError(c, 404, i18n.RecipeNotInTrash)
+		}
+		return middleware.SendError(c, 500, i18n.RecipeUpdateFailed)
+	}
+
+	logger.LogInfo("Recette restaurée", map[string]interface{}{
+		"request_id": requestID,
+		"recipe_id":  id,
+		"author":     requestAuthor(c),
+	})
+	h.publishRecetteEvent(events.RecetteRestored, *recette, requestAuthor(c))
+
+	if err := h.RefreshIndexes(); err != nil {
+		logger.LogError("Échec de reconstruction des index après restauration", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+	}
+
+	return c.Status(200).JSON(recette)
+}
+
+// GetTrash retourne les recettes de la corbeille, les plus récemment supprimées en premier
+func (h *RecetteHandler) GetTrash(c *fiber.Ctx) error {
+	start := time.Now()
+	requestID := c.Locals("requestID").(string)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	recettes, err := h.recettes.ListDeleted(ctx)
+	if err != nil {
+		logger.LogError("Échec de récupération de la corbeille", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.TrashFetchFailed)
+	}
+
+	items := make([]responses.TrashedRecette, 0, len(recettes))
+	for _, recette := range recettes {
+		items = append(items, responses.TrashedRecette{
+			RecetteV1: recette.V1(),
+			PurgeAt:   recette.DeletedAt.Add(trashRetention),
+		})
+	}
+
+	logger.LogDatabase(logger.INFO, "Corbeille récupérée", "find_many", "mongodb", time.Since(start), map[string]interface{}{
+		"request_id":     requestID,
+		"recettes_count": len(items),
+	})
+
+	return c.Status(200).JSON(items)
+}
+
+// StartTrashPurge lance la purge périodique des recettes restées dans la corbeille
+// au-delà de TRASH_RETENTION (vérification toutes les TRASH_PURGE_INTERVAL)
+func (h *RecetteHandler) StartTrashPurge() {
+	interval := env.Duration("TRASH_PURGE_INTERVAL", defaultTrashPurgeInterval)
+	logger.LogInfo("Purge de la corbeille planifiée", map[string]interface{}{
+		"retention": trashRetention.String(),
+		"interval":  interval.String(),
+	})
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := h.purgeTrash(time.Now().UTC().Add(-trashRetention)); err != nil {
+				logger.LogError("Échec de la purge de la corbeille", err, nil)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// purgeTrash supprime définitivement les recettes mises à la corbeille avant la date limite,
+// ainsi que leur historique de révisions
+func (h *RecetteHandler) purgeTrash(before time.Time) (int, error) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	ids, err := h.recettes.Purge(ctx, before)
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	if err := h.revisions.DeleteFor(ctx, ids); err != nil {
+		return len(ids), err
+	}
+
+	logger.LogDatabase(logger.INFO, "Corbeille purgée", "delete_many", "mongodb", time.Since(start), map[string]interface{}{
+		"recettes_count": len(ids),
+		"before":         before,
+	})
+	return len(ids), nil
+}
@@ -0,0 +1,347 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/webhooks"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// webhookEventTypes événements auxquels un webhook peut s'abonner ("import" et "scrape" désignent toute la famille)
+var webhookEventTypes = []string{
+	events.ImportStarted, events.ImportCompleted, events.ImportFailed,
+	events.ScrapeStarted, events.ScrapeCompleted, events.ScrapeFailed,
+	"import", "scrape",
+}
+
+// defaultWebhookEvents événements envoyés quand la création ne précise rien
+var defaultWebhookEvents = []string{events.ImportCompleted, events.ImportFailed, events.ScrapeCompleted, events.ScrapeFailed}
+
+// maxDeliveries nombre maximum d'entrées du journal de livraison retournées
+const maxDeliveries = 100
+
+// webhookSender envoi des webhooks avec reprise
+var webhookSender = webhooks.NewSender()
+
+// WebhookHandler handlers des webhooks et livraison des événements aux webhooks abonnés
+type WebhookHandler struct {
+	webhooks   *mongo.Collection
+	deliveries *mongo.Collection
+	events     *events.Bus
+}
+
+// NewWebhookHandler crée les handlers des webhooks sur les collections de la base MongoDB,
+// abonnés au bus des événements des recettes
+func NewWebhookHandler(db *mongo.Database, bus *events.Bus) *WebhookHandler {
+	return &WebhookHandler{
+		webhooks:   db.Collection("webhooks"),
+		deliveries: db.Collection("webhook_deliveries"),
+		events:     bus,
+	}
+}
+
+// CreateWebhookRequest corps de la requête de création de webhook
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"` // Généré si absent
+}
+
+// CreateWebhook enregistre un webhook (la clé de signature n'est retournée qu'une fois)
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+
+	var req CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidBody)
+	}
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return middleware.SendError(c, 400, i18n.InvalidWebhookURL)
+	}
+	if len(req.Events) == 0 {
+		req.Events = defaultWebhookEvents
+	}
+	for _, eventType := range req.Events {
+		if !containsString(webhookEventTypes, eventType) {
+			return middleware.SendError(c, 400, i18n.UnknownEvent, eventType, strings.Join(webhookEventTypes, ", "))
+		}
+	}
+	if req.Secret == "" {
+		if req.Secret, err = webhooks.GenerateSecret(); err != nil {
+			logger.LogError("Échec de génération de la clé de signature", err, map[string]interface{}{
+				"request_id": requestID,
+			})
+			return middleware.SendError(c, 500, i18n.WebhookCreateFailed)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	webhook := models.Webhook{
+		URL:       req.URL,
+		Events:    req.Events,
+		Secret:    req.Secret,
+		Active:    true,
+		CreatedAt: time.Now().UTC(),
+	}
+	result, err := h.webhooks.InsertOne(ctx, webhook)
+	if err != nil {
+		logger.LogError("Échec de création du webhook", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.WebhookCreateFailed)
+	}
+	webhook.ID = result.InsertedID.(primitive.ObjectID)
+
+	logger.LogInfo("Webhook créé", map[string]interface{}{
+		"request_id": requestID,
+		"webhook_id": webhook.ID.Hex(),
+		"url":        webhook.URL,
+		"events":     webhook.Events,
+	})
+
+	return c.Status(201).JSON(fiber.Map{
+		"secret":  webhook.Secret,
+		"webhook": webhook,
+	})
+}
+
+// GetWebhooks liste les webhooks enregistrés
+func (h *WebhookHandler) GetWebhooks(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := h.webhooks.Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"created_at": 1}))
+	if err != nil {
+		logger.LogError("Échec de récupération des webhooks", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.WebhooksFetchFailed)
+	}
+	list := []models.Webhook{}
+	if err := cursor.All(ctx, &list); err != nil {
+		logger.LogError("Échec de décodage des webhooks", err, map[string]interface{}{
+			"request_id": requestID,
+		})
+		return middleware.SendError(c, 500, i18n.WebhooksFetchFailed)
+	}
+
+	return c.Status(200).JSON(list)
+}
+
+// DeleteWebhook supprime un webhook (son journal de livraison est conservé)
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidWebhookID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	result, err := h.webhooks.DeleteOne(ctx, bson.M{"_id": objID})
+	if err != nil {
+		logger.LogError("Échec de suppression du webhook", err, map[string]interface{}{
+			"request_id": requestID,
+			"webhook_id": objID.Hex(),
+		})
+		return middleware.SendError(c, 500, i18n.WebhookDeleteFailed)
+	}
+	if result.DeletedCount == 0 {
+		return middleware.SendError(c, 404, i18n.WebhookNotFound)
+	}
+
+	logger.LogInfo("Webhook supprimé", map[string]interface{}{
+		"request_id": requestID,
+		"webhook_id": objID.Hex(),
+	})
+	return c.SendStatus(204)
+}
+
+// GetWebhookDeliveries retourne le journal de livraison d'un webhook, les plus récentes en premier
+// status=pending|succeeded|failed filtre les livraisons, limit (100 max) borne la réponse
+func (h *WebhookHandler) GetWebhookDeliveries(c *fiber.Ctx) error {
+	requestID := c.Locals("requestID").(string)
+	objID, err := primitive.ObjectIDFromHex(c.Params("id"))
+	if err != nil {
+		return middleware.SendError(c, 400, i18n.InvalidWebhookID)
+	}
+	limit := c.QueryInt("limit", 50)
+	if limit <= 0 || limit > maxDeliveries {
+		return middleware.SendError(c, 400, i18n.ParamOutOfRange, "limit", 1, maxDeliveries)
+	}
+
+	filter := bson.M{"webhook_id": objID}
+	switch status := c.Query("status"); status {
+	case "":
+	case models.DeliveryPending, models.DeliverySucceeded, models.DeliveryFailed:
+		filter["status"] = status
+	default:
+		return middleware.SendError(c, 400, i18n.UnknownStatus, status)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := h.deliveries.Find(ctx, filter, opts)
+	if err != nil {
+		logger.LogError("Échec de récupération des livraisons", err, map[string]interface{}{
+			"request_id": requestID,
+			"webhook_id": objID.Hex(),
+		})
+		return middleware.SendError(c, 500, i18n.DeliveriesFetchFailed)
+	}
+	deliveries := []models.WebhookDelivery{}
+	if err := cursor.All(ctx, &deliveries); err != nil {
+		logger.LogError("Échec de décodage des livraisons", err, map[string]interface{}{
+			"request_id": requestID,
+			"webhook_id": objID.Hex(),
+		})
+		return middleware.SendError(c, 500, i18n.DeliveriesFetchFailed)
+	}
+
+	// Un webhook supprimé garde son journal ; un identifiant inconnu sans livraison est une erreur
+	if len(deliveries) == 0 {
+		if err := h.webhooks.FindOne(ctx, bson.M{"_id": objID}).Err(); err != nil {
+			return middleware.SendError(c, 404, i18n.WebhookNotFound)
+		}
+	}
+
+	return c.Status(200).JSON(deliveries)
+}
+
+// StartWebhookDispatcher transmet les événements d'importation et de scraping aux webhooks abonnés
+func (h *WebhookHandler) StartWebhookDispatcher() {
+	go func() {
+		var lastID uint64
+		for {
+			// Un abonnement coupé (file pleine) reprend là où il s'était arrêté
+			sub, replay, complete := h.events.Subscribe([]string{"import", "scrape"}, lastID)
+			if !complete {
+				logger.LogError("Événements perdus pendant l'interruption des webhooks", nil, map[string]interface{}{
+					"last_event_id": lastID,
+				})
+			}
+			for _, event := range replay {
+				h.dispatchWebhooks(event)
+				lastID = event.ID
+			}
+			for event := range sub.C {
+				h.dispatchWebhooks(event)
+				lastID = event.ID
+			}
+			logger.LogError("Abonnement des webhooks interrompu, reprise", nil, map[string]interface{}{
+				"last_event_id": lastID,
+			})
+		}
+	}()
+}
+
+// dispatchWebhooks lance la livraison de l'événement à chaque webhook actif abonné
+func (h *WebhookHandler) dispatchWebhooks(event events.Event) {
+	if event.Type == events.ImportProgress || event.Type == events.ScrapeProgress {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cursor, err := h.webhooks.Find(ctx, bson.M{"active": true})
+	if err != nil {
+		logger.LogError("Échec de chargement des webhooks", err, map[string]interface{}{
+			"event_type": event.Type,
+		})
+		return
+	}
+	var subscribed []models.Webhook
+	if err := cursor.All(ctx, &subscribed); err != nil {
+		logger.LogError("Échec de décodage des webhooks", err, map[string]interface{}{
+			"event_type": event.Type,
+		})
+		return
+	}
+
+	for _, webhook := range subscribed {
+		if events.Match(webhook.Events, event.Type) {
+			go h.deliverWebhook(webhook, event)
+		}
+	}
+}
+
+// deliverWebhook envoie un événement à un webhook en consignant chaque tentative
+func (h *WebhookHandler) deliverWebhook(webhook models.Webhook, event events.Event) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	defer cancel()
+
+	delivery := models.WebhookDelivery{
+		WebhookID: webhook.ID,
+		EventID:   event.ID,
+		EventType: event.Type,
+		Status:    models.DeliveryPending,
+		Attempts:  []models.WebhookAttempt{},
+		CreatedAt: time.Now().UTC(),
+	}
+	result, err := h.deliveries.InsertOne(ctx, delivery)
+	if err != nil {
+		logger.LogError("Échec d'enregistrement de la livraison", err, map[string]interface{}{
+			"webhook_id": webhook.ID.Hex(),
+			"event_id":   event.ID,
+		})
+		return
+	}
+	delivery.ID = result.InsertedID.(primitive.ObjectID)
+
+	body, err := json.Marshal(fiber.Map{"delivery_id": delivery.ID.Hex(), "event": event})
+	if err != nil {
+		logger.LogError("Échec d'encodage de l'événement", err, map[string]interface{}{
+			"event_id": event.ID,
+		})
+		return
+	}
+
+	msg := webhooks.Message{DeliveryID: delivery.ID.Hex(), EventType: event.Type, Body: body}
+	sendErr := webhookSender.Send(ctx, webhook.URL, webhook.Secret, msg, func(attempt models.WebhookAttempt) {
+		if _, err := h.deliveries.UpdateByID(ctx, delivery.ID, bson.M{"$push": bson.M{"attempts": attempt}}); err != nil {
+			logger.LogError("Échec d'enregistrement de la tentative", err, map[string]interface{}{
+				"delivery_id": delivery.ID.Hex(),
+			})
+		}
+	})
+
+	status := models.DeliverySucceeded
+	if sendErr != nil {
+		status = models.DeliveryFailed
+		logger.LogError("Échec de livraison du webhook", sendErr, map[string]interface{}{
+			"webhook_id":  webhook.ID.Hex(),
+			"delivery_id": delivery.ID.Hex(),
+			"event_type":  event.Type,
+		})
+	}
+	// Contexte séparé : le délai global a pu expirer pendant les tentatives
+	updateCtx, cancelUpdate := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelUpdate()
+	update := bson.M{"$set": bson.M{"status": status, "completed_at": time.Now().UTC()}}
+	if _, err := h.deliveries.UpdateByID(updateCtx, delivery.ID, update); err != nil {
+		logger.LogError("Échec de mise à jour de la livraison", err, map[string]interface{}{
+			"delivery_id": delivery.ID.Hex(),
+		})
+	}
+}
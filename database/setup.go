@@ -8,10 +8,23 @@ import (
 	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/maxime-louis14/api-golang/repository"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// Valeurs par défaut quand les recettes sont stockées en mémoire (STORAGE=memory)
+const (
+	defaultMongoURL = "mongodb://localhost:27017"
+	defaultDBName   = "recettes"
+)
+
+// memoryStorage indique si les recettes sont stockées en mémoire : MongoDB reste utilisé
+// par les autres fonctionnalités (clés d'API, webhooks) mais n'est plus obligatoire au démarrage
+func memoryStorage() bool {
+	return repository.Storage() == repository.StorageMemory
+}
+
 // DBinstance initialise une connexion MongoDB et retourne un client
 func DBinstance() *mongo.Client {
 	// Charger les variables d'environnement (optionnel)
@@ -25,6 +38,10 @@ func DBinstance() *mongo.Client {
 	if MongoDb == "" {
 		// Fallback vers MONGODB_URI si MONGODB_URL n'est pas défini
 		MongoDb = os.Getenv("MONGODB_URI")
+		if MongoDb == "" && memoryStorage() {
+			// Recettes en mémoire : MongoDB local par défaut, la connexion n'est établie qu'au premier accès
+			MongoDb = defaultMongoURL
+		}
 		if MongoDb == "" {
 			log.Fatal("Neither MONGODB_URL nor MONGODB_URI is set in environment variables")
 		}
@@ -50,17 +67,19 @@ func DBinstance() *mongo.Client {
 	return client
 }
 
-// Client est une instance globale de MongoDB
-var Client *mongo.Client = DBinstance()
-
-// OpenCollection retourne une collection MongoDB
-func OpenCollection(client *mongo.Client, collectionName string) *mongo.Collection {
+// OpenDatabase retourne la base MongoDB désignée par DB_NAME
+func OpenDatabase(client *mongo.Client) *mongo.Database {
 	dbName := os.Getenv("DB_NAME") // Récupérer le nom de la base de données
+	if dbName == "" && memoryStorage() {
+		dbName = defaultDBName
+	}
 	if dbName == "" {
 		log.Fatal("DB_NAME is not set in environment variables")
 	}
+	return client.Database(dbName)
+}
 
-	// Accéder à la collection
-	collection := client.Database(dbName).Collection(collectionName)
-	return collection
+// OpenCollection retourne une collection MongoDB
+func OpenCollection(client *mongo.Client, collectionName string) *mongo.Collection {
+	return OpenDatabase(client).Collection(collectionName)
 }
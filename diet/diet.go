@@ -0,0 +1,291 @@
+package diet
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/nutrition"
+)
+
+// Régimes alimentaires reconnus
+const (
+	Vegetarian  = "vegetarian"
+	Vegan       = "vegan"
+	Pescatarian = "pescatarian"
+	GlutenFree  = "gluten-free"
+	DairyFree   = "dairy-free"
+	Keto        = "keto"
+)
+
+// rule définit un régime par les groupes qui l'interdisent et ceux qui le caractérisent
+type rule struct {
+	name       string
+	blocking   []string
+	supporting []string
+}
+
+// rules régimes évalués, dans l'ordre de la réponse
+var rules = []rule{
+	{name: Vegetarian, blocking: []string{groupMeat, groupFish}},
+	{name: Vegan, blocking: []string{groupMeat, groupFish, groupDairy, groupEgg, groupHoney}},
+	{name: Pescatarian, blocking: []string{groupMeat}, supporting: []string{groupFish}},
+	{name: GlutenFree, blocking: []string{groupGluten}},
+	{name: DairyFree, blocking: []string{groupDairy}},
+	{name: Keto, blocking: []string{groupCarbs}, supporting: []string{groupFat}},
+}
+
+// uncertainPenalty facteur appliqué à la confiance pour chaque ingrédient douteux
+const uncertainPenalty = 0.75
+
+// parenthesesPattern précisions entre parenthèses (conditionnement, marque)
+var parenthesesPattern = regexp.MustCompile(`\([^)]*\)`)
+
+// Names retourne les régimes reconnus
+func Names() []string {
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+// Valid indique si le régime est reconnu
+func Valid(name string) bool {
+	for _, r := range rules {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// analysis groupes détectés dans une ligne d'ingrédient
+type analysis struct {
+	line        string
+	definite    map[string]bool // groupes présents avec certitude
+	uncertain   map[string]bool // groupes possiblement présents
+	substituted map[string]bool // groupes remplacés par un substitut ou écartés par une mention (« almond milk », « gluten-free »)
+	known       bool            // ingrédient reconnu par le dictionnaire ou la table nutritionnelle
+}
+
+// Classify évalue chaque régime à partir des lignes d'ingrédients de la recette
+// Retourne nil si la recette n'a aucun ingrédient exploitable
+func Classify(ings []models.Ingredient) []models.DietTag {
+	var lines []analysis
+	for _, ing := range ings {
+		if line := ingredients.Text(ing); line != "" {
+			lines = append(lines, analyze(line))
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	known := 0
+	for _, a := range lines {
+		if a.known {
+			known++
+		}
+	}
+	knownRatio := float64(known) / float64(len(lines))
+
+	tags := make([]models.DietTag, 0, len(rules))
+	for _, r := range rules {
+		tag := models.DietTag{
+			Name:      r.name,
+			Triggers:  []string{},
+			Blockers:  []string{},
+			Uncertain: []string{},
+		}
+
+		for _, a := range lines {
+			switch {
+			case anyOf(a.definite, r.blocking):
+				tag.Blockers = append(tag.Blockers, a.line)
+			case anyOf(a.uncertain, r.blocking):
+				tag.Uncertain = append(tag.Uncertain, a.line)
+			case anyOf(a.definite, r.supporting), anyOf(a.uncertain, r.supporting), anyOf(a.substituted, r.blocking):
+				tag.Triggers = append(tag.Triggers, a.line)
+			}
+		}
+
+		tag.Compatible = len(tag.Blockers) == 0
+		if tag.Compatible {
+			// Les ingrédients inconnus ou douteux peuvent cacher un ingrédient interdit
+			tag.Confidence = (0.5 + 0.5*knownRatio) * math.Pow(uncertainPenalty, float64(len(tag.Uncertain)))
+		} else {
+			tag.Confidence = math.Min(1, 0.9+0.05*float64(len(tag.Blockers)))
+		}
+		tag.Confidence = math.Round(tag.Confidence*100) / 100
+
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// analyze détecte les groupes d'ingrédients d'une ligne
+// Une alternative (« butter or margarine ») ou un ingrédient optionnel rend la détection incertaine
+func analyze(line string) analysis {
+	a := analysis{
+		line:        line,
+		definite:    make(map[string]bool),
+		uncertain:   make(map[string]bool),
+		substituted: make(map[string]bool),
+	}
+
+	text := strings.ToLower(line)
+	optional := strings.Contains(text, "optional")
+	text = parenthesesPattern.ReplaceAllString(text, " ")
+
+	// Seules les alternatives reconnues comptent : "fresh or frozen shrimp" reste du poisson
+	alternatives := 0
+	counts := make(map[string]int)
+	uncertainHits := make(map[string]bool)
+	for _, alternative := range strings.Split(text, " or ") {
+		definite, uncertain, substituted, matched := matchGroups(tokenize(alternative))
+		if !matched {
+			continue
+		}
+		a.known = true
+		alternatives++
+		for group := range definite {
+			counts[group]++
+		}
+		for group := range uncertain {
+			uncertainHits[group] = true
+		}
+		for group := range substituted {
+			a.substituted[group] = true
+		}
+	}
+
+	for group, count := range counts {
+		if count == alternatives && !optional {
+			a.definite[group] = true
+		} else {
+			a.uncertain[group] = true
+		}
+	}
+	for group := range uncertainHits {
+		if !a.definite[group] {
+			a.uncertain[group] = true
+		}
+	}
+
+	if !a.known {
+		_, a.known = nutrition.DefaultTable().Lookup(ingredients.Normalize(line))
+	}
+	return a
+}
+
+// matchGroups applique mentions, exceptions et dictionnaire à une suite de mots
+func matchGroups(tokens []string) (definite, uncertain, substituted map[string]bool, matched bool) {
+	definite = make(map[string]bool)
+	uncertain = make(map[string]bool)
+	substituted = make(map[string]bool)
+
+	// Mentions valables pour toute la ligne
+	labelled := make(map[string]bool)
+	for _, label := range labels {
+		if len(find(tokens, label.phrase)) > 0 {
+			for _, group := range label.groups {
+				labelled[group] = true
+				substituted[group] = true
+			}
+		}
+	}
+
+	// Exceptions valables pour les mots qu'elles couvrent
+	covered := make([]map[string]bool, len(tokens))
+	for _, exc := range exceptions {
+		size := len(strings.Fields(exc.phrase))
+		for _, start := range find(tokens, exc.phrase) {
+			matched = true
+			for i := start; i < start+size; i++ {
+				if covered[i] == nil {
+					covered[i] = make(map[string]bool)
+				}
+				for _, group := range exc.groups {
+					covered[i][group] = true
+					if exc.substitute {
+						substituted[group] = true
+					}
+				}
+			}
+		}
+	}
+
+	for _, kw := range keywords {
+		size := len(strings.Fields(kw.phrase))
+		for _, start := range find(tokens, kw.phrase) {
+			matched = true
+			for _, group := range kw.groups {
+				if labelled[group] || isCovered(covered, start, size, group) {
+					continue
+				}
+				if kw.uncertain {
+					uncertain[group] = true
+				} else {
+					definite[group] = true
+				}
+			}
+		}
+	}
+	return definite, uncertain, substituted, matched
+}
+
+// isCovered indique si une exception couvre un des mots de l'expression pour ce groupe
+func isCovered(covered []map[string]bool, start, size int, group string) bool {
+	for i := start; i < start+size; i++ {
+		if covered[i][group] {
+			return true
+		}
+	}
+	return false
+}
+
+// find retourne les positions où l'expression apparaît dans la suite de mots
+func find(tokens []string, phrase string) []int {
+	words := strings.Fields(phrase)
+	var positions []int
+	for start := 0; start+len(words) <= len(tokens); start++ {
+		found := true
+		for i, word := range words {
+			if tokens[start+i] != word {
+				found = false
+				break
+			}
+		}
+		if found {
+			positions = append(positions, start)
+		}
+	}
+	return positions
+}
+
+// tokenize découpe une ligne en mots au singulier (les traits d'union sont conservés)
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-'
+	})
+	tokens := make([]string, 0, len(words))
+	for _, word := range words {
+		if word = strings.Trim(word, "-"); word != "" {
+			tokens = append(tokens, ingredients.Singular(word))
+		}
+	}
+	return tokens
+}
+
+func anyOf(set map[string]bool, groups []string) bool {
+	for _, group := range groups {
+		if set[group] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,96 @@
+package diet
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func lines(values ...string) []models.Ingredient {
+	ings := make([]models.Ingredient, 0, len(values))
+	for _, value := range values {
+		ings = append(ings, models.Ingredient{Quantity: value})
+	}
+	return ings
+}
+
+func tagsByName(tags []models.DietTag) map[string]models.DietTag {
+	byName := make(map[string]models.DietTag, len(tags))
+	for _, tag := range tags {
+		byName[tag.Name] = tag
+	}
+	return byName
+}
+
+func TestClassifyMeatAndDairy(t *testing.T) {
+	tags := tagsByName(Classify(lines(
+		"2 skinless, boneless chicken breasts",
+		"2 tablespoons butter",
+		"1 cup white rice",
+	)))
+	require.Len(t, tags, len(Names()))
+
+	assert.False(t, tags[Vegetarian].Compatible)
+	assert.Equal(t, []string{"2 skinless, boneless chicken breasts"}, tags[Vegetarian].Blockers)
+	assert.False(t, tags[Vegan].Compatible)
+	assert.Len(t, tags[Vegan].Blockers, 2)
+	assert.False(t, tags[DairyFree].Compatible)
+	assert.True(t, tags[GlutenFree].Compatible)
+	assert.False(t, tags[Keto].Compatible)
+	assert.Equal(t, []string{"1 cup white rice"}, tags[Keto].Blockers)
+	assert.Equal(t, []string{"2 tablespoons butter"}, tags[Keto].Triggers)
+}
+
+func TestClassifyPescatarian(t *testing.T) {
+	tags := tagsByName(Classify(lines("1 pound shrimp, peeled and deveined", "2 cloves garlic", "1 tablespoon olive oil")))
+
+	assert.True(t, tags[Pescatarian].Compatible)
+	assert.Equal(t, []string{"1 pound shrimp, peeled and deveined"}, tags[Pescatarian].Triggers)
+	assert.False(t, tags[Vegetarian].Compatible)
+	assert.Equal(t, 1.0, tags[Pescatarian].Confidence)
+}
+
+func TestClassifyExceptionsAndSubstitutes(t *testing.T) {
+	tags := tagsByName(Classify(lines(
+		"2 tablespoons peanut butter",
+		"1 cup almond milk",
+		"1 cup gluten-free flour",
+		"1 eggplant, cubed",
+		"1/2 teaspoon cream of tartar",
+	)))
+
+	assert.True(t, tags[Vegan].Compatible)
+	assert.True(t, tags[DairyFree].Compatible)
+	assert.Contains(t, tags[DairyFree].Triggers, "1 cup almond milk")
+	assert.True(t, tags[GlutenFree].Compatible)
+	assert.Equal(t, []string{"1 cup gluten-free flour"}, tags[GlutenFree].Triggers)
+}
+
+func TestClassifyUncertain(t *testing.T) {
+	tags := tagsByName(Classify(lines(
+		"2 tablespoons butter or margarine",
+		"1 teaspoon Worcestershire sauce",
+		"1/4 cup grated Parmesan cheese (optional)",
+		"2 cups rolled oats",
+	)))
+
+	// Une alternative ou un ingrédient optionnel ne bloque pas le régime mais réduit la confiance
+	assert.True(t, tags[DairyFree].Compatible)
+	assert.Len(t, tags[DairyFree].Uncertain, 2)
+	assert.Less(t, tags[DairyFree].Confidence, 0.6)
+
+	assert.True(t, tags[Vegetarian].Compatible)
+	assert.Equal(t, []string{"1 teaspoon Worcestershire sauce"}, tags[Vegetarian].Uncertain)
+
+	assert.True(t, tags[GlutenFree].Compatible)
+	assert.Equal(t, []string{"2 cups rolled oats"}, tags[GlutenFree].Uncertain)
+	assert.False(t, tags[Keto].Compatible)
+}
+
+func TestClassifyEmpty(t *testing.T) {
+	assert.Nil(t, Classify(nil))
+	assert.True(t, Valid(Vegan))
+	assert.False(t, Valid("paleo"))
+}
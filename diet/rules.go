@@ -0,0 +1,253 @@
+package diet
+
+// Groupes d'ingrédients utilisés par les règles
+const (
+	groupMeat   = "meat"
+	groupFish   = "fish"
+	groupDairy  = "dairy"
+	groupEgg    = "egg"
+	groupHoney  = "honey"
+	groupGluten = "gluten"
+	groupCarbs  = "carbs" // sucres et féculents incompatibles avec un régime cétogène
+	groupFat    = "fat"   // matières grasses typiques d'un régime cétogène
+)
+
+// keyword expression (mots au singulier) rattachée à des groupes d'ingrédients
+// uncertain : la composition varie selon le produit (sauce Worcestershire, avoine...)
+type keyword struct {
+	phrase    string
+	groups    []string
+	uncertain bool
+}
+
+// keywords dictionnaire des expressions reconnues dans les lignes d'ingrédients
+var keywords = []keyword{
+	// Viandes
+	{phrase: "beef", groups: []string{groupMeat}},
+	{phrase: "pork", groups: []string{groupMeat}},
+	{phrase: "chicken", groups: []string{groupMeat}},
+	{phrase: "turkey", groups: []string{groupMeat}},
+	{phrase: "lamb", groups: []string{groupMeat}},
+	{phrase: "veal", groups: []string{groupMeat}},
+	{phrase: "duck", groups: []string{groupMeat}},
+	{phrase: "goose", groups: []string{groupMeat}},
+	{phrase: "venison", groups: []string{groupMeat}},
+	{phrase: "rabbit", groups: []string{groupMeat}},
+	{phrase: "bacon", groups: []string{groupMeat, groupFat}},
+	{phrase: "ham", groups: []string{groupMeat}},
+	{phrase: "sausage", groups: []string{groupMeat}},
+	{phrase: "prosciutto", groups: []string{groupMeat}},
+	{phrase: "pancetta", groups: []string{groupMeat, groupFat}},
+	{phrase: "guanciale", groups: []string{groupMeat, groupFat}},
+	{phrase: "salami", groups: []string{groupMeat}},
+	{phrase: "pepperoni", groups: []string{groupMeat}},
+	{phrase: "chorizo", groups: []string{groupMeat}},
+	{phrase: "steak", groups: []string{groupMeat}},
+	{phrase: "brisket", groups: []string{groupMeat}},
+	{phrase: "sirloin", groups: []string{groupMeat}},
+	{phrase: "meatball", groups: []string{groupMeat}},
+	{phrase: "meat", groups: []string{groupMeat}},
+	{phrase: "hot dog", groups: []string{groupMeat}},
+	{phrase: "oxtail", groups: []string{groupMeat}},
+	{phrase: "liver", groups: []string{groupMeat}},
+	{phrase: "bone", groups: []string{groupMeat}},
+	{phrase: "gelatin", groups: []string{groupMeat}},
+	{phrase: "lard", groups: []string{groupMeat, groupFat}},
+	{phrase: "tallow", groups: []string{groupMeat, groupFat}},
+
+	// Poissons et fruits de mer
+	{phrase: "fish", groups: []string{groupFish}},
+	{phrase: "salmon", groups: []string{groupFish}},
+	{phrase: "tuna", groups: []string{groupFish}},
+	{phrase: "swordfish", groups: []string{groupFish}},
+	{phrase: "catfish", groups: []string{groupFish}},
+	{phrase: "cod", groups: []string{groupFish}},
+	{phrase: "tilapia", groups: []string{groupFish}},
+	{phrase: "halibut", groups: []string{groupFish}},
+	{phrase: "trout", groups: []string{groupFish}},
+	{phrase: "sardine", groups: []string{groupFish}},
+	{phrase: "anchovy", groups: []string{groupFish}},
+	{phrase: "mackerel", groups: []string{groupFish}},
+	{phrase: "shrimp", groups: []string{groupFish}},
+	{phrase: "prawn", groups: []string{groupFish}},
+	{phrase: "crab", groups: []string{groupFish}},
+	{phrase: "lobster", groups: []string{groupFish}},
+	{phrase: "clam", groups: []string{groupFish}},
+	{phrase: "mussel", groups: []string{groupFish}},
+	{phrase: "oyster", groups: []string{groupFish}},
+	{phrase: "scallop", groups: []string{groupFish}},
+	{phrase: "squid", groups: []string{groupFish}},
+	{phrase: "calamari", groups: []string{groupFish}},
+	{phrase: "octopus", groups: []string{groupFish}},
+	{phrase: "seafood", groups: []string{groupFish}},
+	{phrase: "caviar", groups: []string{groupFish}},
+	{phrase: "roe", groups: []string{groupFish}},
+	{phrase: "bonito", groups: []string{groupFish}},
+	{phrase: "dashi", groups: []string{groupFish}},
+	{phrase: "worcestershire", groups: []string{groupFish}, uncertain: true},
+
+	// Produits laitiers
+	{phrase: "milk", groups: []string{groupDairy}},
+	{phrase: "buttermilk", groups: []string{groupDairy}},
+	{phrase: "butter", groups: []string{groupDairy, groupFat}},
+	{phrase: "ghee", groups: []string{groupDairy, groupFat}},
+	{phrase: "cheese", groups: []string{groupDairy, groupFat}},
+	{phrase: "parmesan", groups: []string{groupDairy, groupFat}},
+	{phrase: "mozzarella", groups: []string{groupDairy, groupFat}},
+	{phrase: "cheddar", groups: []string{groupDairy, groupFat}},
+	{phrase: "ricotta", groups: []string{groupDairy}},
+	{phrase: "feta", groups: []string{groupDairy}},
+	{phrase: "mascarpone", groups: []string{groupDairy, groupFat}},
+	{phrase: "gruyere", groups: []string{groupDairy, groupFat}},
+	{phrase: "paneer", groups: []string{groupDairy}},
+	{phrase: "cream", groups: []string{groupDairy, groupFat}},
+	{phrase: "half-and-half", groups: []string{groupDairy}},
+	{phrase: "yogurt", groups: []string{groupDairy}},
+	{phrase: "yoghurt", groups: []string{groupDairy}},
+	{phrase: "kefir", groups: []string{groupDairy}},
+	{phrase: "whey", groups: []string{groupDairy}},
+	{phrase: "custard", groups: []string{groupDairy, groupEgg}},
+
+	// Oeufs et miel
+	{phrase: "egg", groups: []string{groupEgg}},
+	{phrase: "mayonnaise", groups: []string{groupEgg, groupFat}},
+	{phrase: "mayo", groups: []string{groupEgg, groupFat}},
+	{phrase: "meringue", groups: []string{groupEgg}},
+	{phrase: "aioli", groups: []string{groupEgg, groupFat}},
+	{phrase: "honey", groups: []string{groupHoney, groupCarbs}},
+
+	// Gluten
+	{phrase: "flour", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "wheat", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "bread", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "breadcrumb", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "bread crumb", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "panko", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "crouton", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "pasta", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "spaghetti", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "macaroni", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "penne", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "fettuccine", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "linguine", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "lasagna", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "orzo", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "ravioli", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "gnocchi", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "noodle", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "couscous", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "barley", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "rye", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "bulgur", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "semolina", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "farro", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "seitan", groups: []string{groupGluten}},
+	{phrase: "cracker", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "biscuit", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "crust", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "puff pastry", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "phyllo", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "crescent roll", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "tortilla", groups: []string{groupGluten}, uncertain: true},
+	{phrase: "tortilla", groups: []string{groupCarbs}},
+	{phrase: "pita", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "bagel", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "baguette", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "brioche", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "bun", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "pretzel", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "cake mix", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "soy sauce", groups: []string{groupGluten}},
+	{phrase: "beer", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "malt", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "oat", groups: []string{groupGluten}, uncertain: true},
+	{phrase: "oat", groups: []string{groupCarbs}},
+
+	// Sucres et féculents
+	{phrase: "sugar", groups: []string{groupCarbs}},
+	{phrase: "syrup", groups: []string{groupCarbs}},
+	{phrase: "molasses", groups: []string{groupCarbs}},
+	{phrase: "rice", groups: []string{groupCarbs}},
+	{phrase: "potato", groups: []string{groupCarbs}},
+	{phrase: "corn", groups: []string{groupCarbs}},
+	{phrase: "cornstarch", groups: []string{groupCarbs}},
+	{phrase: "cornmeal", groups: []string{groupCarbs}},
+	{phrase: "quinoa", groups: []string{groupCarbs}},
+	{phrase: "bean", groups: []string{groupCarbs}},
+	{phrase: "lentil", groups: []string{groupCarbs}},
+	{phrase: "chickpea", groups: []string{groupCarbs}},
+	{phrase: "banana", groups: []string{groupCarbs}},
+	{phrase: "date", groups: []string{groupCarbs}},
+	{phrase: "raisin", groups: []string{groupCarbs}},
+	{phrase: "juice", groups: []string{groupCarbs}},
+	{phrase: "jam", groups: []string{groupCarbs}},
+	{phrase: "jelly", groups: []string{groupCarbs}},
+	{phrase: "ketchup", groups: []string{groupCarbs}, uncertain: true},
+	{phrase: "chocolate", groups: []string{groupCarbs}, uncertain: true},
+
+	// Matières grasses
+	{phrase: "oil", groups: []string{groupFat}},
+	{phrase: "avocado", groups: []string{groupFat}},
+	{phrase: "margarine", groups: []string{groupFat}},
+}
+
+// exception expression qui neutralise certains groupes sur les mots qu'elle couvre
+// ex: "peanut butter" n'est pas un produit laitier, "green bean" n'est pas un féculent
+// substitute : produit de remplacement qui caractérise le régime ("almond milk", "rice flour")
+type exception struct {
+	phrase     string
+	groups     []string
+	substitute bool
+}
+
+// exceptions expressions prioritaires sur le dictionnaire
+var exceptions = []exception{
+	{phrase: "peanut butter", groups: []string{groupDairy}},
+	{phrase: "almond butter", groups: []string{groupDairy}, substitute: true},
+	{phrase: "cashew butter", groups: []string{groupDairy}, substitute: true},
+	{phrase: "nut butter", groups: []string{groupDairy}, substitute: true},
+	{phrase: "apple butter", groups: []string{groupDairy, groupFat}},
+	{phrase: "cocoa butter", groups: []string{groupDairy}},
+	{phrase: "coconut milk", groups: []string{groupDairy}, substitute: true},
+	{phrase: "coconut cream", groups: []string{groupDairy}, substitute: true},
+	{phrase: "almond milk", groups: []string{groupDairy}, substitute: true},
+	{phrase: "oat milk", groups: []string{groupDairy, groupGluten, groupCarbs}, substitute: true},
+	{phrase: "soy milk", groups: []string{groupDairy}, substitute: true},
+	{phrase: "rice milk", groups: []string{groupDairy}, substitute: true},
+	{phrase: "cashew milk", groups: []string{groupDairy}, substitute: true},
+	{phrase: "cream of tartar", groups: []string{groupDairy, groupFat}},
+	{phrase: "almond flour", groups: []string{groupGluten, groupCarbs}, substitute: true},
+	{phrase: "coconut flour", groups: []string{groupGluten, groupCarbs}, substitute: true},
+	{phrase: "rice flour", groups: []string{groupGluten}, substitute: true},
+	{phrase: "chickpea flour", groups: []string{groupGluten}, substitute: true},
+	{phrase: "tapioca flour", groups: []string{groupGluten}, substitute: true},
+	{phrase: "buckwheat flour", groups: []string{groupGluten}, substitute: true},
+	{phrase: "corn tortilla", groups: []string{groupGluten}, substitute: true},
+	{phrase: "rice noodle", groups: []string{groupGluten}, substitute: true},
+	{phrase: "rice vinegar", groups: []string{groupCarbs}},
+	{phrase: "green bean", groups: []string{groupCarbs}},
+	{phrase: "lemon juice", groups: []string{groupCarbs}},
+	{phrase: "lime juice", groups: []string{groupCarbs}},
+	{phrase: "corn oil", groups: []string{groupCarbs}},
+	{phrase: "bagel seasoning", groups: []string{groupGluten, groupCarbs}},
+	{phrase: "sugar snap", groups: []string{groupCarbs}},
+	{phrase: "tamari", groups: []string{groupGluten}, substitute: true},
+}
+
+// labels mentions « sans » ou végétales qui neutralisent des groupes sur toute la ligne
+var labels = []exception{
+	{phrase: "vegan", groups: []string{groupMeat, groupFish, groupDairy, groupEgg, groupHoney}},
+	{phrase: "plant-based", groups: []string{groupMeat, groupFish, groupDairy, groupEgg}},
+	{phrase: "vegetarian", groups: []string{groupMeat, groupFish}},
+	{phrase: "meatless", groups: []string{groupMeat}},
+	{phrase: "dairy-free", groups: []string{groupDairy}},
+	{phrase: "dairy free", groups: []string{groupDairy}},
+	{phrase: "non-dairy", groups: []string{groupDairy}},
+	{phrase: "egg-free", groups: []string{groupEgg}},
+	{phrase: "eggless", groups: []string{groupEgg}},
+	{phrase: "gluten-free", groups: []string{groupGluten}},
+	{phrase: "gluten free", groups: []string{groupGluten}},
+	{phrase: "sugar-free", groups: []string{groupCarbs}},
+	{phrase: "sugar free", groups: []string{groupCarbs}},
+	{phrase: "low-carb", groups: []string{groupCarbs}},
+}
@@ -0,0 +1,44 @@
+// Package domain définit le format des recettes partagé par le scraper (data.json) et l'API (MongoDB)
+// Chaque document enregistré porte la version de son schéma ; les documents écrits avec une
+// version antérieure sont mis à niveau au décodage (voir upgrade.go)
+package domain
+
+import "strings"
+
+// SchemaVersion version courante du schéma des recettes
+const SchemaVersion = 1
+
+// Recipe recette telle qu'écrite par le scraper et enregistrée par l'API
+type Recipe struct {
+	SchemaVersion int           `json:"schema_version" bson:"schema_version" swagger:"description(Version du schéma du document)"`
+	Name          string        `json:"name" bson:"name" swagger:"description(Nom de la recette)"`
+	Page          string        `json:"page" bson:"page" swagger:"description(URL de la page de la recette)"`
+	Image         string        `json:"image" bson:"image" swagger:"description(URL de l'image de la recette)"`
+	ImageKey      string        `json:"image_key,omitempty" bson:"image_key" swagger:"description(Clé de l'image copiée dans le stockage local)"`
+	Category      string        `json:"category,omitempty" bson:"category,omitempty" swagger:"description(Catégorie d'origine de la recette)"`
+	Ingredients   []Ingredient  `json:"ingredients" bson:"ingredients" swagger:"description(Liste des ingrédients de la recette)"`
+	Instructions  []Instruction `json:"instructions" bson:"instructions" swagger:"description(Liste des instructions de la recette)"`
+	PrepTime      string        `json:"prep_time,omitempty" bson:"prep_time" swagger:"description(Temps de préparation, durée ISO-8601)"`
+	CookTime      string        `json:"cook_time,omitempty" bson:"cook_time" swagger:"description(Temps de cuisson, durée ISO-8601)"`
+	TotalTime     string        `json:"total_time,omitempty" bson:"total_time" swagger:"description(Temps total, durée ISO-8601)"`
+	Servings      int           `json:"servings,omitempty" bson:"servings" swagger:"description(Nombre de portions)"`
+}
+
+// NormalizeCategory forme enregistrée d'une catégorie (minuscules, sans espaces autour),
+// comparée telle quelle par le filtre category=
+func NormalizeCategory(category string) string {
+	return strings.ToLower(strings.TrimSpace(category))
+}
+
+// Ingredient ingrédient d'une recette
+// Le scraper et les importateurs enregistrent la ligne complète dans Quantity
+type Ingredient struct {
+	Quantity string `json:"quantity" bson:"quantity" swagger:"description(Quantité de l'ingrédient)"`
+	Unit     string `json:"unit" bson:"unit" swagger:"description(Unité de mesure de l'ingrédient)"`
+}
+
+// Instruction étape d'une recette
+type Instruction struct {
+	Number      string `json:"number" bson:"number" swagger:"description(Numéro de l'instruction)"`
+	Description string `json:"description" bson:"description" swagger:"description(Description de l'instruction)"`
+}
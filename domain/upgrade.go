@@ -0,0 +1,133 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Document recette décodée sans typage (JSON ou BSON), sur laquelle s'appliquent les mises à niveau
+type Document map[string]interface{}
+
+// upgrades mises à niveau successives : upgrades[v] convertit un document de la version v en version v+1
+// Une évolution du schéma incrémente SchemaVersion et ajoute sa fonction à la fin de la liste
+var upgrades = []func(Document){
+	0: upgradeUnversioned,
+}
+
+// upgradeUnversioned met à niveau les documents écrits avant l'introduction de schema_version :
+//   - l'API exportait les étapes sous la clé "Instructions" alors que le scraper écrivait "instructions"
+//   - les étapes sans numéro sont numérotées dans l'ordre
+func upgradeUnversioned(doc Document) {
+	if steps, ok := doc["Instructions"]; ok {
+		if _, exists := doc["instructions"]; !exists {
+			doc["instructions"] = steps
+		}
+		delete(doc, "Instructions")
+	}
+	steps, _ := doc["instructions"].([]interface{})
+	for i, step := range steps {
+		fields, ok := step.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if number, _ := fields["number"].(string); strings.TrimSpace(number) == "" {
+			fields["number"] = strconv.Itoa(i + 1)
+		}
+	}
+}
+
+// Version version du schéma d'un document (0 si absente)
+func (doc Document) Version() int {
+	switch v := doc["schema_version"].(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// Upgrade applique les mises à niveau nécessaires pour amener le document à SchemaVersion
+// Un document d'une version plus récente est laissé tel quel : ses champs inconnus sont ignorés au décodage
+func (doc Document) Upgrade() {
+	version := doc.Version()
+	if version >= SchemaVersion {
+		return
+	}
+	for ; version < SchemaVersion; version++ {
+		upgrades[version](doc)
+	}
+	doc["schema_version"] = SchemaVersion
+}
+
+// UpgradeJSON met à niveau un objet JSON représentant une recette
+// Les valeurs autres qu'un objet (null notamment) sont retournées inchangées
+func UpgradeJSON(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return data, nil
+	}
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Version() >= SchemaVersion {
+		return data, nil
+	}
+	doc.Upgrade()
+	return json.Marshal(doc)
+}
+
+// UpgradeBSON met à niveau un document MongoDB représentant une recette
+// Un document déjà à la version courante est retourné sans être décodé
+func UpgradeBSON(data []byte) ([]byte, error) {
+	raw := bson.Raw(data)
+	if value, err := raw.LookupErr("schema_version"); err == nil {
+		if version, ok := value.AsInt64OK(); ok && int(version) >= SchemaVersion {
+			return data, nil
+		}
+	}
+
+	var doc bson.D
+	if err := bson.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("document de recette illisible: %w", err)
+	}
+	plain := Document(plainValue(doc).(map[string]interface{}))
+	plain.Upgrade()
+	return bson.Marshal(plain)
+}
+
+// plainValue convertit les documents et tableaux BSON en map et slice, pour que les mises à niveau
+// manipulent les mêmes types qu'en JSON
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case primitive.D:
+		doc := make(map[string]interface{}, len(v))
+		for _, elem := range v {
+			doc[elem.Key] = plainValue(elem.Value)
+		}
+		return doc
+	case primitive.M:
+		doc := make(map[string]interface{}, len(v))
+		for key, elem := range v {
+			doc[key] = plainValue(elem)
+		}
+		return doc
+	case primitive.A:
+		list := make([]interface{}, len(v))
+		for i, elem := range v {
+			list[i] = plainValue(elem)
+		}
+		return list
+	}
+	return value
+}
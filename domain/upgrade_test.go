@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestUpgradeJSONLegacyAPIExport(t *testing.T) {
+	legacy := []byte(`{"name": "Soup", "page": "https://example.com/soup",
+		"Instructions": [{"number": "", "description": "Chop."}, {"description": "Simmer."}]}`)
+
+	data, err := UpgradeJSON(legacy)
+	require.NoError(t, err)
+
+	var recipe Recipe
+	require.NoError(t, json.Unmarshal(data, &recipe))
+	assert.Equal(t, SchemaVersion, recipe.SchemaVersion)
+	assert.Equal(t, "Soup", recipe.Name)
+	assert.Equal(t, []Instruction{
+		{Number: "1", Description: "Chop."},
+		{Number: "2", Description: "Simmer."},
+	}, recipe.Instructions)
+	assert.NotContains(t, string(data), `"Instructions"`)
+}
+
+func TestUpgradeJSONScraperFile(t *testing.T) {
+	// Format de data.json avant l'introduction de schema_version
+	legacy := []byte(`{"name": "Bread", "page": "https://example.com/bread", "image": "",
+		"ingredients": [{"quantity": "3 cups flour", "unit": ""}],
+		"instructions": [{"number": "1", "description": "Knead."}], "prep_time": "PT15M"}`)
+
+	data, err := UpgradeJSON(legacy)
+	require.NoError(t, err)
+
+	var recipe Recipe
+	require.NoError(t, json.Unmarshal(data, &recipe))
+	assert.Equal(t, SchemaVersion, recipe.SchemaVersion)
+	assert.Equal(t, []Ingredient{{Quantity: "3 cups flour"}}, recipe.Ingredients)
+	assert.Equal(t, []Instruction{{Number: "1", Description: "Knead."}}, recipe.Instructions)
+	assert.Equal(t, "PT15M", recipe.PrepTime)
+}
+
+func TestUpgradeJSONUnchanged(t *testing.T) {
+	current := []byte(`{"schema_version": 1, "name": "Bread"}`)
+	data, err := UpgradeJSON(current)
+	require.NoError(t, err)
+	assert.Equal(t, current, data)
+
+	// Version plus récente : décodée telle quelle
+	future := []byte(`{"schema_version": 7, "name": "Bread"}`)
+	data, err = UpgradeJSON(future)
+	require.NoError(t, err)
+	assert.Equal(t, future, data)
+
+	data, err = UpgradeJSON([]byte("null"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("null"), data)
+
+	_, err = UpgradeJSON([]byte(`{"name": `))
+	assert.Error(t, err)
+}
+
+func TestUpgradeBSON(t *testing.T) {
+	id := primitive.NewObjectID()
+	legacy, err := bson.Marshal(bson.D{
+		{Key: "_id", Value: id},
+		{Key: "name", Value: "Soup"},
+		{Key: "instructions", Value: bson.A{
+			bson.D{{Key: "description", Value: "Chop."}},
+			bson.D{{Key: "number", Value: "2"}, {Key: "description", Value: "Simmer."}},
+		}},
+		{Key: "servings", Value: 4},
+	})
+	require.NoError(t, err)
+
+	data, err := UpgradeBSON(legacy)
+	require.NoError(t, err)
+
+	var stored struct {
+		ID     primitive.ObjectID `bson:"_id"`
+		Recipe `bson:",inline"`
+	}
+	require.NoError(t, bson.Unmarshal(data, &stored))
+	assert.Equal(t, id, stored.ID)
+	assert.Equal(t, SchemaVersion, stored.SchemaVersion)
+	assert.Equal(t, 4, stored.Servings)
+	assert.Equal(t, []Instruction{
+		{Number: "1", Description: "Chop."},
+		{Number: "2", Description: "Simmer."},
+	}, stored.Instructions)
+}
+
+func TestUpgradeBSONCurrent(t *testing.T) {
+	current, err := bson.Marshal(Recipe{SchemaVersion: SchemaVersion, Name: "Soup"})
+	require.NoError(t, err)
+
+	data, err := UpgradeBSON(current)
+	require.NoError(t, err)
+	assert.Equal(t, current, data)
+
+	_, err = UpgradeBSON([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
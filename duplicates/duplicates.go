@@ -0,0 +1,138 @@
+package duplicates
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Raisons pour lesquelles deux recettes sont considérées comme doublons
+const (
+	ReasonURL         = "canonical_url" // même page une fois l'URL normalisée
+	ReasonTitle       = "title"         // même titre normalisé
+	ReasonIngredients = "ingredients"   // même ensemble d'ingrédients
+)
+
+// titleNoise mots ignorés dans la comparaison des titres
+var titleNoise = map[string]bool{
+	"a": true, "an": true, "the": true, "recipe": true, "recipes": true,
+	"easy": true, "best": true, "simple": true, "quick": true, "classic": true, "homemade": true,
+}
+
+// Entry recette candidate à la détection de doublons
+type Entry struct {
+	ID          string
+	Name        string
+	Page        string
+	Ingredients []string // noms normalisés des ingrédients
+}
+
+// Ref recette d'une paire de doublons
+type Ref struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Page string `json:"page"`
+}
+
+// Pair deux recettes probablement identiques et les raisons de ce rapprochement
+type Pair struct {
+	Recettes [2]Ref   `json:"recettes"`
+	Reasons  []string `json:"reasons"`
+}
+
+// CanonicalURL normalise une URL de page : https, hôte en minuscules sans "www.",
+// sans paramètres, ancre ni barre oblique finale
+func CanonicalURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimRight(strings.ToLower(strings.TrimSpace(raw)), "/")
+	}
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	return "https://" + host + strings.TrimRight(strings.ToLower(u.EscapedPath()), "/")
+}
+
+// NormalizeTitle réduit un titre à ses mots significatifs, en minuscules et sans ponctuation
+func NormalizeTitle(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		if !titleNoise[word] {
+			kept = append(kept, word)
+		}
+	}
+	return strings.Join(kept, " ")
+}
+
+// Fingerprint empreinte de l'ensemble des ingrédients, indépendante de leur ordre
+// Retourne une chaîne vide pour une recette sans ingrédient
+func Fingerprint(names []string) string {
+	set := map[string]bool{}
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			set[name] = true
+		}
+	}
+	if len(set) == 0 {
+		return ""
+	}
+	unique := make([]string, 0, len(set))
+	for name := range set {
+		unique = append(unique, name)
+	}
+	sort.Strings(unique)
+	sum := sha1.Sum([]byte(strings.Join(unique, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// Find retourne les paires de recettes partageant au moins une clé (URL canonique, titre normalisé
+// ou empreinte des ingrédients), les paires ayant le plus de raisons en premier
+func Find(entries []Entry) []Pair {
+	type key struct{ reason, value string }
+	groups := map[key][]int{}
+	for i, entry := range entries {
+		for _, k := range []key{
+			{ReasonURL, CanonicalURL(entry.Page)},
+			{ReasonTitle, NormalizeTitle(entry.Name)},
+			{ReasonIngredients, Fingerprint(entry.Ingredients)},
+		} {
+			if k.value != "" {
+				groups[k] = append(groups[k], i)
+			}
+		}
+	}
+
+	reasons := map[[2]int][]string{}
+	for k, members := range groups {
+		for a := 0; a < len(members); a++ {
+			for b := a + 1; b < len(members); b++ {
+				pair := [2]int{members[a], members[b]}
+				reasons[pair] = append(reasons[pair], k.reason)
+			}
+		}
+	}
+
+	pairs := make([]Pair, 0, len(reasons))
+	for indexes, why := range reasons {
+		sort.Strings(why)
+		a, b := entries[indexes[0]], entries[indexes[1]]
+		pairs = append(pairs, Pair{
+			Recettes: [2]Ref{{ID: a.ID, Name: a.Name, Page: a.Page}, {ID: b.ID, Name: b.Name, Page: b.Page}},
+			Reasons:  why,
+		})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		if len(pairs[i].Reasons) != len(pairs[j].Reasons) {
+			return len(pairs[i].Reasons) > len(pairs[j].Reasons)
+		}
+		if pairs[i].Recettes[0].ID != pairs[j].Recettes[0].ID {
+			return pairs[i].Recettes[0].ID < pairs[j].Recettes[0].ID
+		}
+		return pairs[i].Recettes[1].ID < pairs[j].Recettes[1].ID
+	})
+	return pairs
+}
@@ -0,0 +1,60 @@
+package duplicates
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	tests := map[string]string{
+		"https://www.allrecipes.com/recipe/123/soup/":          "https://allrecipes.com/recipe/123/soup",
+		"http://allrecipes.com/Recipe/123/Soup?utm_source=x#a": "https://allrecipes.com/recipe/123/soup",
+		" https://WWW.AllRecipes.com/recipe/123/soup ":         "https://allrecipes.com/recipe/123/soup",
+		"/recipe/123/": "/recipe/123",
+		"":             "",
+	}
+	for input, expected := range tests {
+		assert.Equal(t, expected, CanonicalURL(input), input)
+	}
+}
+
+func TestNormalizeTitle(t *testing.T) {
+	assert.Equal(t, "chicken noodle soup", NormalizeTitle("Easy Chicken-Noodle Soup Recipe"))
+	assert.Equal(t, "chicken noodle soup", NormalizeTitle("The Best  Chicken Noodle Soup!"))
+	assert.Equal(t, "crème brûlée", NormalizeTitle("Crème Brûlée"))
+	assert.Equal(t, "", NormalizeTitle("The Best Recipe"))
+}
+
+func TestFingerprint(t *testing.T) {
+	assert.Equal(t, Fingerprint([]string{"salt", "butter", "egg"}), Fingerprint([]string{"egg", "salt", "butter", "salt"}))
+	assert.NotEqual(t, Fingerprint([]string{"salt", "butter"}), Fingerprint([]string{"salt", "butter", "egg"}))
+	assert.Equal(t, "", Fingerprint([]string{" ", ""}))
+}
+
+func TestFind(t *testing.T) {
+	entries := []Entry{
+		{ID: "1", Name: "Chicken Soup", Page: "https://www.allrecipes.com/recipe/1/chicken-soup/", Ingredients: []string{"chicken", "carrot"}},
+		{ID: "2", Name: "Easy Chicken Soup", Page: "https://allrecipes.com/recipe/1/chicken-soup", Ingredients: []string{"carrot", "chicken"}},
+		{ID: "3", Name: "Beef Stew", Page: "https://allrecipes.com/recipe/3/beef-stew", Ingredients: []string{"chicken", "carrot"}},
+		{ID: "4", Name: "Tomato Soup", Page: "https://allrecipes.com/recipe/4/tomato-soup"},
+		{ID: "5", Name: "Bread", Page: "https://allrecipes.com/recipe/5/bread"},
+	}
+
+	pairs := Find(entries)
+	require.Len(t, pairs, 3)
+
+	assert.Equal(t, "1", pairs[0].Recettes[0].ID)
+	assert.Equal(t, "2", pairs[0].Recettes[1].ID)
+	assert.Equal(t, []string{ReasonURL, ReasonIngredients, ReasonTitle}, pairs[0].Reasons)
+
+	assert.Equal(t, [2]string{"1", "3"}, [2]string{pairs[1].Recettes[0].ID, pairs[1].Recettes[1].ID})
+	assert.Equal(t, []string{ReasonIngredients}, pairs[1].Reasons)
+	assert.Equal(t, [2]string{"2", "3"}, [2]string{pairs[2].Recettes[0].ID, pairs[2].Recettes[1].ID})
+}
+
+func TestFindNoDuplicates(t *testing.T) {
+	assert.Empty(t, Find(nil))
+	assert.Empty(t, Find([]Entry{{ID: "1", Name: "Soup"}, {ID: "2", Name: "Bread"}}))
+}
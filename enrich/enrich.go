@@ -0,0 +1,45 @@
+// Package enrich calcule les données dérivées d'une recette, enregistrées avec elle
+// pour les filtres, les tris et les statistiques
+package enrich
+
+import (
+	"time"
+
+	"github.com/maxime-louis14/api-golang/allergens"
+	"github.com/maxime-louis14/api-golang/diet"
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/isoduration"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// Recette normalise la catégorie et calcule les données dérivées (ingrédients normalisés, régimes, allergènes, durées en minutes)
+// Appelé à chaque importation pour que ces données suivent la recette
+func Recette(recette *models.Recette) {
+	recette.Category = domain.NormalizeCategory(recette.Category)
+	recette.IngredientNames = unique(ingredients.Names(recette.Ingredients))
+	recette.Diets = diet.Classify(recette.Ingredients)
+	recette.Allergens = allergens.DefaultDictionary().Detect(recette.Ingredients)
+
+	// Les durées ISO-8601 sont converties en minutes pour les filtres et les tris
+	recette.PrepMinutes = isoduration.Minutes(recette.PrepTime)
+	recette.CookMinutes = isoduration.Minutes(recette.CookTime)
+	recette.TotalMinutes = isoduration.Minutes(recette.TotalTime)
+	if recette.TotalMinutes == 0 && recette.PrepMinutes+recette.CookMinutes > 0 {
+		recette.TotalMinutes = recette.PrepMinutes + recette.CookMinutes
+		recette.TotalTime = isoduration.Format(time.Duration(recette.TotalMinutes) * time.Minute)
+	}
+}
+
+// unique retire les doublons en conservant l'ordre
+func unique(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
@@ -0,0 +1,33 @@
+package enrich
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecette(t *testing.T) {
+	recette := models.Recette{Recipe: domain.Recipe{
+		Category: " Desserts ",
+		Ingredients: []models.Ingredient{
+			{Quantity: "3 eggs"},
+			{Quantity: "2 large eggs"},
+			{Quantity: "1 cup flour"},
+		},
+		PrepTime: "PT15M",
+		CookTime: "PT25M",
+	}}
+	Recette(&recette)
+
+	assert.Equal(t, "desserts", recette.Category)
+	assert.Len(t, recette.IngredientNames, 2)
+	assert.NotEmpty(t, recette.Diets)
+	assert.Equal(t, []string{"gluten", "eggs"}, recette.Allergens)
+	assert.Equal(t, 15, recette.PrepMinutes)
+	assert.Equal(t, 25, recette.CookMinutes)
+	// Temps total déduit de la préparation et de la cuisson
+	assert.Equal(t, 40, recette.TotalMinutes)
+	assert.Equal(t, "PT40M", recette.TotalTime)
+}
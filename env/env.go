@@ -0,0 +1,55 @@
+// Package env lit la configuration depuis les variables d'environnement, avec des valeurs par défaut
+package env
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/isoduration"
+	"github.com/maxime-louis14/api-golang/logger"
+)
+
+// Int lit une variable d'environnement entière strictement positive avec une valeur par défaut
+func Int(name string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value > 0 {
+		return value
+	}
+	return fallback
+}
+
+// Count lit une variable d'environnement entière positive ou nulle avec une valeur par défaut
+func Count(name string, fallback int) int {
+	if value, err := strconv.Atoi(os.Getenv(name)); err == nil && value >= 0 {
+		return value
+	}
+	return fallback
+}
+
+// Bool lit un booléen ("true", "false", "1", "0"...) avec une valeur par défaut
+func Bool(name string, fallback bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(name)); err == nil {
+		return value
+	}
+	return fallback
+}
+
+// Duration lit une durée Go ("720h") ou ISO-8601 ("P30D") avec une valeur par défaut
+func Duration(name string, fallback time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	if d, err := isoduration.Parse(value); err == nil && d > 0 {
+		return d
+	}
+	logger.LogError("Durée invalide, valeur par défaut utilisée", nil, map[string]interface{}{
+		"variable": name,
+		"value":    value,
+		"default":  fallback.String(),
+	})
+	return fallback
+}
@@ -0,0 +1,33 @@
+package env
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInt(t *testing.T) {
+	t.Setenv("TEST_INT", "12")
+	assert.Equal(t, 12, Int("TEST_INT", 5))
+	t.Setenv("TEST_INT", "0")
+	assert.Equal(t, 5, Int("TEST_INT", 5))
+	assert.Equal(t, 0, Count("TEST_INT", 5))
+	t.Setenv("TEST_INT", "abc")
+	assert.Equal(t, 5, Count("TEST_INT", 5))
+}
+
+func TestBool(t *testing.T) {
+	assert.True(t, Bool("TEST_BOOL_UNSET", true))
+	t.Setenv("TEST_BOOL", "0")
+	assert.False(t, Bool("TEST_BOOL", true))
+}
+
+func TestDuration(t *testing.T) {
+	t.Setenv("TEST_DURATION", "90m")
+	assert.Equal(t, 90*time.Minute, Duration("TEST_DURATION", time.Hour))
+	t.Setenv("TEST_DURATION", "P2D")
+	assert.Equal(t, 48*time.Hour, Duration("TEST_DURATION", time.Hour))
+	t.Setenv("TEST_DURATION", "-1h")
+	assert.Equal(t, time.Hour, Duration("TEST_DURATION", time.Hour))
+}
@@ -0,0 +1,186 @@
+package events
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Types d'événements publiés par l'API
+const (
+	RecetteCreated  = "recette.created"
+	RecetteUpdated  = "recette.updated"
+	RecetteDeleted  = "recette.deleted"
+	RecetteRestored = "recette.restored"
+
+	ImportStarted   = "import.started"
+	ImportProgress  = "import.progress"
+	ImportCompleted = "import.completed"
+	ImportFailed    = "import.failed"
+
+	ScrapeStarted   = "scrape.started"
+	ScrapeProgress  = "scrape.progress"
+	ScrapeCompleted = "scrape.completed"
+	ScrapeFailed    = "scrape.failed"
+)
+
+// subscriptionBuffer nombre d'événements en attente par abonné avant sa déconnexion
+const subscriptionBuffer = 64
+
+// Event événement diffusé aux abonnés
+type Event struct {
+	ID   uint64      `json:"id"`
+	Type string      `json:"type"`
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data,omitempty"`
+}
+
+// ErrInvalidCursor position de reprise mal formée
+var ErrInvalidCursor = errors.New("position de reprise invalide")
+
+// Bus diffuse les événements aux abonnés et conserve les derniers pour la reprise
+// Les identifiants repartent de 1 à chaque démarrage : l'époque distingue les bus successifs
+type Bus struct {
+	mu          sync.Mutex
+	size        int
+	epoch       string
+	lastID      uint64
+	buffer      []Event
+	subscribers map[*Subscription]struct{}
+}
+
+// Subscription abonnement à un bus d'événements
+// C est fermé quand l'abonnement est clos, ou quand l'abonné ne suit plus le rythme
+type Subscription struct {
+	C     <-chan Event
+	Start uint64 // dernier identifiant publié à l'abonnement
+	ch    chan Event
+	types []string
+	bus   *Bus
+}
+
+// NewBus crée un bus conservant les size derniers événements
+func NewBus(size int) *Bus {
+	if size < 1 {
+		size = 1
+	}
+	return &Bus{
+		size:        size,
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		subscribers: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish diffuse un événement à tous les abonnés intéressés
+// Un abonné dont la file est pleine est déconnecté plutôt que de bloquer les écritures
+func (b *Bus) Publish(eventType string, data interface{}) Event {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastID++
+	event := Event{ID: b.lastID, Type: eventType, Time: time.Now().UTC(), Data: data}
+	b.buffer = append(b.buffer, event)
+	if len(b.buffer) > b.size {
+		b.buffer = b.buffer[len(b.buffer)-b.size:]
+	}
+
+	for sub := range b.subscribers {
+		if !Match(sub.types, eventType) {
+			continue
+		}
+		select {
+		case sub.ch <- event:
+		default:
+			b.remove(sub)
+		}
+	}
+	return event
+}
+
+// Epoch époque du bus, propre au processus
+func (b *Bus) Epoch() string {
+	return b.epoch
+}
+
+// Cursor position de reprise correspondant à l'identifiant d'un événement de ce bus ("<époque>-<id>")
+func (b *Bus) Cursor(id uint64) string {
+	return fmt.Sprintf("%s-%d", b.epoch, id)
+}
+
+// ParseCursor décode une position de reprise ; current indique si elle provient de ce bus
+// Une position d'un autre processus (époque différente) n'est pas une erreur mais ne peut être reprise
+func (b *Bus) ParseCursor(cursor string) (id uint64, current bool, err error) {
+	epoch, value, ok := strings.Cut(cursor, "-")
+	if !ok || epoch == "" {
+		return 0, false, ErrInvalidCursor
+	}
+	if id, err = strconv.ParseUint(value, 10, 64); err != nil {
+		return 0, false, ErrInvalidCursor
+	}
+	return id, epoch == b.epoch, nil
+}
+
+// Subscribe s'abonne aux événements des types demandés (tous si vide)
+// Les événements conservés postérieurs à lastID sont retournés pour être rejoués (aucun si lastID vaut 0)
+// complete est faux quand des événements postérieurs à lastID ne sont plus conservés ou que lastID est inconnu :
+// l'abonné doit alors se resynchroniser entièrement
+func (b *Bus) Subscribe(types []string, lastID uint64) (sub *Subscription, replay []Event, complete bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ch := make(chan Event, subscriptionBuffer)
+	sub = &Subscription{C: ch, Start: b.lastID, ch: ch, types: types, bus: b}
+	b.subscribers[sub] = struct{}{}
+
+	if lastID == 0 {
+		return sub, nil, true
+	}
+	if lastID > b.lastID || (len(b.buffer) > 0 && lastID+1 < b.buffer[0].ID) {
+		return sub, nil, false
+	}
+	for _, event := range b.buffer {
+		if event.ID > lastID && Match(types, event.Type) {
+			replay = append(replay, event)
+		}
+	}
+	return sub, replay, true
+}
+
+// Close met fin à l'abonnement
+func (s *Subscription) Close() {
+	s.bus.mu.Lock()
+	defer s.bus.mu.Unlock()
+	s.bus.remove(s)
+}
+
+// remove retire un abonné et ferme son canal (verrou déjà pris)
+func (b *Bus) remove(sub *Subscription) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.ch)
+	}
+}
+
+// Subscribers retourne le nombre d'abonnés actifs
+func (b *Bus) Subscribers() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.subscribers)
+}
+
+// Match indique si le type d'événement correspond au filtre
+// Un filtre vide accepte tout, "recette" accepte toute la famille "recette.*"
+func Match(types []string, eventType string) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if t == eventType || strings.HasPrefix(eventType, t+".") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,105 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishSubscribe(t *testing.T) {
+	bus := NewBus(10)
+	sub, replay, complete := bus.Subscribe([]string{"recette"}, 0)
+	defer sub.Close()
+	assert.Empty(t, replay)
+	assert.True(t, complete)
+
+	bus.Publish(ImportStarted, nil)
+	created := bus.Publish(RecetteCreated, map[string]string{"id": "1"})
+
+	event := <-sub.C
+	assert.Equal(t, created.ID, event.ID)
+	assert.Equal(t, RecetteCreated, event.Type)
+	assert.Len(t, sub.C, 0)
+}
+
+func TestReplay(t *testing.T) {
+	bus := NewBus(3)
+	for i := 0; i < 5; i++ {
+		bus.Publish(RecetteUpdated, i)
+	}
+	bus.Publish(ImportCompleted, nil)
+
+	// Seuls les 3 derniers événements sont conservés
+	sub, replay, complete := bus.Subscribe(nil, 3)
+	defer sub.Close()
+	assert.True(t, complete)
+	assert.Equal(t, uint64(6), sub.Start)
+	require.Len(t, replay, 3)
+	assert.Equal(t, uint64(4), replay[0].ID)
+	assert.Equal(t, uint64(6), replay[2].ID)
+
+	sub2, replay, complete := bus.Subscribe([]string{RecetteUpdated}, 4)
+	defer sub2.Close()
+	assert.True(t, complete)
+	require.Len(t, replay, 1)
+	assert.Equal(t, uint64(5), replay[0].ID)
+
+	// Événements perdus ou identifiant inconnu : resynchronisation complète
+	sub3, replay, complete := bus.Subscribe(nil, 2)
+	defer sub3.Close()
+	assert.False(t, complete)
+	assert.Empty(t, replay)
+
+	sub4, replay, complete := bus.Subscribe(nil, 7)
+	defer sub4.Close()
+	assert.False(t, complete)
+	assert.Empty(t, replay)
+}
+
+func TestCursor(t *testing.T) {
+	bus := NewBus(10)
+	event := bus.Publish(RecetteCreated, nil)
+
+	id, current, err := bus.ParseCursor(bus.Cursor(event.ID))
+	require.NoError(t, err)
+	assert.True(t, current)
+	assert.Equal(t, event.ID, id)
+
+	// Position d'un processus précédent
+	id, current, err = bus.ParseCursor("0abc-1")
+	require.NoError(t, err)
+	assert.False(t, current)
+	assert.Equal(t, uint64(1), id)
+
+	for _, cursor := range []string{"", "12", "-1", "abc-", "abc-x"} {
+		_, _, err = bus.ParseCursor(cursor)
+		assert.ErrorIs(t, err, ErrInvalidCursor, cursor)
+	}
+}
+
+func TestSlowSubscriberIsDropped(t *testing.T) {
+	bus := NewBus(10)
+	sub, _, _ := bus.Subscribe(nil, 0)
+	for i := 0; i < subscriptionBuffer+1; i++ {
+		bus.Publish(ImportProgress, i)
+	}
+	assert.Equal(t, 0, bus.Subscribers())
+
+	count := 0
+	for range sub.C {
+		count++
+	}
+	assert.Equal(t, subscriptionBuffer, count)
+
+	// Fermer un abonnement déjà retiré est sans effet
+	sub.Close()
+}
+
+func TestMatch(t *testing.T) {
+	assert.True(t, Match(nil, RecetteCreated))
+	assert.True(t, Match([]string{"recette"}, RecetteDeleted))
+	assert.True(t, Match([]string{ImportProgress}, ImportProgress))
+	assert.False(t, Match([]string{"recette"}, ImportProgress))
+	assert.False(t, Match([]string{"rec"}, RecetteCreated))
+}
@@ -0,0 +1,37 @@
+package events
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ScrapeProgressPrefix préfixe des lignes d'avancement écrites par le scraper sur sa sortie standard,
+// lues par l'API pour publier les événements scrape.progress
+const ScrapeProgressPrefix = "@scrape.progress "
+
+// ScrapeProgressLine avancement du scraper après le traitement d'une recette
+type ScrapeProgressLine struct {
+	Found     int    `json:"found"`
+	Completed int    `json:"completed"`
+	Failed    int    `json:"failed"`
+	Recipe    string `json:"recipe,omitempty"`
+	Page      string `json:"page,omitempty"`
+}
+
+// FormatScrapeProgress écrit l'avancement sur une ligne (sans retour à la ligne)
+func FormatScrapeProgress(progress ScrapeProgressLine) string {
+	data, _ := json.Marshal(progress)
+	return ScrapeProgressPrefix + string(data)
+}
+
+// ParseScrapeProgress lit une ligne d'avancement ; false si la ligne est une sortie ordinaire du scraper
+func ParseScrapeProgress(line string) (ScrapeProgressLine, bool) {
+	var progress ScrapeProgressLine
+	if !strings.HasPrefix(line, ScrapeProgressPrefix) {
+		return progress, false
+	}
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(line, ScrapeProgressPrefix)), &progress); err != nil {
+		return progress, false
+	}
+	return progress, true
+}
@@ -0,0 +1,21 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScrapeProgressRoundTrip(t *testing.T) {
+	progress := ScrapeProgressLine{Found: 40, Completed: 12, Failed: 1, Recipe: "Soupe", Page: "https://example.com/soupe"}
+	line := FormatScrapeProgress(progress)
+
+	parsed, ok := ParseScrapeProgress(line)
+	assert.True(t, ok)
+	assert.Equal(t, progress, parsed)
+
+	_, ok = ParseScrapeProgress("✅ Recette #12 complétée: 'Soupe'")
+	assert.False(t, ok)
+	_, ok = ParseScrapeProgress(ScrapeProgressPrefix + "{invalide")
+	assert.False(t, ok)
+}
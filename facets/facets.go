@@ -0,0 +1,243 @@
+package facets
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Count nombre de recettes pour une valeur de facette
+type Count struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// Facets décomptes des recettes correspondant aux filtres courants
+type Facets struct {
+	Category     []Count `json:"category"`
+	Diets        []Count `json:"diets"`        // recettes compatibles avec chaque régime
+	Ingredients  []Count `json:"ingredients"`  // tranches de nombre d'ingrédients
+	Instructions []Count `json:"instructions"` // tranches de nombre d'étapes
+	TotalTime    []Count `json:"total_time"`   // tranches de temps total en minutes
+}
+
+// bucket découpage d'un champ numérique en tranches
+// Chaque borne est la valeur minimale (incluse) de sa tranche, la dernière tranche est ouverte
+type bucket struct {
+	name       string
+	expression interface{}
+	value      func(r *models.Recette) int // équivalent de expression pour Compute
+	bounds     []int
+	unknown    string // libellé de la tranche commençant à 0 (vide : tranche numérique)
+}
+
+// unbounded borne supérieure de la dernière tranche
+const unbounded = math.MaxInt32
+
+var buckets = []bucket{
+	{
+		name:       "ingredients",
+		expression: bson.M{"$size": bson.M{"$ifNull": bson.A{"$ingredients", bson.A{}}}},
+		value:      func(r *models.Recette) int { return len(r.Ingredients) },
+		bounds:     []int{0, 1, 6, 11, 16, 21},
+	},
+	{
+		name:       "instructions",
+		expression: bson.M{"$size": bson.M{"$ifNull": bson.A{"$instructions", bson.A{}}}},
+		value:      func(r *models.Recette) int { return len(r.Instructions) },
+		bounds:     []int{0, 1, 4, 7, 11},
+	},
+	{
+		name:       "total_time",
+		expression: bson.M{"$ifNull": bson.A{"$total_minutes", 0}},
+		value:      func(r *models.Recette) int { return r.TotalMinutes },
+		bounds:     []int{0, 1, 16, 31, 61, 121},
+		unknown:    "unknown",
+	},
+}
+
+// Stage retourne l'étape $facet calculant toutes les facettes en une passe
+func Stage() bson.D {
+	facet := bson.M{
+		"category": bson.A{
+			bson.M{"$match": bson.M{"category": bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$sortByCount": "$category"},
+		},
+		"diets": bson.A{
+			bson.M{"$unwind": "$diets"},
+			bson.M{"$match": bson.M{"diets.compatible": true}},
+			bson.M{"$sortByCount": "$diets.name"},
+		},
+	}
+	for _, b := range buckets {
+		boundaries := make(bson.A, 0, len(b.bounds)+1)
+		for _, bound := range b.bounds {
+			boundaries = append(boundaries, bound)
+		}
+		boundaries = append(boundaries, unbounded)
+		facet[b.name] = bson.A{bson.M{"$bucket": bson.M{
+			"groupBy":    b.expression,
+			"boundaries": boundaries,
+			"default":    "other",
+		}}}
+	}
+	return bson.D{{Key: "$facet", Value: facet}}
+}
+
+// rawCount décompte brut retourné par $sortByCount ou $bucket
+type rawCount struct {
+	ID    interface{} `bson:"_id"`
+	Count int         `bson:"count"`
+}
+
+// Decode convertit le document produit par Stage (nil si l'agrégation n'a rien retourné)
+func Decode(doc bson.Raw) (Facets, error) {
+	var raw map[string][]rawCount
+	if len(doc) > 0 {
+		if err := bson.Unmarshal(doc, &raw); err != nil {
+			return Facets{}, err
+		}
+	}
+	return decodeRaw(raw), nil
+}
+
+// decodeRaw convertit les décomptes bruts par facette
+func decodeRaw(raw map[string][]rawCount) Facets {
+	result := Facets{
+		Category: counts(raw["category"]),
+		Diets:    counts(raw["diets"]),
+	}
+	for _, b := range buckets {
+		ranges := b.decode(raw[b.name])
+		switch b.name {
+		case "ingredients":
+			result.Ingredients = ranges
+		case "instructions":
+			result.Instructions = ranges
+		case "total_time":
+			result.TotalTime = ranges
+		}
+	}
+	return result
+}
+
+// Compute calcule les facettes d'une liste de recettes déjà filtrée, sans passer par MongoDB
+// Même résultat que Decode sur l'agrégation Stage des mêmes recettes
+func Compute(recettes []models.Recette) Facets {
+	raw := map[string][]rawCount{}
+	categories := map[string]int{}
+	diets := map[string]int{}
+	bucketed := make([]map[int]int, len(buckets))
+	for i := range buckets {
+		bucketed[i] = map[int]int{}
+	}
+
+	for i := range recettes {
+		recette := &recettes[i]
+		if recette.Category != "" {
+			categories[recette.Category]++
+		}
+		for _, tag := range recette.Diets {
+			if tag.Compatible {
+				diets[tag.Name]++
+			}
+		}
+		for j, b := range buckets {
+			if lower, ok := b.lower(b.value(recette)); ok {
+				bucketed[j][lower]++
+			}
+		}
+	}
+
+	for value, count := range categories {
+		raw["category"] = append(raw["category"], rawCount{ID: value, Count: count})
+	}
+	for value, count := range diets {
+		raw["diets"] = append(raw["diets"], rawCount{ID: value, Count: count})
+	}
+	for i, b := range buckets {
+		for lower, count := range bucketed[i] {
+			raw[b.name] = append(raw[b.name], rawCount{ID: int32(lower), Count: count})
+		}
+	}
+	return decodeRaw(raw)
+}
+
+// counts convertit un $sortByCount, trié par nombre décroissant puis par valeur
+func counts(raw []rawCount) []Count {
+	result := make([]Count, 0, len(raw))
+	for _, r := range raw {
+		if value, ok := r.ID.(string); ok {
+			result = append(result, Count{Value: value, Count: r.Count})
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Value < result[j].Value
+	})
+	return result
+}
+
+// decode associe chaque tranche à son libellé, tranches vides comprises
+func (b bucket) decode(raw []rawCount) []Count {
+	result := make([]Count, len(b.bounds))
+	for i := range b.bounds {
+		result[i] = Count{Value: b.label(i)}
+	}
+	for _, r := range raw {
+		lower, ok := toInt(r.ID)
+		if !ok {
+			continue
+		}
+		for i, bound := range b.bounds {
+			if bound == lower {
+				result[i].Count += r.Count
+			}
+		}
+	}
+	return result
+}
+
+// lower borne inférieure de la tranche contenant value (false si value est négative, "other" de $bucket)
+func (b bucket) lower(value int) (int, bool) {
+	for i := len(b.bounds) - 1; i >= 0; i-- {
+		if value >= b.bounds[i] {
+			return b.bounds[i], true
+		}
+	}
+	return 0, false
+}
+
+// label libellé de la tranche i ("1-5", "21+", ou le libellé des valeurs inconnues)
+func (b bucket) label(i int) string {
+	lower := b.bounds[i]
+	if lower == 0 && b.unknown != "" {
+		return b.unknown
+	}
+	if i == len(b.bounds)-1 {
+		return strconv.Itoa(lower) + "+"
+	}
+	upper := b.bounds[i+1] - 1
+	if upper == lower {
+		return strconv.Itoa(lower)
+	}
+	return strconv.Itoa(lower) + "-" + strconv.Itoa(upper)
+}
+
+// toInt convertit une borne numérique décodée depuis BSON
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
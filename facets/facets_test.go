@@ -0,0 +1,102 @@
+package facets
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStage(t *testing.T) {
+	stage := Stage()
+	require.Len(t, stage, 1)
+	assert.Equal(t, "$facet", stage[0].Key)
+
+	facet := stage[0].Value.(bson.M)
+	for _, name := range []string{"category", "diets", "ingredients", "instructions", "total_time"} {
+		assert.Contains(t, facet, name)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	doc, err := bson.Marshal(bson.M{
+		"category": bson.A{
+			bson.M{"_id": "soup", "count": 3},
+			bson.M{"_id": "desserts", "count": 5},
+			bson.M{"_id": "drinks", "count": 3},
+		},
+		"diets": bson.A{
+			bson.M{"_id": "vegetarian", "count": int64(7)},
+		},
+		"ingredients": bson.A{
+			bson.M{"_id": int32(1), "count": 2},
+			bson.M{"_id": int32(6), "count": 6},
+			bson.M{"_id": int32(21), "count": 1},
+		},
+		"instructions": bson.A{
+			bson.M{"_id": int32(0), "count": 1},
+			bson.M{"_id": int32(4), "count": 8},
+		},
+		"total_time": bson.A{
+			bson.M{"_id": int32(0), "count": 4},
+			bson.M{"_id": int32(31), "count": 5},
+		},
+	})
+	require.NoError(t, err)
+
+	facets, err := Decode(doc)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Count{{"desserts", 5}, {"drinks", 3}, {"soup", 3}}, facets.Category)
+	assert.Equal(t, []Count{{"vegetarian", 7}}, facets.Diets)
+	assert.Equal(t, []Count{{"0", 0}, {"1-5", 2}, {"6-10", 6}, {"11-15", 0}, {"16-20", 0}, {"21+", 1}}, facets.Ingredients)
+	assert.Equal(t, []Count{{"0", 1}, {"1-3", 0}, {"4-6", 8}, {"7-10", 0}, {"11+", 0}}, facets.Instructions)
+	assert.Equal(t, []Count{{"unknown", 4}, {"1-15", 0}, {"16-30", 0}, {"31-60", 5}, {"61-120", 0}, {"121+", 0}}, facets.TotalTime)
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	doc, err := bson.Marshal(bson.M{})
+	require.NoError(t, err)
+
+	facets, err := Decode(doc)
+	require.NoError(t, err)
+	assert.Empty(t, facets.Category)
+	assert.NotNil(t, facets.Category)
+	assert.Len(t, facets.TotalTime, 6)
+
+	facets, err = Decode(nil)
+	require.NoError(t, err)
+	assert.Len(t, facets.Ingredients, 6)
+}
+
+func TestCompute(t *testing.T) {
+	recettes := []models.Recette{
+		{
+			Recipe:       domain.Recipe{Category: "soup", Ingredients: make([]models.Ingredient, 3), Instructions: make([]models.Instruction, 4)},
+			Diets:        []models.DietTag{{Name: "vegan", Compatible: true}, {Name: "keto"}},
+			TotalMinutes: 20,
+		},
+		{
+			Recipe:       domain.Recipe{Category: "soup", Ingredients: make([]models.Ingredient, 21)},
+			Diets:        []models.DietTag{{Name: "vegan", Compatible: true}},
+			TotalMinutes: 180,
+		},
+		{
+			Recipe: domain.Recipe{Category: "desserts"},
+		},
+	}
+
+	facets := Compute(recettes)
+	assert.Equal(t, []Count{{"soup", 2}, {"desserts", 1}}, facets.Category)
+	assert.Equal(t, []Count{{"vegan", 2}}, facets.Diets)
+	assert.Equal(t, []Count{{"0", 1}, {"1-5", 1}, {"6-10", 0}, {"11-15", 0}, {"16-20", 0}, {"21+", 1}}, facets.Ingredients)
+	assert.Equal(t, []Count{{"0", 2}, {"1-3", 0}, {"4-6", 1}, {"7-10", 0}, {"11+", 0}}, facets.Instructions)
+	assert.Equal(t, []Count{{"unknown", 1}, {"1-15", 0}, {"16-30", 1}, {"31-60", 0}, {"61-120", 0}, {"121+", 1}}, facets.TotalTime)
+
+	empty := Compute(nil)
+	assert.NotNil(t, empty.Category)
+	assert.Len(t, empty.TotalTime, 6)
+}
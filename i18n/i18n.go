@@ -0,0 +1,103 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultLanguage langue des réponses lorsque le client n'en demande aucune de disponible
+const DefaultLanguage = "fr"
+
+// Code identifiant stable d'un message, indépendant de sa traduction
+type Code string
+
+// Languages retourne les langues disponibles, triées
+func Languages() []string {
+	languages := make([]string, 0, len(catalogue))
+	for language := range catalogue {
+		languages = append(languages, language)
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// Message retourne le message traduit dans la langue demandée (ou la langue par défaut)
+// Les arguments de type *Error sont eux-mêmes traduits dans la même langue
+func Message(language string, code Code, args ...interface{}) string {
+	messages, ok := catalogue[language]
+	if !ok {
+		language = DefaultLanguage
+		messages = catalogue[DefaultLanguage]
+	}
+	format, ok := messages[code]
+	if !ok {
+		format, ok = catalogue[DefaultLanguage][code]
+	}
+	if !ok {
+		return string(code)
+	}
+	if len(args) == 0 {
+		return format
+	}
+
+	translated := make([]interface{}, len(args))
+	for i, arg := range args {
+		if e, ok := arg.(*Error); ok {
+			translated[i] = Message(language, e.Code, e.Args...)
+		} else {
+			translated[i] = arg
+		}
+	}
+	return fmt.Sprintf(format, translated...)
+}
+
+// Error erreur destinée au client, traduite au moment de la réponse
+type Error struct {
+	Code Code
+	Args []interface{}
+}
+
+// Errorf crée une erreur traduisible avec les arguments du message
+func Errorf(code Code, args ...interface{}) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+// Error retourne le message dans la langue par défaut
+func (e *Error) Error() string {
+	return Message(DefaultLanguage, e.Code, e.Args...)
+}
+
+// Negotiate choisit la langue disponible préférée d'après un en-tête Accept-Language
+// ("en-US,en;q=0.9,fr;q=0.8") ; retourne la langue par défaut si aucune ne convient
+func Negotiate(header string) string {
+	best, bestQuality := DefaultLanguage, 0.0
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		language := tag
+		if i := strings.IndexByte(tag, '-'); i > 0 {
+			language = tag[:i]
+		}
+		if tag == "*" {
+			language = DefaultLanguage
+		}
+		if _, ok := catalogue[language]; ok && quality > bestQuality {
+			best, bestQuality = language, quality
+		}
+	}
+	return best
+}
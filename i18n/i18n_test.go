@@ -0,0 +1,51 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogueComplete(t *testing.T) {
+	assert.Equal(t, []string{"en", "fr"}, Languages())
+	for code := range catalogue[DefaultLanguage] {
+		for _, language := range Languages() {
+			message, ok := catalogue[language][code]
+			assert.True(t, ok, "%s sans traduction en %s", code, language)
+			assert.Equal(t, strings.Count(catalogue[DefaultLanguage][code], "%"), strings.Count(message, "%"), "%s en %s", code, language)
+		}
+	}
+}
+
+func TestMessage(t *testing.T) {
+	assert.Equal(t, "Recette introuvable", Message("fr", RecipeNotFound))
+	assert.Equal(t, "Recipe not found", Message("en", RecipeNotFound))
+	assert.Equal(t, "Recette introuvable", Message("de", RecipeNotFound))
+	assert.Equal(t, "The limit parameter must be between 1 and 100", Message("en", ParamOutOfRange, "limit", 1, 100))
+	assert.Equal(t, "unknown_code", Message("en", Code("unknown_code")))
+}
+
+func TestMessageNestedError(t *testing.T) {
+	err := Errorf(BulkInvalidValue, "servings", Errorf(ValuePositiveInteger))
+	assert.Equal(t, "Valeur invalide pour servings : un nombre entier positif est attendu", err.Error())
+	assert.Equal(t, "Invalid value for servings: a positive integer is expected", Message("en", err.Code, err.Args...))
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]string{
+		"":                        "fr",
+		"en":                      "en",
+		"en-US,en;q=0.9":          "en",
+		"fr-FR,fr;q=0.9,en;q=0.8": "fr",
+		"de-DE,en;q=0.5,fr;q=0.7": "fr",
+		"de-DE,es;q=0.5":          "fr",
+		"EN-gb":                   "en",
+		"*":                       "fr",
+		"en;q=0.2, fr;q=0":        "en",
+		"fr;q=invalid, en;q=0.5":  "fr",
+	}
+	for header, expected := range tests {
+		assert.Equal(t, expected, Negotiate(header), header)
+	}
+}
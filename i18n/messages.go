@@ -0,0 +1,373 @@
+package i18n
+
+// Codes des messages retournés aux clients
+const (
+	// Requêtes
+	InvalidRequest  Code = "invalid_request"
+	InvalidBody     Code = "invalid_body"
+	ParamRequired   Code = "param_required"
+	ParamOutOfRange Code = "param_out_of_range"
+
+	// Recettes
+	InvalidRecipeID     Code = "invalid_recipe_id"
+	RecipeNotFound      Code = "recipe_not_found"
+	RecipeNameRequired  Code = "recipe_name_required"
+	RecipesFetchFailed  Code = "recipes_fetch_failed"
+	RecipesDecodeFailed Code = "recipes_decode_failed"
+	RecipeUpdateFailed  Code = "recipe_update_failed"
+	RecipeDeleteFailed  Code = "recipe_delete_failed"
+	RecipeNotInTrash    Code = "recipe_not_in_trash"
+	TrashFetchFailed    Code = "trash_fetch_failed"
+	FacetsFailed        Code = "facets_failed"
+	InvalidSuggestType  Code = "invalid_suggest_type"
+
+	// Filtres et tri
+	UnknownDiet          Code = "unknown_diet"
+	UnknownAllergen      Code = "unknown_allergen"
+	InvalidDurationParam Code = "invalid_duration_param"
+	UnknownSort          Code = "unknown_sort"
+
+	// Révisions
+	RevisionsFetchFailed   Code = "revisions_fetch_failed"
+	RevisionNotFound       Code = "revision_not_found"
+	InvalidRevisionNumbers Code = "invalid_revision_numbers"
+
+	// Statistiques
+	StatsFailed        Code = "stats_failed"
+	StatsHistoryFailed Code = "stats_history_failed"
+
+	// Images
+	ImageStoreUnavailable Code = "image_store_unavailable"
+	ImageNotMirrored      Code = "image_not_mirrored"
+	ImageNotFound         Code = "image_not_found"
+	ImageReadFailed       Code = "image_read_failed"
+	ImageTooLarge         Code = "image_too_large"
+	RecipeWithoutImage    Code = "recipe_without_image"
+
+	// Fiches imprimables
+	UnknownPrintFormat Code = "unknown_print_format"
+	PrintFailed        Code = "print_failed"
+	PrintIngredients   Code = "print_ingredients"
+	PrintInstructions  Code = "print_instructions"
+	PrintServings      Code = "print_servings"
+	PrintTotalTime     Code = "print_total_time"
+	PrintSource        Code = "print_source"
+
+	// Importation et scraper
+	ImportFileNotFound   Code = "import_file_not_found"
+	ImportFileOpenFailed Code = "import_file_open_failed"
+	ImportFileReadFailed Code = "import_file_read_failed"
+	ImportDecodeFailed   Code = "import_decode_failed"
+	ImportInsertFailed   Code = "import_insert_failed"
+	RecipesImported      Code = "recipes_imported"
+	ScraperFailed        Code = "scraper_failed"
+	ScraperCompleted     Code = "scraper_completed"
+	UnknownImportFormat  Code = "unknown_import_format"
+	ImportBodyRequired   Code = "import_body_required"
+	ImportParseFailed    Code = "import_parse_failed"
+
+	// Opérations en masse
+	BulkFilterRequired   Code = "bulk_filter_required"
+	BulkFieldNotEditable Code = "bulk_field_not_editable"
+	BulkInvalidValue     Code = "bulk_invalid_value"
+	BulkUnknownOperation Code = "bulk_unknown_operation"
+	BulkSelectFailed     Code = "bulk_select_failed"
+	BulkFailed           Code = "bulk_failed"
+	ValueNameEmpty       Code = "value_name_empty"
+	ValuePositiveInteger Code = "value_positive_integer"
+	ValueString          Code = "value_string"
+	ValueDuration        Code = "value_duration"
+
+	// Événements
+	InvalidLastEventID Code = "invalid_last_event_id"
+
+	// Clés d'API
+	APIKeyInvalid     Code = "api_key_invalid"
+	APIKeyCheckFailed Code = "api_key_check_failed"
+	APIKeyRequired    Code = "api_key_required"
+	InsufficientScope Code = "insufficient_scope"
+	RateLimited       Code = "rate_limited"
+	KeyNameRequired   Code = "key_name_required"
+	UnknownScope      Code = "unknown_scope"
+	InvalidRateLimit  Code = "invalid_rate_limit"
+	InvalidKeyID      Code = "invalid_key_id"
+	KeyNotFound       Code = "key_not_found"
+	KeyCreateFailed   Code = "key_create_failed"
+	KeysFetchFailed   Code = "keys_fetch_failed"
+	KeyFetchFailed    Code = "key_fetch_failed"
+	KeyRevokeFailed   Code = "key_revoke_failed"
+	KeyRevoked        Code = "key_revoked"
+
+	// Webhooks
+	InvalidWebhookURL     Code = "invalid_webhook_url"
+	UnknownEvent          Code = "unknown_event"
+	WebhookCreateFailed   Code = "webhook_create_failed"
+	WebhooksFetchFailed   Code = "webhooks_fetch_failed"
+	InvalidWebhookID      Code = "invalid_webhook_id"
+	WebhookNotFound       Code = "webhook_not_found"
+	WebhookDeleteFailed   Code = "webhook_delete_failed"
+	DeliveriesFetchFailed Code = "deliveries_fetch_failed"
+	UnknownStatus         Code = "unknown_status"
+
+	// Qualité
+	QualityReportFailed    Code = "quality_report_failed"
+	UnknownRule            Code = "unknown_rule"
+	InvalidQuarantineID    Code = "invalid_quarantine_id"
+	QuarantineNotFound     Code = "quarantine_not_found"
+	QuarantineFixFailed    Code = "quarantine_fix_failed"
+	QuarantineDeleteFailed Code = "quarantine_delete_failed"
+	PromoteFailed          Code = "promote_failed"
+
+	// Doublons
+	DuplicatesFailed       Code = "duplicates_failed"
+	UnknownReason          Code = "unknown_reason"
+	InvalidCanonicalID     Code = "invalid_canonical_id"
+	InvalidDuplicateID     Code = "invalid_duplicate_id"
+	DuplicateRequired      Code = "duplicate_required"
+	CanonicalSelfDuplicate Code = "canonical_self_duplicate"
+	CanonicalNotFound      Code = "canonical_not_found"
+	DuplicateNotFound      Code = "duplicate_not_found"
+	MergeFailed            Code = "merge_failed"
+
+	// Serveur
+	MetricsFailed      Code = "metrics_failed"
+	IndexesCheckFailed Code = "indexes_check_failed"
+)
+
+// catalogue messages par langue ; les arguments suivent la syntaxe de fmt
+var catalogue = map[string]map[Code]string{
+	"fr": {
+		InvalidRequest:  "Requête invalide : %s",
+		InvalidBody:     "Corps de requête invalide",
+		ParamRequired:   "Le paramètre %s est obligatoire",
+		ParamOutOfRange: "Le paramètre %s doit être compris entre %d et %d",
+
+		InvalidRecipeID:     "ID de recette invalide",
+		RecipeNotFound:      "Recette introuvable",
+		RecipeNameRequired:  "Le nom de la recette est obligatoire",
+		RecipesFetchFailed:  "Erreur lors de la récupération des recettes",
+		RecipesDecodeFailed: "Erreur lors du décodage des recettes",
+		RecipeUpdateFailed:  "Erreur lors de la mise à jour de la recette",
+		RecipeDeleteFailed:  "Erreur lors de la suppression de la recette",
+		RecipeNotInTrash:    "Recette absente de la corbeille",
+		TrashFetchFailed:    "Erreur lors de la récupération de la corbeille",
+		FacetsFailed:        "Erreur lors du calcul des facettes",
+		InvalidSuggestType:  "Le paramètre type doit valoir recipe ou ingredient",
+
+		UnknownDiet:          "Régime inconnu : %s (valeurs possibles : %s)",
+		UnknownAllergen:      "Allergène inconnu : %s (valeurs possibles : %s)",
+		InvalidDurationParam: "Durée invalide pour %s : %s (ex: 30m, 1h30m, PT45M)",
+		UnknownSort:          "Tri inconnu : %s (valeurs possibles : %s)",
+
+		RevisionsFetchFailed:   "Erreur lors de la récupération des révisions",
+		RevisionNotFound:       "Révision introuvable : %s",
+		InvalidRevisionNumbers: "Les numéros de révision doivent être des entiers positifs",
+
+		StatsFailed:        "Erreur lors du calcul des statistiques",
+		StatsHistoryFailed: "Erreur lors de la récupération de l'historique des statistiques",
+
+		ImageStoreUnavailable: "Stockage d'images indisponible",
+		ImageNotMirrored:      "L'image de cette recette n'a pas encore été copiée",
+		ImageNotFound:         "Image introuvable",
+		ImageReadFailed:       "Erreur lors de la lecture de l'image",
+		ImageTooLarge:         "Image trop grande pour être redimensionnée",
+		RecipeWithoutImage:    "Cette recette n'a pas d'image",
+
+		UnknownPrintFormat: "Format d'impression inconnu : %s (html, md ou pdf)",
+		PrintFailed:        "Erreur lors de la mise en page de la recette",
+		PrintIngredients:   "Ingrédients",
+		PrintInstructions:  "Préparation",
+		PrintServings:      "Portions",
+		PrintTotalTime:     "Temps total",
+		PrintSource:        "Source",
+
+		ImportFileNotFound:   "Erreur lors de la localisation du fichier data.json",
+		ImportFileOpenFailed: "Erreur lors de l'ouverture du fichier data.json",
+		ImportFileReadFailed: "Erreur lors de la lecture du fichier data.json",
+		ImportDecodeFailed:   "Erreur lors du décodage des données JSON",
+		ImportInsertFailed:   "Erreur lors de l'insertion des recettes",
+		RecipesImported:      "Recettes ajoutées avec succès",
+		ScraperFailed:        "Erreur lors de l'exécution du scraper",
+		ScraperCompleted:     "Scraper exécuté avec succès",
+		UnknownImportFormat:  "Format d'importation inconnu : %s (disponibles : %s)",
+		ImportBodyRequired:   "Le fichier à importer est vide",
+		ImportParseFailed:    "Le fichier n'a pas pu être lu au format %s",
+
+		BulkFilterRequired:   "Au moins un critère de filtre est obligatoire (category, ingredient, missing_instructions, import_run)",
+		BulkFieldNotEditable: "Champ non modifiable : %s (valeurs possibles : %s)",
+		BulkInvalidValue:     "Valeur invalide pour %s : %v",
+		BulkUnknownOperation: "Opération inconnue : %s (valeurs possibles : %s)",
+		BulkSelectFailed:     "Erreur lors de la sélection des recettes",
+		BulkFailed:           "Erreur lors de l'opération en masse",
+		ValueNameEmpty:       "le nom ne peut pas être vide",
+		ValuePositiveInteger: "un nombre entier positif est attendu",
+		ValueString:          "une chaîne de caractères est attendue",
+		ValueDuration:        "durée invalide (ex: 30m, 1h30m, PT45M)",
+
+		InvalidLastEventID: "Last-Event-ID invalide : %s",
+
+		APIKeyInvalid:     "Clé d'API invalide ou révoquée",
+		APIKeyCheckFailed: "Erreur lors de la vérification de la clé d'API",
+		APIKeyRequired:    "Clé d'API requise",
+		InsufficientScope: "Scope insuffisant: %s requis",
+		RateLimited:       "Trop de requêtes, réessayez dans %d secondes",
+		KeyNameRequired:   "Le nom de la clé est obligatoire",
+		UnknownScope:      "Scope inconnu: %s",
+		InvalidRateLimit:  "La limite de débit doit être positive",
+		InvalidKeyID:      "ID de clé invalide",
+		KeyNotFound:       "Clé d'API introuvable",
+		KeyCreateFailed:   "Erreur lors de la création de la clé d'API",
+		KeysFetchFailed:   "Erreur lors de la récupération des clés d'API",
+		KeyFetchFailed:    "Erreur lors de la récupération de la clé d'API",
+		KeyRevokeFailed:   "Erreur lors de la révocation de la clé d'API",
+		KeyRevoked:        "Clé d'API révoquée",
+
+		InvalidWebhookURL:     "URL de webhook invalide (http ou https attendu)",
+		UnknownEvent:          "Événement inconnu : %s (valeurs possibles : %s)",
+		WebhookCreateFailed:   "Erreur lors de la création du webhook",
+		WebhooksFetchFailed:   "Erreur lors de la récupération des webhooks",
+		InvalidWebhookID:      "ID de webhook invalide",
+		WebhookNotFound:       "Webhook introuvable",
+		WebhookDeleteFailed:   "Erreur lors de la suppression du webhook",
+		DeliveriesFetchFailed: "Erreur lors de la récupération des livraisons",
+		UnknownStatus:         "Statut inconnu : %s",
+
+		QualityReportFailed:    "Erreur lors de la génération du rapport de qualité",
+		UnknownRule:            "Règle inconnue : %s",
+		InvalidQuarantineID:    "ID de quarantaine invalide",
+		QuarantineNotFound:     "Recette en quarantaine introuvable",
+		QuarantineFixFailed:    "Erreur lors de la correction de la recette",
+		QuarantineDeleteFailed: "Erreur lors de la suppression de la recette en quarantaine",
+		PromoteFailed:          "Erreur lors de la promotion de la recette",
+
+		DuplicatesFailed:       "Erreur lors de la recherche des doublons",
+		UnknownReason:          "Raison inconnue : %s",
+		InvalidCanonicalID:     "ID de recette canonique invalide",
+		InvalidDuplicateID:     "ID de doublon invalide : %s",
+		DuplicateRequired:      "Au moins un doublon est requis",
+		CanonicalSelfDuplicate: "La recette canonique ne peut pas être son propre doublon",
+		CanonicalNotFound:      "Recette canonique introuvable",
+		DuplicateNotFound:      "Doublon introuvable",
+		MergeFailed:            "Erreur lors de la fusion des recettes",
+
+		MetricsFailed:      "Erreur lors de la récupération des métriques",
+		IndexesCheckFailed: "Erreur lors de la vérification des index",
+	},
+	"en": {
+		InvalidRequest:  "Invalid request: %s",
+		InvalidBody:     "Invalid request body",
+		ParamRequired:   "The %s parameter is required",
+		ParamOutOfRange: "The %s parameter must be between %d and %d",
+
+		InvalidRecipeID:     "Invalid recipe ID",
+		RecipeNotFound:      "Recipe not found",
+		RecipeNameRequired:  "The recipe name is required",
+		RecipesFetchFailed:  "Error while retrieving recipes",
+		RecipesDecodeFailed: "Error while decoding recipes",
+		RecipeUpdateFailed:  "Error while updating the recipe",
+		RecipeDeleteFailed:  "Error while deleting the recipe",
+		RecipeNotInTrash:    "Recipe is not in the trash",
+		TrashFetchFailed:    "Error while retrieving the trash",
+		FacetsFailed:        "Error while computing facets",
+		InvalidSuggestType:  "The type parameter must be recipe or ingredient",
+
+		UnknownDiet:          "Unknown diet: %s (allowed values: %s)",
+		UnknownAllergen:      "Unknown allergen: %s (allowed values: %s)",
+		InvalidDurationParam: "Invalid duration for %s: %s (e.g. 30m, 1h30m, PT45M)",
+		UnknownSort:          "Unknown sort: %s (allowed values: %s)",
+
+		RevisionsFetchFailed:   "Error while retrieving revisions",
+		RevisionNotFound:       "Revision not found: %s",
+		InvalidRevisionNumbers: "Revision numbers must be positive integers",
+
+		StatsFailed:        "Error while computing statistics",
+		StatsHistoryFailed: "Error while retrieving the statistics history",
+
+		ImageStoreUnavailable: "Image storage unavailable",
+		ImageNotMirrored:      "The image of this recipe has not been copied yet",
+		ImageNotFound:         "Image not found",
+		ImageReadFailed:       "Error while reading the image",
+		ImageTooLarge:         "Image too large to be resized",
+		RecipeWithoutImage:    "This recipe has no image",
+
+		UnknownPrintFormat: "Unknown print format: %s (html, md or pdf)",
+		PrintFailed:        "Error while laying out the recipe",
+		PrintIngredients:   "Ingredients",
+		PrintInstructions:  "Instructions",
+		PrintServings:      "Servings",
+		PrintTotalTime:     "Total time",
+		PrintSource:        "Source",
+
+		ImportFileNotFound:   "Error while locating the data.json file",
+		ImportFileOpenFailed: "Error while opening the data.json file",
+		ImportFileReadFailed: "Error while reading the data.json file",
+		ImportDecodeFailed:   "Error while decoding the JSON data",
+		ImportInsertFailed:   "Error while inserting recipes",
+		RecipesImported:      "Recipes imported successfully",
+		ScraperFailed:        "Error while running the scraper",
+		ScraperCompleted:     "Scraper ran successfully",
+		UnknownImportFormat:  "Unknown import format: %s (available: %s)",
+		ImportBodyRequired:   "The file to import is empty",
+		ImportParseFailed:    "The file could not be read as %s",
+
+		BulkFilterRequired:   "At least one filter criterion is required (category, ingredient, missing_instructions, import_run)",
+		BulkFieldNotEditable: "Field cannot be edited: %s (allowed values: %s)",
+		BulkInvalidValue:     "Invalid value for %s: %v",
+		BulkUnknownOperation: "Unknown operation: %s (allowed values: %s)",
+		BulkSelectFailed:     "Error while selecting recipes",
+		BulkFailed:           "Error while running the bulk operation",
+		ValueNameEmpty:       "the name cannot be empty",
+		ValuePositiveInteger: "a positive integer is expected",
+		ValueString:          "a string is expected",
+		ValueDuration:        "invalid duration (e.g. 30m, 1h30m, PT45M)",
+
+		InvalidLastEventID: "Invalid Last-Event-ID: %s",
+
+		APIKeyInvalid:     "Invalid or revoked API key",
+		APIKeyCheckFailed: "Error while checking the API key",
+		APIKeyRequired:    "API key required",
+		InsufficientScope: "Insufficient scope: %s required",
+		RateLimited:       "Too many requests, retry in %d seconds",
+		KeyNameRequired:   "The key name is required",
+		UnknownScope:      "Unknown scope: %s",
+		InvalidRateLimit:  "The rate limit must be positive",
+		InvalidKeyID:      "Invalid key ID",
+		KeyNotFound:       "API key not found",
+		KeyCreateFailed:   "Error while creating the API key",
+		KeysFetchFailed:   "Error while retrieving API keys",
+		KeyFetchFailed:    "Error while retrieving the API key",
+		KeyRevokeFailed:   "Error while revoking the API key",
+		KeyRevoked:        "API key revoked",
+
+		InvalidWebhookURL:     "Invalid webhook URL (http or https expected)",
+		UnknownEvent:          "Unknown event: %s (allowed values: %s)",
+		WebhookCreateFailed:   "Error while creating the webhook",
+		WebhooksFetchFailed:   "Error while retrieving webhooks",
+		InvalidWebhookID:      "Invalid webhook ID",
+		WebhookNotFound:       "Webhook not found",
+		WebhookDeleteFailed:   "Error while deleting the webhook",
+		DeliveriesFetchFailed: "Error while retrieving deliveries",
+		UnknownStatus:         "Unknown status: %s",
+
+		QualityReportFailed:    "Error while generating the quality report",
+		UnknownRule:            "Unknown rule: %s",
+		InvalidQuarantineID:    "Invalid quarantine ID",
+		QuarantineNotFound:     "Quarantined recipe not found",
+		QuarantineFixFailed:    "Error while fixing the recipe",
+		QuarantineDeleteFailed: "Error while deleting the quarantined recipe",
+		PromoteFailed:          "Error while promoting the recipe",
+
+		DuplicatesFailed:       "Error while searching for duplicates",
+		UnknownReason:          "Unknown reason: %s",
+		InvalidCanonicalID:     "Invalid canonical recipe ID",
+		InvalidDuplicateID:     "Invalid duplicate ID: %s",
+		DuplicateRequired:      "At least one duplicate is required",
+		CanonicalSelfDuplicate: "The canonical recipe cannot be its own duplicate",
+		CanonicalNotFound:      "Canonical recipe not found",
+		DuplicateNotFound:      "Duplicate not found",
+		MergeFailed:            "Error while merging recipes",
+
+		MetricsFailed:      "Error while retrieving metrics",
+		IndexesCheckFailed: "Error while checking indexes",
+	},
+}
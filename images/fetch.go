@@ -0,0 +1,149 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// MaxImageSize taille maximale d'une image téléchargée
+const MaxImageSize = 10 << 20
+
+// maxRedirects nombre maximal de redirections suivies pour télécharger une image
+const maxRedirects = 5
+
+// ErrForbiddenURL est retournée pour une URL d'image qui n'est pas http(s) ou qui désigne
+// une adresse interne (boucle locale, réseau privé, lien local...)
+var ErrForbiddenURL = errors.New("URL d'image non autorisée")
+
+// blockedNetworks plages d'adresses non couvertes par les méthodes de net.IP et jamais contactées
+var blockedNetworks = parseNetworks(
+	"0.0.0.0/8",     // « ce réseau »
+	"100.64.0.0/10", // NAT des opérateurs
+	"192.0.0.0/24",  // Affectations IETF
+	"198.18.0.0/15", // Tests de performance
+	"64:ff9b::/96",  // Traduction NAT64 vers IPv4
+)
+
+// parseNetworks convertit une liste de plages CIDR
+func parseNetworks(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks = append(networks, network)
+	}
+	return networks
+}
+
+// PublicIP indique si l'adresse peut être contactée pour télécharger une image
+func PublicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsMulticast() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() {
+		return false
+	}
+	for _, network := range blockedNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL vérifie qu'une URL d'image est en http(s) et désigne un hôte
+// Les adresses internes sont refusées à la connexion (après résolution DNS) par le client par défaut
+func CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrForbiddenURL
+	}
+	return nil
+}
+
+// publicOnly refuse la connexion à une adresse interne, vérifiée après la résolution DNS
+// pour qu'un nom pointant vers le réseau interne ne contourne pas la vérification
+func publicOnly(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || !PublicIP(ip) {
+		return ErrForbiddenURL
+	}
+	return nil
+}
+
+// defaultClient client HTTP utilisé pour la copie des images : adresses publiques uniquement,
+// sans proxy, redirections vérifiées comme l'URL d'origine
+var defaultClient = &http.Client{
+	Timeout: 20 * time.Second,
+	Transport: &http.Transport{
+		DialContext:         (&net.Dialer{Timeout: 10 * time.Second, Control: publicOnly}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+		MaxIdleConns:        10,
+		IdleConnTimeout:     90 * time.Second,
+	},
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("téléchargement de l'image: plus de %d redirections", maxRedirects)
+		}
+		return CheckURL(req.URL.String())
+	},
+}
+
+// Download télécharge une image et vérifie qu'il s'agit d'un format supporté
+// Sans client fourni, seules les adresses publiques sont contactées
+func Download(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	if err := CheckURL(url); err != nil {
+		return nil, err
+	}
+	if client == nil {
+		client = defaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("téléchargement de l'image: statut HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxImageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageSize {
+		return nil, fmt.Errorf("image trop volumineuse (plus de %d octets)", MaxImageSize)
+	}
+	if _, err := Key(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Mirror télécharge une image distante et l'enregistre dans le stockage
+// Retourne la clé de l'image copiée
+func Mirror(ctx context.Context, store Store, client *http.Client, url string) (string, error) {
+	data, err := Download(ctx, client, url)
+	if err != nil {
+		return "", err
+	}
+	return store.Put(ctx, data)
+}
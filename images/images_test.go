@@ -0,0 +1,217 @@
+package images
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestLocalStoreContentAddressed(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	data := testPNG(t, 40, 20)
+	key, err := store.Put(ctx, data)
+	require.NoError(t, err)
+	assert.True(t, ValidKey(key))
+	assert.Equal(t, "image/png", ContentType(key))
+
+	// Même contenu, même clé
+	again, err := store.Put(ctx, data)
+	require.NoError(t, err)
+	assert.Equal(t, key, again)
+
+	stored, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	_, err = store.Get(ctx, "0000000000000000000000000000000000000000000000000000000000000000.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = store.Get(ctx, "../../etc/passwd")
+	assert.ErrorIs(t, err, ErrInvalidKey)
+
+	_, err = store.Put(ctx, []byte("pas une image"))
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestLocalStoreVariants(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	key, err := store.Put(ctx, testPNG(t, 40, 20))
+	require.NoError(t, err)
+
+	_, err = store.GetVariant(ctx, key, "w10.png")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.PutVariant(ctx, key, "w10.png", []byte("miniature")))
+	variant, err := store.GetVariant(ctx, key, "w10.png")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("miniature"), variant)
+
+	assert.Error(t, store.PutVariant(ctx, key, "../w10.png", []byte("x")))
+}
+
+func TestResize(t *testing.T) {
+	data := testPNG(t, 400, 200)
+	key, err := Key(data)
+	require.NoError(t, err)
+
+	assert.Equal(t, "w100.png", VariantName(key, 100))
+	resized, err := Resize(data, key, 100)
+	require.NoError(t, err)
+
+	img, format, err := image.Decode(bytes.NewReader(resized))
+	require.NoError(t, err)
+	assert.Equal(t, "png", format)
+	assert.Equal(t, 100, img.Bounds().Dx())
+	assert.Equal(t, 50, img.Bounds().Dy())
+
+	// Pas d'agrandissement
+	resized, err = Resize(data, key, 1000)
+	require.NoError(t, err)
+	img, _, err = image.Decode(bytes.NewReader(resized))
+	require.NoError(t, err)
+	assert.Equal(t, 400, img.Bounds().Dx())
+}
+
+func TestVariantWidth(t *testing.T) {
+	assert.Equal(t, 64, VariantWidth(MinWidth))
+	assert.Equal(t, 512, VariantWidth(300))
+	assert.Equal(t, 512, VariantWidth(512))
+	assert.Equal(t, 2048, VariantWidth(MaxWidth))
+}
+
+func TestResizeJPEG(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 300, 300)), nil))
+	key, err := Key(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", ContentType(key))
+
+	resized, err := Resize(buf.Bytes(), key, 30)
+	require.NoError(t, err)
+	assert.Equal(t, "image/jpeg", http.DetectContentType(resized))
+}
+
+// hugePNG en-tête PNG valide déclarant des dimensions énormes, sans les pixels correspondants
+func hugePNG(width, height uint32) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("\x89PNG\r\n\x1a\n")
+	ihdr := make([]byte, 13)
+	binary.BigEndian.PutUint32(ihdr[0:], width)
+	binary.BigEndian.PutUint32(ihdr[4:], height)
+	ihdr[8], ihdr[9] = 8, 6 // 8 bits par canal, RGBA
+	chunk := append([]byte("IHDR"), ihdr...)
+	binary.Write(&buf, binary.BigEndian, uint32(len(ihdr)))
+	buf.Write(chunk)
+	binary.Write(&buf, binary.BigEndian, crc32.ChecksumIEEE(chunk))
+	return buf.Bytes()
+}
+
+func TestResizeRejectsHugeImages(t *testing.T) {
+	data := hugePNG(100000, 100000)
+	key, err := Key(data)
+	require.NoError(t, err)
+
+	_, err = Resize(data, key, 100)
+	assert.ErrorIs(t, err, ErrTooLarge)
+}
+
+func TestMirror(t *testing.T) {
+	data := testPNG(t, 8, 8)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/image.png":
+			w.Write(data)
+		case "/page.html":
+			w.Write([]byte("<html></html>"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	store, err := NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+
+	key, err := Mirror(ctx, store, server.Client(), server.URL+"/image.png")
+	require.NoError(t, err)
+	stored, err := store.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, data, stored)
+
+	_, err = Mirror(ctx, store, server.Client(), server.URL+"/page.html")
+	assert.ErrorIs(t, err, ErrUnsupported)
+	_, err = Mirror(ctx, store, server.Client(), server.URL+"/missing.png")
+	assert.Error(t, err)
+}
+
+func TestDownloadRefusesInternalAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(testPNG(t, 8, 8))
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	// Le client par défaut ne contacte pas la boucle locale, même via un nom qui y est résolu
+	_, err := Download(ctx, nil, server.URL+"/image.png")
+	assert.ErrorIs(t, err, ErrForbiddenURL)
+	_, err = Download(ctx, nil, strings.Replace(server.URL, "127.0.0.1", "localhost", 1)+"/image.png")
+	assert.ErrorIs(t, err, ErrForbiddenURL)
+
+	for _, raw := range []string{"file:///etc/passwd", "gopher://example.com/", "http:///image.png", "://"} {
+		_, err = Download(ctx, nil, raw)
+		assert.ErrorIs(t, err, ErrForbiddenURL, raw)
+	}
+}
+
+func TestPublicIP(t *testing.T) {
+	for _, ip := range []string{"127.0.0.1", "10.1.2.3", "172.16.0.1", "192.168.1.1", "169.254.169.254", "100.64.0.1", "0.0.0.0", "::1", "fd00::1", "fe80::1"} {
+		assert.False(t, PublicIP(net.ParseIP(ip)), ip)
+	}
+	for _, ip := range []string{"93.184.216.34", "2606:2800:220:1:248:1893:25c8:1946"} {
+		assert.True(t, PublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	store, err := Open("local", t.TempDir())
+	require.NoError(t, err)
+	assert.NotNil(t, store)
+
+	_, err = Open("s3", "bucket")
+	assert.Error(t, err)
+
+	Register("memory-test", func(location string) (Store, error) { return NewLocalStore(t.TempDir()) })
+	_, err = Open("memory-test", "")
+	assert.NoError(t, err)
+}
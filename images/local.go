@@ -0,0 +1,107 @@
+package images
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// LocalStore stockage sur disque local
+// Les images sont réparties en sous-répertoires selon les deux premiers caractères de la clé
+type LocalStore struct {
+	root string
+}
+
+// NewLocalStore crée (si besoin) le répertoire racine et retourne le stockage
+func NewLocalStore(root string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o755); err != nil {
+		return nil, err
+	}
+	return &LocalStore{root: root}, nil
+}
+
+// Put enregistre une image ; une image déjà présente n'est pas réécrite
+func (s *LocalStore) Put(ctx context.Context, data []byte) (string, error) {
+	key, err := Key(data)
+	if err != nil {
+		return "", err
+	}
+
+	path := s.path(key)
+	if _, err := os.Stat(path); err == nil {
+		return key, nil
+	}
+	return key, writeAtomic(path, data)
+}
+
+// Get retourne le contenu d'une image
+func (s *LocalStore) Get(ctx context.Context, key string) ([]byte, error) {
+	if !ValidKey(key) {
+		return nil, ErrInvalidKey
+	}
+	return readFile(s.path(key))
+}
+
+// PutVariant enregistre une version dérivée d'une image
+func (s *LocalStore) PutVariant(ctx context.Context, key, variant string, data []byte) error {
+	path, err := s.variantPath(key, variant)
+	if err != nil {
+		return err
+	}
+	return writeAtomic(path, data)
+}
+
+// GetVariant retourne une version dérivée d'une image
+func (s *LocalStore) GetVariant(ctx context.Context, key, variant string) ([]byte, error) {
+	path, err := s.variantPath(key, variant)
+	if err != nil {
+		return nil, err
+	}
+	return readFile(path)
+}
+
+func (s *LocalStore) path(key string) string {
+	return filepath.Join(s.root, key[:2], key)
+}
+
+func (s *LocalStore) variantPath(key, variant string) (string, error) {
+	if !ValidKey(key) {
+		return "", ErrInvalidKey
+	}
+	if !variantPattern.MatchString(variant) {
+		return "", fmt.Errorf("variante d'image invalide: %s", variant)
+	}
+	return filepath.Join(s.root, "variants", key[:2], key+"."+variant), nil
+}
+
+// writeAtomic écrit dans un fichier temporaire puis le renomme,
+// pour qu'un lecteur concurrent ne voie jamais un fichier partiel
+func writeAtomic(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func readFile(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
@@ -0,0 +1,97 @@
+package images
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	_ "image/gif" // Décodage des images GIF
+	"image/jpeg"
+	"image/png"
+	"strconv"
+	"strings"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp" // Décodage des images WebP
+)
+
+// Limites de largeur acceptées pour les miniatures
+const (
+	MinWidth = 16
+	MaxWidth = 2048
+)
+
+// Widths largeurs des miniatures générées : une largeur demandée est arrondie à la largeur supérieure
+// de cette liste, une image n'a donc jamais plus de len(Widths) miniatures en cache
+var Widths = []int{64, 128, 256, 512, 1024, 2048}
+
+// VariantWidth largeur de la miniature servie pour une largeur demandée entre MinWidth et MaxWidth
+func VariantWidth(width int) int {
+	for _, w := range Widths {
+		if width <= w {
+			return w
+		}
+	}
+	return Widths[len(Widths)-1]
+}
+
+// MaxPixels nombre maximal de pixels d'une image redimensionnée (40 mégapixels)
+// Une image compressée de quelques kilo-octets peut déclarer des dimensions énormes :
+// elles sont vérifiées avant le décodage, qui alloue l'image entière en mémoire
+const MaxPixels = 40_000_000
+
+// ErrTooLarge est retournée par Resize pour une image dépassant MaxPixels
+var ErrTooLarge = errors.New("image trop grande pour être redimensionnée")
+
+// jpegQuality qualité des miniatures JPEG
+const jpegQuality = 85
+
+// VariantName nom de la variante redimensionnée d'une image ("w300.jpg")
+// Les images avec transparence (PNG, GIF) restent en PNG, les autres sont servies en JPEG
+func VariantName(key string, width int) string {
+	ext := "jpg"
+	if strings.HasSuffix(key, ".png") || strings.HasSuffix(key, ".gif") {
+		ext = "png"
+	}
+	return "w" + strconv.Itoa(width) + "." + ext
+}
+
+// Resize redimensionne une image à la largeur demandée en conservant ses proportions
+// L'image n'est jamais agrandie : si elle est déjà plus étroite, elle est réencodée telle quelle
+// Le format de sortie correspond à VariantName
+func Resize(data []byte, key string, width int) ([]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if config.Width <= 0 || config.Height <= 0 || int64(config.Width)*int64(config.Height) > MaxPixels {
+		return nil, ErrTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := src.Bounds()
+	var dst image.Image = src
+	if width < bounds.Dx() {
+		height := bounds.Dy() * width / bounds.Dx()
+		if height < 1 {
+			height = 1
+		}
+		scaled := image.NewNRGBA(image.Rect(0, 0, width, height))
+		draw.CatmullRom.Scale(scaled, scaled.Bounds(), src, bounds, draw.Src, nil)
+		dst = scaled
+	}
+
+	var buf bytes.Buffer
+	if strings.HasSuffix(VariantName(key, width), ".png") {
+		err = png.Encode(&buf, dst)
+	} else {
+		err = jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,124 @@
+package images
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Erreurs du stockage d'images
+var (
+	ErrNotFound    = errors.New("image introuvable")
+	ErrUnsupported = errors.New("format d'image non supporté")
+	ErrInvalidKey  = errors.New("clé d'image invalide")
+)
+
+// Store stockage d'images adressé par contenu
+// La clé d'une image est l'empreinte SHA-256 de son contenu suivie de son extension,
+// une même image téléchargée deux fois n'est donc stockée qu'une fois
+type Store interface {
+	// Put enregistre une image et retourne sa clé
+	Put(ctx context.Context, data []byte) (string, error)
+	// Get retourne le contenu d'une image (ErrNotFound si absente)
+	Get(ctx context.Context, key string) ([]byte, error)
+	// PutVariant enregistre une version dérivée d'une image (miniature...)
+	PutVariant(ctx context.Context, key, variant string, data []byte) error
+	// GetVariant retourne une version dérivée (ErrNotFound si absente)
+	GetVariant(ctx context.Context, key, variant string) ([]byte, error)
+}
+
+// Factory crée un stockage à partir de son emplacement (répertoire, bucket...)
+type Factory func(location string) (Store, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Factory{
+		"local": func(location string) (Store, error) { return NewLocalStore(location) },
+	}
+)
+
+// Register ajoute un type de stockage utilisable via IMAGE_STORE_BACKEND
+func Register(name string, factory Factory) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	backends[name] = factory
+}
+
+// Open ouvre un stockage du type demandé
+func Open(backend, location string) (Store, error) {
+	backendsMu.RLock()
+	factory, ok := backends[backend]
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	backendsMu.RUnlock()
+
+	if !ok {
+		sort.Strings(names)
+		return nil, fmt.Errorf("stockage d'images inconnu: %s (disponibles: %s)", backend, strings.Join(names, ", "))
+	}
+	return factory(location)
+}
+
+// OpenFromEnv ouvre le stockage configuré par IMAGE_STORE_BACKEND (local par défaut)
+// et IMAGE_STORE_DIR (./data/images par défaut)
+func OpenFromEnv() (Store, error) {
+	backend := os.Getenv("IMAGE_STORE_BACKEND")
+	if backend == "" {
+		backend = "local"
+	}
+	location := os.Getenv("IMAGE_STORE_DIR")
+	if location == "" {
+		location = "./data/images"
+	}
+	return Open(backend, location)
+}
+
+// extensions formats acceptés et extension associée
+var extensions = map[string]string{
+	"image/jpeg": "jpg",
+	"image/png":  "png",
+	"image/gif":  "gif",
+	"image/webp": "webp",
+}
+
+// keyPattern format d'une clé : empreinte SHA-256 et extension
+var keyPattern = regexp.MustCompile(`^[0-9a-f]{64}\.(jpg|png|gif|webp)$`)
+
+// variantPattern format d'un nom de variante (ex: "w300.jpg")
+var variantPattern = regexp.MustCompile(`^[a-z0-9]+\.(jpg|png|gif|webp)$`)
+
+// Key calcule la clé d'une image à partir de son contenu
+func Key(data []byte) (string, error) {
+	ext, ok := extensions[http.DetectContentType(data)]
+	if !ok {
+		return "", ErrUnsupported
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]) + "." + ext, nil
+}
+
+// ValidKey indique si la clé a le format attendu (protège le stockage des chemins arbitraires)
+func ValidKey(key string) bool {
+	return keyPattern.MatchString(key)
+}
+
+// ContentType retourne le type MIME associé à une clé ou un nom de variante
+func ContentType(name string) string {
+	ext := name[strings.LastIndex(name, ".")+1:]
+	for contentType, e := range extensions {
+		if e == ext {
+			return contentType
+		}
+	}
+	return "application/octet-stream"
+}
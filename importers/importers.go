@@ -0,0 +1,170 @@
+package importers
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/isoduration"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// ErrNoRecipe le fichier ne contient aucune recette
+var ErrNoRecipe = errors.New("aucune recette trouvée")
+
+// Importer convertit les recettes d'un format tiers
+// Seuls les champs présents dans le format sont renseignés : les données dérivées (régimes, allergènes,
+// durées en minutes) sont calculées à l'enregistrement, comme pour les recettes du scraper
+type Importer interface {
+	Import(data []byte) ([]models.Recette, error)
+}
+
+var (
+	importersMu sync.RWMutex
+	importers   = map[string]Importer{
+		"jsonld":     JSONLD{},
+		"paprika":    Paprika{},
+		"mealmaster": MealMaster{},
+	}
+)
+
+// Register ajoute un format utilisable via POST /recettes/import?format= et la commande import
+func Register(name string, importer Importer) {
+	importersMu.Lock()
+	defer importersMu.Unlock()
+	importers[name] = importer
+}
+
+// Get retourne l'importeur d'un format
+func Get(format string) (Importer, error) {
+	importersMu.RLock()
+	importer, ok := importers[format]
+	importersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("format d'importation inconnu: %s (disponibles: %s)", format, strings.Join(Formats(), ", "))
+	}
+	return importer, nil
+}
+
+// Formats retourne les formats disponibles, triés
+func Formats() []string {
+	importersMu.RLock()
+	defer importersMu.RUnlock()
+	names := make([]string, 0, len(importers))
+	for name := range importers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// durationUnits unités des durées en texte libre ("1 hr 30 mins", "1 h 30", "45 minutes")
+var durationUnits = map[string]time.Duration{
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour, "jour": 24 * time.Hour, "jours": 24 * time.Hour,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour, "heure": time.Hour, "heures": time.Hour,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute, "mn": time.Minute,
+}
+
+// durationPattern nombre suivi éventuellement d'une unité ("1h30" donne 1 h puis 30)
+var durationPattern = regexp.MustCompile(`(\d+)\s*([a-z]*)`)
+
+// parseDuration convertit une durée ISO-8601 ou en texte libre en durée ISO-8601 ("" si illisible)
+// Un nombre sans unité suivant des heures compte en minutes ("1 h 30"), seul il compte en minutes ("45")
+func parseDuration(value string) string {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" {
+		return ""
+	}
+	if d, err := isoduration.Parse(value); err == nil {
+		if d <= 0 {
+			return ""
+		}
+		return isoduration.Format(d)
+	}
+
+	var total time.Duration
+	for _, match := range durationPattern.FindAllStringSubmatch(value, -1) {
+		amount, err := strconv.Atoi(match[1])
+		if err != nil {
+			continue
+		}
+		unit, ok := durationUnits[strings.TrimSuffix(match[2], ".")]
+		if match[2] == "" {
+			unit, ok = time.Minute, true
+		}
+		if ok {
+			total += time.Duration(amount) * unit
+		}
+	}
+	if total <= 0 {
+		return ""
+	}
+	return isoduration.Format(total)
+}
+
+// servingsPattern premier nombre entier d'un rendement ("4 servings", "Serves 6", "8 to 10")
+var servingsPattern = regexp.MustCompile(`\d+`)
+
+// parseServings extrait le nombre de portions d'un rendement (0 si absent)
+func parseServings(value string) int {
+	servings, err := strconv.Atoi(servingsPattern.FindString(value))
+	if err != nil || servings <= 0 {
+		return 0
+	}
+	return servings
+}
+
+// stepNumberPattern numérotation présente dans le texte d'une étape ("1. ", "2) ", "Step 3: ")
+var stepNumberPattern = regexp.MustCompile(`(?i)^(?:step\s*)?\d+\s*[.):]\s*`)
+
+// nonEmptyLines découpe un texte en lignes non vides, sans espaces superflus
+func nonEmptyLines(text string) []string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// newIngredients convertit des lignes en ingrédients (ligne complète dans Quantity, comme le scraper)
+func newIngredients(lines []string) []models.Ingredient {
+	result := []models.Ingredient{}
+	for _, line := range lines {
+		if line = strings.TrimSpace(line); line != "" {
+			result = append(result, models.Ingredient{Quantity: line})
+		}
+	}
+	return result
+}
+
+// newInstructions convertit des étapes en instructions numérotées à partir de 1
+// Une numérotation présente dans le texte est retirée
+func newInstructions(steps []string) []models.Instruction {
+	result := []models.Instruction{}
+	for _, step := range steps {
+		step = strings.TrimSpace(stepNumberPattern.ReplaceAllString(strings.TrimSpace(step), ""))
+		if step == "" {
+			continue
+		}
+		result = append(result, models.Instruction{Number: strconv.Itoa(len(result) + 1), Description: step})
+	}
+	return result
+}
+
+// category retourne la première catégorie non vide, en minuscules
+func category(categories []string) string {
+	for _, value := range categories {
+		if value = domain.NormalizeCategory(value); value != "" {
+			return value
+		}
+	}
+	return ""
+}
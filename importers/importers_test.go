@@ -0,0 +1,264 @@
+package importers
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	assert.Equal(t, []string{"jsonld", "mealmaster", "paprika"}, Formats())
+
+	importer, err := Get("jsonld")
+	require.NoError(t, err)
+	assert.IsType(t, JSONLD{}, importer)
+
+	_, err = Get("docx")
+	assert.EqualError(t, err, "format d'importation inconnu: docx (disponibles: jsonld, mealmaster, paprika)")
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]string{
+		"PT1H30M":       "PT1H30M",
+		"pt20m":         "PT20M",
+		"1 hr 30 mins":  "PT1H30M",
+		"1h30":          "PT1H30M",
+		"45 minutes":    "PT45M",
+		"2 heures":      "PT2H",
+		"1 day 2 hours": "P1DT2H",
+		"15":            "PT15M",
+		"":              "",
+		"overnight":     "",
+		"PT0M":          "",
+	}
+	for value, expected := range tests {
+		assert.Equal(t, expected, parseDuration(value), value)
+	}
+}
+
+func TestNewInstructions(t *testing.T) {
+	steps := newInstructions([]string{"1. Preheat the oven.", "", "Step 2: Mix.", "3) Bake"})
+	assert.Equal(t, []models.Instruction{
+		{Number: "1", Description: "Preheat the oven."},
+		{Number: "2", Description: "Mix."},
+		{Number: "3", Description: "Bake"},
+	}, steps)
+}
+
+func TestJSONLDGraph(t *testing.T) {
+	data := []byte(`{
+		"@context": "https://schema.org",
+		"@graph": [
+			{"@type": "WebPage", "@id": "https://example.com/pancakes"},
+			{
+				"@type": ["Recipe", "NewsArticle"],
+				"@id": "https://example.com/pancakes#recipe",
+				"name": "Fluffy Pancakes &amp; Syrup",
+				"image": [{"@type": "ImageObject", "url": "https://example.com/pancakes.jpg"}],
+				"recipeCategory": ["Breakfast", "Brunch"],
+				"recipeYield": ["4", "4 servings"],
+				"prepTime": "PT10M",
+				"cookTime": "PT15M",
+				"recipeIngredient": ["1 cup flour", " 2 eggs ", ""],
+				"recipeInstructions": [
+					{"@type": "HowToSection", "name": "Batter", "itemListElement": [
+						{"@type": "HowToStep", "text": "Whisk the flour and eggs."}
+					]},
+					{"@type": "HowToStep", "text": "<p>Cook on a hot griddle.</p>"}
+				]
+			}
+		]
+	}`)
+
+	recettes, err := JSONLD{}.Import(data)
+	require.NoError(t, err)
+	require.Len(t, recettes, 1)
+	recette := recettes[0]
+	assert.Equal(t, "Fluffy Pancakes & Syrup", recette.Name)
+	assert.Equal(t, "https://example.com/pancakes", recette.Page)
+	assert.Equal(t, "https://example.com/pancakes.jpg", recette.Image)
+	assert.Equal(t, "breakfast", recette.Category)
+	assert.Equal(t, 4, recette.Servings)
+	assert.Equal(t, "PT10M", recette.PrepTime)
+	assert.Equal(t, "PT15M", recette.CookTime)
+	assert.Equal(t, []models.Ingredient{{Quantity: "1 cup flour"}, {Quantity: "2 eggs"}}, recette.Ingredients)
+	assert.Equal(t, []models.Instruction{
+		{Number: "1", Description: "Whisk the flour and eggs."},
+		{Number: "2", Description: "Cook on a hot griddle."},
+	}, recette.Instructions)
+}
+
+func TestJSONLDFromHTML(t *testing.T) {
+	page := []byte(`<!DOCTYPE html><html><head>
+		<script type="application/ld+json">{"@type": "Organization", "name": "Example"}</script>
+		<script type="application/ld+json">
+		[{"@type": "Recipe", "name": "Soup", "url": "https://example.com/soup",
+		  "image": "https://example.com/soup.jpg", "recipeYield": 6, "totalTime": "PT1H",
+		  "recipeIngredient": ["1 onion"], "recipeInstructions": "1. Chop the onion.\n2. Simmer."},
+		 {"@type": "Recipe", "name": "Bread"}]
+		</script></head><body></body></html>`)
+
+	recettes, err := JSONLD{}.Import(page)
+	require.NoError(t, err)
+	require.Len(t, recettes, 2)
+	assert.Equal(t, "Soup", recettes[0].Name)
+	assert.Equal(t, "https://example.com/soup", recettes[0].Page)
+	assert.Equal(t, 6, recettes[0].Servings)
+	assert.Equal(t, "PT1H", recettes[0].TotalTime)
+	assert.Equal(t, []models.Instruction{
+		{Number: "1", Description: "Chop the onion."},
+		{Number: "2", Description: "Simmer."},
+	}, recettes[0].Instructions)
+	assert.Equal(t, "Bread", recettes[1].Name)
+	assert.Empty(t, recettes[1].Page)
+}
+
+func TestJSONLDErrors(t *testing.T) {
+	_, err := JSONLD{}.Import([]byte(`{"@type": "Organization"}`))
+	assert.ErrorIs(t, err, ErrNoRecipe)
+	_, err = JSONLD{}.Import([]byte(`{"@type": `))
+	assert.Error(t, err)
+	_, err = JSONLD{}.Import([]byte(`<html><body>no recipe</body></html>`))
+	assert.ErrorIs(t, err, ErrNoRecipe)
+}
+
+func gzipRecipe(t *testing.T, recipe map[string]interface{}) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	require.NoError(t, json.NewEncoder(gz).Encode(recipe))
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestPaprikaArchive(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	recipes := []map[string]interface{}{
+		{
+			"name":        "Lemon Tart",
+			"ingredients": "1 pie crust\n\n3 lemons\n1 cup sugar",
+			"directions":  "Zest the lemons.\n\nBake the crust for 10 minutes.",
+			"servings":    "8 slices",
+			"prep_time":   "20 mins",
+			"cook_time":   "1 hr",
+			"categories":  []string{"Desserts"},
+			"source_url":  "https://example.com/lemon-tart",
+			"image_url":   "https://example.com/lemon-tart.jpg",
+			"photo_data":  "aGVsbG8=",
+		},
+		{"name": "Toast", "ingredients": "1 slice bread", "directions": "Toast it."},
+	}
+	for i, recipe := range recipes {
+		w, err := zw.Create([]string{"Lemon Tart.paprikarecipe", "Toast.paprikarecipe"}[i])
+		require.NoError(t, err)
+		_, err = w.Write(gzipRecipe(t, recipe))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	recettes, err := Paprika{}.Import(archive.Bytes())
+	require.NoError(t, err)
+	require.Len(t, recettes, 2)
+
+	tart := recettes[0]
+	assert.Equal(t, "Lemon Tart", tart.Name)
+	assert.Equal(t, "https://example.com/lemon-tart", tart.Page)
+	assert.Equal(t, "https://example.com/lemon-tart.jpg", tart.Image)
+	assert.Equal(t, "desserts", tart.Category)
+	assert.Equal(t, 8, tart.Servings)
+	assert.Equal(t, "PT20M", tart.PrepTime)
+	assert.Equal(t, "PT1H", tart.CookTime)
+	assert.Empty(t, tart.TotalTime)
+	assert.Len(t, tart.Ingredients, 3)
+	assert.Equal(t, "Bake the crust for 10 minutes.", tart.Instructions[1].Description)
+	assert.Equal(t, "Toast", recettes[1].Name)
+}
+
+func TestPaprikaSingleRecipe(t *testing.T) {
+	recettes, err := Paprika{}.Import(gzipRecipe(t, map[string]interface{}{"name": "Toast", "directions": "Toast it."}))
+	require.NoError(t, err)
+	require.Len(t, recettes, 1)
+	assert.Equal(t, "Toast", recettes[0].Name)
+
+	_, err = Paprika{}.Import([]byte("not an archive"))
+	assert.Error(t, err)
+}
+
+const mealMasterFile = `Exported from a Meal-Master database
+
+MMMMM----- Recipe via Meal-Master (tm) v8.05
+
+      Title: Chocolate Chip Cookies
+ Categories: Cookies, Desserts
+      Yield: 36 servings
+
+      1 c  Butter; softened
+    3/4 c  Sugar
+      2    Eggs
+MMMMM--------------------------CHIPS---------------------------------
+      2 c  Chocolate chips
+           -semi-sweet
+           Salt
+
+  Preheat oven to 375 F. Cream the butter
+  and the sugar.
+
+  Stir in the chips and bake 10 minutes.
+
+MMMMM
+
+---------- Recipe via Meal-Master (tm) v8.02
+
+      Title: Quick Salad
+ Categories: Salads
+   Servings: 2
+
+      1 lg Tomato                              1 sm Onion
+      2 T  Olive oil                           1 pn Salt
+
+  Slice and toss.
+-----
+`
+
+func TestMealMaster(t *testing.T) {
+	recettes, err := MealMaster{}.Import([]byte(mealMasterFile))
+	require.NoError(t, err)
+	require.Len(t, recettes, 2)
+
+	cookies := recettes[0]
+	assert.Equal(t, "Chocolate Chip Cookies", cookies.Name)
+	assert.Equal(t, "cookies", cookies.Category)
+	assert.Equal(t, 36, cookies.Servings)
+	assert.Empty(t, cookies.Page)
+	assert.Equal(t, []models.Ingredient{
+		{Quantity: "1 cup Butter; softened"},
+		{Quantity: "3/4 cup Sugar"},
+		{Quantity: "2 Eggs"},
+		{Quantity: "2 cup Chocolate chips semi-sweet"},
+		{Quantity: "Salt"},
+	}, cookies.Ingredients)
+	assert.Equal(t, []models.Instruction{
+		{Number: "1", Description: "Preheat oven to 375 F. Cream the butter and the sugar."},
+		{Number: "2", Description: "Stir in the chips and bake 10 minutes."},
+	}, cookies.Instructions)
+
+	salad := recettes[1]
+	assert.Equal(t, "Quick Salad", salad.Name)
+	assert.Equal(t, 2, salad.Servings)
+	assert.Equal(t, []models.Ingredient{
+		{Quantity: "1 large Tomato"},
+		{Quantity: "1 small Onion"},
+		{Quantity: "2 tablespoon Olive oil"},
+		{Quantity: "1 pinch Salt"},
+	}, salad.Ingredients)
+	assert.Equal(t, "Slice and toss.", salad.Instructions[0].Description)
+
+	_, err = MealMaster{}.Import([]byte("just some text"))
+	assert.ErrorIs(t, err, ErrNoRecipe)
+}
@@ -0,0 +1,214 @@
+package importers
+
+import (
+	"encoding/json"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// JSONLD importe les recettes schema.org (https://schema.org/Recipe) en JSON-LD
+// Accepte un document JSON (objet, tableau ou @graph) ou une page HTML contenant
+// des blocs <script type="application/ld+json">
+type JSONLD struct{}
+
+// scriptPattern blocs JSON-LD d'une page HTML
+var scriptPattern = regexp.MustCompile(`(?is)<script[^>]*type\s*=\s*["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+// tagPattern balises HTML présentes dans certains textes
+var tagPattern = regexp.MustCompile(`<[^>]*>`)
+
+// breakPattern balises marquant une fin de ligne
+var breakPattern = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>`)
+
+// Import extrait les nœuds de type Recipe
+func (JSONLD) Import(data []byte) ([]models.Recette, error) {
+	documents := [][]byte{data}
+	if trimmed := strings.TrimSpace(string(data)); strings.HasPrefix(trimmed, "<") {
+		documents = nil
+		for _, match := range scriptPattern.FindAllSubmatch(data, -1) {
+			documents = append(documents, match[1])
+		}
+	}
+
+	recettes := []models.Recette{}
+	var firstErr error
+	for _, document := range documents {
+		var node interface{}
+		if err := json.Unmarshal(document, &node); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, recipe := range findRecipes(node) {
+			recettes = append(recettes, recipeFromJSONLD(recipe))
+		}
+	}
+	if len(recettes) == 0 {
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		return nil, ErrNoRecipe
+	}
+	return recettes, nil
+}
+
+// findRecipes parcourt les tableaux et @graph à la recherche des nœuds Recipe
+func findRecipes(node interface{}) []map[string]interface{} {
+	switch value := node.(type) {
+	case []interface{}:
+		recipes := []map[string]interface{}{}
+		for _, item := range value {
+			recipes = append(recipes, findRecipes(item)...)
+		}
+		return recipes
+	case map[string]interface{}:
+		if isRecipe(value["@type"]) {
+			return []map[string]interface{}{value}
+		}
+		if graph, ok := value["@graph"]; ok {
+			return findRecipes(graph)
+		}
+	}
+	return nil
+}
+
+// isRecipe indique si le @type désigne une recette ("Recipe" ou ["Recipe", ...])
+func isRecipe(value interface{}) bool {
+	for _, t := range texts(value) {
+		if t == "Recipe" || strings.HasSuffix(t, "/Recipe") {
+			return true
+		}
+	}
+	return false
+}
+
+// recipeFromJSONLD convertit un nœud Recipe
+func recipeFromJSONLD(node map[string]interface{}) models.Recette {
+	recette := models.Recette{
+		Recipe: domain.Recipe{
+			Name:         text(node["name"]),
+			Page:         recipePage(node),
+			Image:        imageURL(node["image"]),
+			Category:     category(texts(node["recipeCategory"])),
+			Ingredients:  newIngredients(texts(firstOf(node, "recipeIngredient", "ingredients"))),
+			Instructions: newInstructions(howToSteps(node["recipeInstructions"])),
+			PrepTime:     parseDuration(text(node["prepTime"])),
+			CookTime:     parseDuration(text(node["cookTime"])),
+			TotalTime:    parseDuration(text(node["totalTime"])),
+		},
+	}
+	for _, yield := range texts(node["recipeYield"]) {
+		if recette.Servings = parseServings(yield); recette.Servings > 0 {
+			break
+		}
+	}
+	return recette
+}
+
+// recipePage page d'origine : url, mainEntityOfPage ou @id s'il s'agit d'une URL
+func recipePage(node map[string]interface{}) string {
+	candidates := []interface{}{node["url"], node["mainEntityOfPage"], node["@id"]}
+	for _, candidate := range candidates {
+		if entity, ok := candidate.(map[string]interface{}); ok {
+			candidate = entity["@id"]
+		}
+		if page := text(candidate); strings.HasPrefix(page, "http://") || strings.HasPrefix(page, "https://") {
+			// Un @id de la forme https://site/recette#recipe désigne la page
+			return strings.SplitN(page, "#", 2)[0]
+		}
+	}
+	return ""
+}
+
+// imageURL URL d'une image : texte, ImageObject ou liste (la première est retenue)
+func imageURL(value interface{}) string {
+	switch image := value.(type) {
+	case string:
+		return strings.TrimSpace(image)
+	case []interface{}:
+		for _, item := range image {
+			if url := imageURL(item); url != "" {
+				return url
+			}
+		}
+	case map[string]interface{}:
+		if url := text(image["url"]); url != "" {
+			return url
+		}
+		return text(image["@id"])
+	}
+	return ""
+}
+
+// howToSteps texte des étapes : texte (une étape par ligne), liste de textes, HowToStep ou HowToSection
+func howToSteps(value interface{}) []string {
+	switch steps := value.(type) {
+	case string:
+		return nonEmptyLines(cleanText(steps))
+	case []interface{}:
+		result := []string{}
+		for _, step := range steps {
+			result = append(result, howToSteps(step)...)
+		}
+		return result
+	case map[string]interface{}:
+		if items, ok := steps["itemListElement"]; ok {
+			return howToSteps(items)
+		}
+		if step := text(firstOf(steps, "text", "name")); step != "" {
+			return []string{step}
+		}
+	}
+	return nil
+}
+
+// firstOf première propriété renseignée parmi keys
+func firstOf(node map[string]interface{}, keys ...string) interface{} {
+	for _, key := range keys {
+		if value, ok := node[key]; ok && value != nil {
+			return value
+		}
+	}
+	return nil
+}
+
+// text texte d'une valeur (premier élément d'une liste), sans balises ni entités HTML
+func text(value interface{}) string {
+	values := texts(value)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// texts textes d'une valeur simple ou d'une liste, nettoyés et non vides
+// Les nombres sont convertis ("recipeYield": 4)
+func texts(value interface{}) []string {
+	result := []string{}
+	switch v := value.(type) {
+	case string:
+		if s := strings.Join(strings.Fields(cleanText(v)), " "); s != "" {
+			result = append(result, s)
+		}
+	case float64:
+		result = append(result, strconv.FormatFloat(v, 'f', -1, 64))
+	case []interface{}:
+		for _, item := range v {
+			result = append(result, texts(item)...)
+		}
+	}
+	return result
+}
+
+// cleanText retire les balises HTML et décode les entités (&amp;, &#39;...)
+// Les balises de fin de paragraphe et les retours à la ligne deviennent des sauts de ligne
+func cleanText(value string) string {
+	value = breakPattern.ReplaceAllString(value, "\n")
+	return html.UnescapeString(tagPattern.ReplaceAllString(value, ""))
+}
@@ -0,0 +1,185 @@
+package importers
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// MealMaster importe les fichiers texte Meal-Master (une ou plusieurs recettes par fichier)
+//
+//	MMMMM----- Recipe via Meal-Master (tm) v8.05
+//	      Title: Chocolate Chip Cookies
+//	 Categories: Cookies, Desserts
+//	      Yield: 36 servings
+//
+//	      1 c  Butter
+//	    3/4 c  Sugar
+//
+//	  Cream butter and sugar...
+//	MMMMM
+type MealMaster struct{}
+
+var (
+	// mmStartPattern ligne d'en-tête d'une recette
+	mmStartPattern = regexp.MustCompile(`(?i)^(MMMMM|-----).*meal-master`)
+	// mmEndPattern ligne de fin d'une recette
+	mmEndPattern = regexp.MustCompile(`^(MMMMM|-----)\s*$`)
+	// mmSectionPattern titre de partie dans la liste d'ingrédients ("MMMMM-----FILLING-----")
+	mmSectionPattern = regexp.MustCompile(`^(MMMMM|-----)-*[^-]*-*\s*$`)
+	// mmHeaderPattern champ d'en-tête ("Title: ...")
+	mmHeaderPattern = regexp.MustCompile(`(?i)^\s*(title|categories|yield|servings)\s*:\s*(.*)$`)
+	// mmIngredientPattern ingrédient en colonnes : quantité (7), unité (2), texte
+	mmIngredientPattern = regexp.MustCompile(`^([ 0-9/.\-]{7}) ([ a-zA-Z]{2}) (.*\S.*)$`)
+)
+
+// mmUnits abréviations des unités Meal-Master
+var mmUnits = map[string]string{
+	"x": "per serving", "sm": "small", "md": "medium", "lg": "large",
+	"cn": "can", "pk": "package", "pn": "pinch", "dr": "drop", "ds": "dash",
+	"ct": "carton", "bn": "bunch", "sl": "slice", "ea": "each",
+	"t": "teaspoon", "ts": "teaspoon", "T": "tablespoon", "tb": "tablespoon",
+	"fl": "fluid ounce", "c": "cup", "pt": "pint", "qt": "quart", "ga": "gallon",
+	"oz": "ounce", "lb": "pound",
+	"ml": "milliliter", "cb": "cubic cm", "cl": "centiliter", "dl": "deciliter", "l": "liter",
+	"mg": "milligram", "cg": "centigram", "dg": "decigram", "g": "gram", "kg": "kilogram",
+}
+
+// mmRecipe recette en cours de lecture
+type mmRecipe struct {
+	recette     models.Recette
+	ingredients []string
+	steps       []string
+	paragraph   []string
+	inSteps     bool
+}
+
+// Import lit toutes les recettes du fichier
+func (MealMaster) Import(data []byte) ([]models.Recette, error) {
+	recettes := []models.Recette{}
+	var current *mmRecipe
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r \t")
+		switch {
+		case mmStartPattern.MatchString(line):
+			if current != nil {
+				recettes = append(recettes, current.finish())
+			}
+			current = &mmRecipe{}
+		case current == nil:
+			// texte hors recette ignoré
+		case mmEndPattern.MatchString(line):
+			recettes = append(recettes, current.finish())
+			current = nil
+		default:
+			current.add(line)
+		}
+	}
+	// Fichier tronqué : la dernière recette est conservée
+	if current != nil {
+		recettes = append(recettes, current.finish())
+	}
+	if len(recettes) == 0 {
+		return nil, ErrNoRecipe
+	}
+	return recettes, nil
+}
+
+// add traite une ligne de la recette
+func (r *mmRecipe) add(line string) {
+	if !r.inSteps {
+		if match := mmHeaderPattern.FindStringSubmatch(line); match != nil && len(r.ingredients) == 0 {
+			r.header(strings.ToLower(match[1]), strings.TrimSpace(match[2]))
+			return
+		}
+		if strings.TrimSpace(line) == "" || mmSectionPattern.MatchString(line) {
+			return
+		}
+		if text, ok := mmIngredient(line); ok {
+			r.addIngredient(text)
+			// Présentation sur deux colonnes : le second ingrédient commence en colonne 41
+			if len(line) > 41 {
+				if left, ok := mmIngredient(line[:41]); ok {
+					if right, ok := mmIngredient(line[41:]); ok {
+						r.ingredients[len(r.ingredients)-1] = left
+						r.addIngredient(right)
+					}
+				}
+			}
+			return
+		}
+		r.inSteps = true
+	}
+
+	if strings.TrimSpace(line) == "" {
+		r.endParagraph()
+		return
+	}
+	r.paragraph = append(r.paragraph, strings.TrimSpace(line))
+}
+
+// header enregistre un champ d'en-tête
+func (r *mmRecipe) header(name, value string) {
+	switch name {
+	case "title":
+		r.recette.Name = value
+	case "categories":
+		r.recette.Category = category(strings.Split(value, ","))
+	case "yield", "servings":
+		if r.recette.Servings == 0 {
+			r.recette.Servings = parseServings(value)
+		}
+	}
+}
+
+// addIngredient ajoute un ingrédient ; un texte commençant par "-" prolonge le précédent
+func (r *mmRecipe) addIngredient(text string) {
+	if strings.HasPrefix(text, "-") && len(r.ingredients) > 0 {
+		last := len(r.ingredients) - 1
+		r.ingredients[last] += " " + strings.TrimSpace(strings.TrimPrefix(text, "-"))
+		return
+	}
+	r.ingredients = append(r.ingredients, text)
+}
+
+// endParagraph termine l'étape en cours
+func (r *mmRecipe) endParagraph() {
+	if len(r.paragraph) > 0 {
+		r.steps = append(r.steps, strings.Join(r.paragraph, " "))
+		r.paragraph = nil
+	}
+}
+
+// finish retourne la recette lue
+func (r *mmRecipe) finish() models.Recette {
+	r.endParagraph()
+	r.recette.Ingredients = newIngredients(r.ingredients)
+	r.recette.Instructions = newInstructions(r.steps)
+	return r.recette
+}
+
+// mmIngredient convertit une ligne d'ingrédient en colonnes en texte ("1 cup Butter")
+// Un texte de continuation ("-chopped") est retourné avec son tiret
+func mmIngredient(line string) (string, bool) {
+	match := mmIngredientPattern.FindStringSubmatch(line)
+	if match == nil {
+		return "", false
+	}
+	quantity := strings.TrimSpace(match[1])
+	unit := strings.TrimSpace(match[2])
+	text := strings.TrimSpace(match[3])
+	if name, ok := mmUnits[unit]; ok {
+		unit = name
+	}
+	if quantity == "" && unit == "" {
+		return text, true
+	}
+	parts := []string{}
+	for _, part := range []string{quantity, unit, text} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, " "), true
+}
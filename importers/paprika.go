@@ -0,0 +1,100 @@
+package importers
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// Paprika importe les exports de l'application Paprika : archive .paprikarecipes (zip contenant
+// une recette .paprikarecipe par fichier) ou recette .paprikarecipe seule (JSON compressé en gzip)
+// Les photos embarquées (photo_data) ne sont pas reprises, seule image_url l'est
+type Paprika struct{}
+
+// paprikaRecipe champs utilisés d'une recette Paprika
+type paprikaRecipe struct {
+	Name        string   `json:"name"`
+	Ingredients string   `json:"ingredients"`
+	Directions  string   `json:"directions"`
+	Servings    string   `json:"servings"`
+	PrepTime    string   `json:"prep_time"`
+	CookTime    string   `json:"cook_time"`
+	TotalTime   string   `json:"total_time"`
+	Categories  []string `json:"categories"`
+	SourceURL   string   `json:"source_url"`
+	ImageURL    string   `json:"image_url"`
+}
+
+// gzipMagic premiers octets d'un fichier gzip
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Import lit une archive ou une recette seule
+func (Paprika) Import(data []byte) ([]models.Recette, error) {
+	if bytes.HasPrefix(data, gzipMagic) {
+		recette, err := decodePaprika(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return []models.Recette{recette}, nil
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("archive Paprika illisible: %w", err)
+	}
+	recettes := []models.Recette{}
+	for _, file := range archive.File {
+		if file.FileInfo().IsDir() {
+			continue
+		}
+		reader, err := file.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		recette, err := decodePaprika(reader)
+		reader.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Name, err)
+		}
+		recettes = append(recettes, recette)
+	}
+	if len(recettes) == 0 {
+		return nil, ErrNoRecipe
+	}
+	return recettes, nil
+}
+
+// decodePaprika décompresse et convertit une recette
+func decodePaprika(r io.Reader) (models.Recette, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return models.Recette{}, err
+	}
+	defer gz.Close()
+
+	var recipe paprikaRecipe
+	if err := json.NewDecoder(gz).Decode(&recipe); err != nil {
+		return models.Recette{}, err
+	}
+	return models.Recette{
+		Recipe: domain.Recipe{
+			Name:         strings.TrimSpace(recipe.Name),
+			Page:         strings.TrimSpace(recipe.SourceURL),
+			Image:        strings.TrimSpace(recipe.ImageURL),
+			Category:     category(recipe.Categories),
+			Ingredients:  newIngredients(nonEmptyLines(recipe.Ingredients)),
+			Instructions: newInstructions(nonEmptyLines(recipe.Directions)),
+			Servings:     parseServings(recipe.Servings),
+			PrepTime:     parseDuration(recipe.PrepTime),
+			CookTime:     parseDuration(recipe.CookTime),
+			TotalTime:    parseDuration(recipe.TotalTime),
+		},
+	}, nil
+}
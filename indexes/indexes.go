@@ -0,0 +1,245 @@
+// Package indexes déclare les index MongoDB de l'application, les crée au démarrage
+// et signale les écarts entre les index déclarés et ceux présents en base
+package indexes
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Types de clé d'index
+const (
+	Asc  = "asc"
+	Desc = "desc"
+	Text = "text"
+)
+
+// Statuts d'un index dans le rapport
+const (
+	StatusOK         = "ok"         // index déclaré présent avec les mêmes options
+	StatusMissing    = "missing"    // index déclaré absent de la base
+	StatusCreated    = "created"    // index déclaré créé par la synchronisation
+	StatusFailed     = "failed"     // échec de création de l'index déclaré
+	StatusDuplicates = "duplicates" // index unique absent : des documents partagent la même valeur
+	StatusChanged    = "changed"    // index présent sous ce nom avec d'autres clés ou options
+	StatusUnexpected = "unexpected" // index présent en base mais non déclaré
+)
+
+// Key champ d'un index
+type Key struct {
+	Field string `json:"field"`
+	Type  string `json:"type"` // asc, desc ou text
+}
+
+// Collation règles de comparaison des chaînes d'un index
+type Collation struct {
+	Locale   string `json:"locale"`
+	Strength int    `json:"strength"`
+}
+
+// Spec index déclaré
+type Spec struct {
+	Collection string     `json:"collection"`
+	Name       string     `json:"name"`
+	Keys       []Key      `json:"keys"`
+	Unique     bool       `json:"unique,omitempty"`
+	Collation  *Collation `json:"collation,omitempty"`
+	Partial    bson.D     `json:"-"` // filtre partiel : seuls les documents correspondants sont indexés
+}
+
+// Index index présent en base
+type Index struct {
+	Name      string
+	Keys      []Key
+	Unique    bool
+	Collation *Collation
+	Partial   bson.D
+}
+
+// Entry état d'un index dans le rapport
+type Entry struct {
+	Collection string `json:"collection"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Keys       string `json:"keys"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+// Report écarts entre les index déclarés et ceux présents en base
+type Report struct {
+	InSync  bool    `json:"in_sync"`
+	Indexes []Entry `json:"indexes"`
+}
+
+// Model index au format du pilote MongoDB, pour sa création
+func (s Spec) Model() mongo.IndexModel {
+	keys := bson.D{}
+	for _, key := range s.Keys {
+		var value interface{} = 1
+		switch key.Type {
+		case Desc:
+			value = -1
+		case Text:
+			value = "text"
+		}
+		keys = append(keys, bson.E{Key: key.Field, Value: value})
+	}
+	opts := options.Index().SetName(s.Name)
+	if s.Unique {
+		opts.SetUnique(true)
+	}
+	if s.Collation != nil {
+		opts.SetCollation(&options.Collation{Locale: s.Collation.Locale, Strength: s.Collation.Strength})
+	}
+	if len(s.Partial) > 0 {
+		opts.SetPartialFilterExpression(s.Partial)
+	}
+	return mongo.IndexModel{Keys: keys, Options: opts}
+}
+
+// ParseIndex lit un index retourné par listIndexes
+// Les champs d'un index texte sont retrouvés dans ses poids, la clé stockée étant _fts/_ftsx
+func ParseIndex(doc bson.Raw) (Index, error) {
+	var raw struct {
+		Name      string `bson:"name"`
+		Key       bson.D `bson:"key"`
+		Unique    bool   `bson:"unique"`
+		Weights   bson.D `bson:"weights"`
+		Collation *struct {
+			Locale   string `bson:"locale"`
+			Strength int    `bson:"strength"`
+		} `bson:"collation"`
+		Partial bson.D `bson:"partialFilterExpression"`
+	}
+	if err := bson.Unmarshal(doc, &raw); err != nil {
+		return Index{}, fmt.Errorf("index illisible: %w", err)
+	}
+
+	index := Index{Name: raw.Name, Unique: raw.Unique, Partial: raw.Partial}
+	if raw.Collation != nil {
+		index.Collation = &Collation{Locale: raw.Collation.Locale, Strength: raw.Collation.Strength}
+	}
+	for _, elem := range raw.Key {
+		switch {
+		case elem.Key == "_fts":
+			textFields := []string{}
+			for _, weight := range raw.Weights {
+				textFields = append(textFields, weight.Key)
+			}
+			sort.Strings(textFields)
+			for _, field := range textFields {
+				index.Keys = append(index.Keys, Key{Field: field, Type: Text})
+			}
+		case elem.Key == "_ftsx":
+		case isNegative(elem.Value):
+			index.Keys = append(index.Keys, Key{Field: elem.Key, Type: Desc})
+		default:
+			index.Keys = append(index.Keys, Key{Field: elem.Key, Type: Asc})
+		}
+	}
+	return index, nil
+}
+
+// isNegative indique un ordre décroissant (-1 stocké en int32, int64 ou double)
+func isNegative(value interface{}) bool {
+	switch v := value.(type) {
+	case int32:
+		return v < 0
+	case int64:
+		return v < 0
+	case float64:
+		return v < 0
+	}
+	return false
+}
+
+// Compare confronte les index déclarés d'une collection à ceux présents en base
+// L'index _id_, créé par MongoDB, n'est jamais signalé
+func Compare(collection string, specs []Spec, existing []Index) []Entry {
+	byName := map[string]Index{}
+	for _, index := range existing {
+		byName[index.Name] = index
+	}
+
+	entries := []Entry{}
+	declared := map[string]bool{}
+	for _, spec := range specs {
+		declared[spec.Name] = true
+		entry := Entry{Collection: collection, Name: spec.Name, Keys: keysString(spec.Keys), Status: StatusOK}
+		index, ok := byName[spec.Name]
+		if !ok {
+			entry.Status = StatusMissing
+		} else if differences := differences(spec, index); len(differences) > 0 {
+			entry.Status = StatusChanged
+			entry.Detail = strings.Join(differences, "; ")
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, index := range existing {
+		if index.Name == "_id_" || declared[index.Name] {
+			continue
+		}
+		entries = append(entries, Entry{Collection: collection, Name: index.Name, Keys: keysString(index.Keys), Status: StatusUnexpected})
+	}
+	return entries
+}
+
+// differences écarts entre un index déclaré et l'index présent en base sous le même nom
+func differences(spec Spec, index Index) []string {
+	result := []string{}
+	if declared, found := keysString(spec.Keys), keysString(index.Keys); declared != found {
+		result = append(result, fmt.Sprintf("clés : déclarées %s, trouvées %s", declared, found))
+	}
+	if spec.Unique != index.Unique {
+		result = append(result, fmt.Sprintf("unique : déclaré %t, trouvé %t", spec.Unique, index.Unique))
+	}
+	if declared, found := collationString(spec.Collation), collationString(index.Collation); declared != found {
+		result = append(result, fmt.Sprintf("collation : déclarée %s, trouvée %s", declared, found))
+	}
+	if declared, found := filterString(spec.Partial), filterString(index.Partial); declared != found {
+		result = append(result, fmt.Sprintf("filtre partiel : déclaré %s, trouvé %s", declared, found))
+	}
+	return result
+}
+
+// keysString représentation lisible des clés ("page:asc, name:asc")
+// Les champs d'un index texte sont triés, MongoDB ne conservant pas leur ordre
+func keysString(keys []Key) string {
+	parts := []string{}
+	textFields := []string{}
+	for _, key := range keys {
+		if key.Type == Text {
+			textFields = append(textFields, key.Field+":"+Text)
+			continue
+		}
+		parts = append(parts, key.Field+":"+key.Type)
+	}
+	sort.Strings(textFields)
+	return strings.Join(append(parts, textFields...), ", ")
+}
+
+// collationString représentation lisible d'une collation ("simple" si absente)
+func collationString(collation *Collation) string {
+	if collation == nil {
+		return "simple"
+	}
+	return fmt.Sprintf("%s/%d", collation.Locale, collation.Strength)
+}
+
+// filterString représentation JSON d'un filtre partiel ("aucun" si absent)
+func filterString(filter bson.D) string {
+	if len(filter) == 0 {
+		return "aucun"
+	}
+	data, err := bson.MarshalExtJSON(filter, false, false)
+	if err != nil {
+		return fmt.Sprint(filter)
+	}
+	return string(data)
+}
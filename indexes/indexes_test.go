@@ -0,0 +1,96 @@
+package indexes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+func rawIndex(t *testing.T, doc bson.D) bson.Raw {
+	data, err := bson.Marshal(doc)
+	require.NoError(t, err)
+	return data
+}
+
+func TestModel(t *testing.T) {
+	specs := Specs()["recettes"]
+	require.Len(t, specs, 5)
+
+	page := specs[0].Model()
+	assert.Equal(t, bson.D{{Key: "page", Value: 1}}, page.Keys)
+	assert.Equal(t, "page_unique", *page.Options.Name)
+	assert.True(t, *page.Options.Unique)
+	assert.NotNil(t, page.Options.PartialFilterExpression)
+
+	name := specs[1].Model()
+	assert.Equal(t, &options.Collation{Locale: "en", Strength: 2}, name.Options.Collation)
+
+	text := specs[2].Model()
+	assert.Equal(t, bson.D{{Key: "ingredients.quantity", Value: "text"}, {Key: "ingredients.unit", Value: "text"}}, text.Keys)
+
+	created := specs[4].Model()
+	assert.Equal(t, bson.D{{Key: "created_at", Value: -1}}, created.Keys)
+}
+
+func TestParseIndex(t *testing.T) {
+	text, err := ParseIndex(rawIndex(t, bson.D{
+		{Key: "v", Value: 2},
+		{Key: "key", Value: bson.D{{Key: "_fts", Value: "text"}, {Key: "_ftsx", Value: 1}}},
+		{Key: "name", Value: "ingredients_text"},
+		{Key: "weights", Value: bson.D{{Key: "ingredients.unit", Value: 1}, {Key: "ingredients.quantity", Value: 1}}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []Key{{Field: "ingredients.quantity", Type: Text}, {Field: "ingredients.unit", Type: Text}}, text.Keys)
+
+	name, err := ParseIndex(rawIndex(t, bson.D{
+		{Key: "key", Value: bson.D{{Key: "name", Value: int32(1)}, {Key: "created_at", Value: float64(-1)}}},
+		{Key: "name", Value: "name_ci"},
+		{Key: "collation", Value: bson.D{{Key: "locale", Value: "en"}, {Key: "caseLevel", Value: false}, {Key: "strength", Value: int32(2)}}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, []Key{{Field: "name", Type: Asc}, {Field: "created_at", Type: Desc}}, name.Keys)
+	assert.Equal(t, &Collation{Locale: "en", Strength: 2}, name.Collation)
+	assert.False(t, name.Unique)
+
+	_, err = ParseIndex([]byte{1, 2, 3})
+	assert.Error(t, err)
+}
+
+func TestCompare(t *testing.T) {
+	specs := Specs()["recettes"]
+	existing := []Index{
+		{Name: "_id_", Keys: []Key{{Field: "_id", Type: Asc}}},
+		{Name: "page_unique", Keys: []Key{{Field: "page", Type: Asc}}, Unique: true, Partial: specs[0].Partial},
+		{Name: "name_ci", Keys: []Key{{Field: "name", Type: Asc}}},
+		{Name: "ingredients_text", Keys: []Key{{Field: "ingredients.unit", Type: Text}, {Field: "ingredients.quantity", Type: Text}}},
+		{Name: "servings_1", Keys: []Key{{Field: "servings", Type: Asc}}},
+	}
+
+	entries := Compare("recettes", specs, existing)
+	statuses := map[string]string{}
+	for _, entry := range entries {
+		statuses[entry.Name] = entry.Status
+	}
+	assert.Equal(t, map[string]string{
+		"page_unique":      StatusOK,
+		"name_ci":          StatusChanged,
+		"ingredients_text": StatusOK,
+		"category":         StatusMissing,
+		"created_at":       StatusMissing,
+		"servings_1":       StatusUnexpected,
+	}, statuses)
+
+	assert.Equal(t, "collation : déclarée en/2, trouvée simple", entries[1].Detail)
+	assert.Equal(t, "ingredients.quantity:text, ingredients.unit:text", entries[2].Keys)
+}
+
+func TestCompareUniqueAndFilter(t *testing.T) {
+	spec := Specs()["recettes"][0]
+	entries := Compare("recettes", []Spec{spec}, []Index{{Name: "page_unique", Keys: []Key{{Field: "page", Type: Desc}}}})
+	require.Len(t, entries, 1)
+	assert.Equal(t, StatusChanged, entries[0].Status)
+	assert.Equal(t, `clés : déclarées page:asc, trouvées page:desc; unique : déclaré true, trouvé false; filtre partiel : déclaré {"page":{"$gt":""}}, trouvé aucun`, entries[0].Detail)
+}
@@ -0,0 +1,52 @@
+package indexes
+
+import (
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// NameCollation comparaison des noms de recettes insensible à la casse
+// Une requête doit utiliser la même collation que l'index pour en bénéficier
+var NameCollation = &Collation{Locale: "en", Strength: 2}
+
+// registry index déclarés de l'application
+var registry = []Spec{
+	{
+		// Une page n'est importée qu'une fois ; les recettes saisies sans page ne sont pas concernées
+		Collection: "recettes",
+		Name:       "page_unique",
+		Keys:       []Key{{Field: "page", Type: Asc}},
+		Unique:     true,
+		Partial:    bson.D{{Key: "page", Value: bson.D{{Key: "$gt", Value: ""}}}},
+	},
+	{
+		Collection: "recettes",
+		Name:       "name_ci",
+		Keys:       []Key{{Field: "name", Type: Asc}},
+		Collation:  NameCollation,
+	},
+	{
+		// Recherche par ingrédient : le scraper enregistre la ligne complète dans quantity
+		Collection: "recettes",
+		Name:       "ingredients_text",
+		Keys:       []Key{{Field: "ingredients.quantity", Type: Text}, {Field: "ingredients.unit", Type: Text}},
+	},
+	{
+		Collection: "recettes",
+		Name:       "category",
+		Keys:       []Key{{Field: "category", Type: Asc}},
+	},
+	{
+		Collection: "recettes",
+		Name:       "created_at",
+		Keys:       []Key{{Field: "created_at", Type: Desc}},
+	},
+}
+
+// Specs index déclarés, regroupés par collection dans l'ordre de déclaration
+func Specs() map[string][]Spec {
+	specs := map[string][]Spec{}
+	for _, spec := range registry {
+		specs[spec.Collection] = append(specs[spec.Collection], spec)
+	}
+	return specs
+}
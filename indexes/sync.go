@@ -0,0 +1,147 @@
+package indexes
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// namespaceNotFound code d'erreur de listIndexes sur une collection qui n'existe pas encore
+const namespaceNotFound = 26
+
+// Check compare les index déclarés à ceux présents en base, sans rien modifier
+func Check(ctx context.Context, db *mongo.Database) (Report, error) {
+	return run(ctx, db, false)
+}
+
+// Sync crée les index déclarés absents de la base puis retourne le rapport des écarts
+// Les index modifiés ou non déclarés sont signalés mais jamais supprimés : leur remplacement
+// peut bloquer la collection et reste une décision de l'administrateur
+func Sync(ctx context.Context, db *mongo.Database) (Report, error) {
+	return run(ctx, db, true)
+}
+
+// run construit le rapport de chaque collection, en créant les index manquants si create est vrai
+func run(ctx context.Context, db *mongo.Database, create bool) (Report, error) {
+	specs := Specs()
+	collections := make([]string, 0, len(specs))
+	for collection := range specs {
+		collections = append(collections, collection)
+	}
+	sort.Strings(collections)
+
+	report := Report{InSync: true, Indexes: []Entry{}}
+	for _, collection := range collections {
+		existing, err := listIndexes(ctx, db.Collection(collection))
+		if err != nil {
+			return report, err
+		}
+		byName := map[string]Spec{}
+		for _, spec := range specs[collection] {
+			byName[spec.Name] = spec
+		}
+
+		for _, entry := range Compare(collection, specs[collection], existing) {
+			spec := byName[entry.Name]
+			if entry.Status == StatusMissing && spec.Unique {
+				// Un index unique ne peut pas être créé tant que des documents partagent la même valeur
+				detail, err := duplicates(ctx, db.Collection(collection), spec)
+				if err != nil {
+					return report, err
+				}
+				if detail != "" {
+					entry.Status = StatusDuplicates
+					entry.Detail = detail
+				}
+			}
+			if entry.Status == StatusMissing && create {
+				if _, err := db.Collection(collection).Indexes().CreateOne(ctx, spec.Model()); mongo.IsDuplicateKeyError(err) {
+					entry.Status = StatusDuplicates
+					entry.Detail = err.Error()
+				} else if err != nil {
+					entry.Status = StatusFailed
+					entry.Detail = err.Error()
+				} else {
+					entry.Status = StatusCreated
+				}
+			}
+			if entry.Status != StatusOK && entry.Status != StatusCreated {
+				report.InSync = false
+			}
+			report.Indexes = append(report.Indexes, entry)
+		}
+	}
+	return report, nil
+}
+
+// maxDuplicateSamples nombre de valeurs en double citées dans le rapport
+const maxDuplicateSamples = 3
+
+// duplicates décrit les valeurs partagées par plusieurs documents indexés par spec ("" si aucune)
+func duplicates(ctx context.Context, collection *mongo.Collection, spec Spec) (string, error) {
+	group := bson.D{}
+	for _, key := range spec.Keys {
+		group = append(group, bson.E{Key: key.Field, Value: "$" + key.Field})
+	}
+	pipeline := mongo.Pipeline{}
+	if len(spec.Partial) > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: spec.Partial}})
+	}
+	pipeline = append(pipeline,
+		bson.D{{Key: "$group", Value: bson.D{{Key: "_id", Value: group}, {Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}}}}},
+		bson.D{{Key: "$match", Value: bson.D{{Key: "count", Value: bson.D{{Key: "$gt", Value: 1}}}}}},
+	)
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return "", err
+	}
+	defer cursor.Close(ctx)
+
+	count := 0
+	samples := []string{}
+	for cursor.Next(ctx) {
+		count++
+		if len(samples) < maxDuplicateSamples {
+			var value struct {
+				ID bson.D `bson:"_id"`
+			}
+			if err := cursor.Decode(&value); err != nil {
+				return "", err
+			}
+			samples = append(samples, filterString(value.ID))
+		}
+	}
+	if err := cursor.Err(); err != nil || count == 0 {
+		return "", err
+	}
+	return fmt.Sprintf("%d valeur(s) partagée(s) par plusieurs documents, dont %s : à dédoublonner avant la création de l'index",
+		count, strings.Join(samples, ", ")), nil
+}
+
+// listIndexes index présents sur une collection (aucun si elle n'existe pas encore)
+func listIndexes(ctx context.Context, collection *mongo.Collection) ([]Index, error) {
+	cursor, err := collection.Indexes().List(ctx)
+	if err != nil {
+		var commandErr mongo.CommandError
+		if errors.As(err, &commandErr) && commandErr.Code == namespaceNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	indexes := []Index{}
+	for cursor.Next(ctx) {
+		index, err := ParseIndex(cursor.Current)
+		if err != nil {
+			return nil, err
+		}
+		indexes = append(indexes, index)
+	}
+	return indexes, cursor.Err()
+}
@@ -0,0 +1,68 @@
+//go:build integration
+
+package indexes
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testDatabase base MongoDB jetable sur le serveur de MONGODB_URI, supprimée en fin de test
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		t.Skip("MONGODB_URI n'est pas défini")
+	}
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	db := client.Database("api_golang_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		db.Drop(ctx)
+		client.Disconnect(ctx)
+	})
+	return db
+}
+
+func TestSyncReportsDuplicates(t *testing.T) {
+	ctx := context.Background()
+	db := testDatabase(t)
+	_, err := db.Collection("recettes").InsertMany(ctx, []interface{}{
+		bson.M{"name": "Soupe", "page": "https://example.com/soupe"},
+		bson.M{"name": "Soupe bis", "page": "https://example.com/soupe"},
+		bson.M{"name": "Sans page", "page": ""},
+		bson.M{"name": "Sans page bis", "page": ""},
+	})
+	require.NoError(t, err)
+
+	// La vérification signale les doublons sans rien créer, la synchronisation crée les autres index
+	for _, run := range []func(context.Context, *mongo.Database) (Report, error){Check, Sync} {
+		report, err := run(ctx, db)
+		require.NoError(t, err)
+		assert.False(t, report.InSync)
+		statuses := map[string]Entry{}
+		for _, entry := range report.Indexes {
+			statuses[entry.Name] = entry
+		}
+		assert.Equal(t, StatusDuplicates, statuses["page_unique"].Status)
+		assert.Contains(t, statuses["page_unique"].Detail, "https://example.com/soupe")
+		assert.Contains(t, statuses["page_unique"].Detail, "1 valeur(s)")
+	}
+
+	report, err := Check(ctx, db)
+	require.NoError(t, err)
+	for _, entry := range report.Indexes {
+		if entry.Name != "page_unique" {
+			assert.Equal(t, StatusOK, entry.Status, entry.Name)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package ingredients
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// units mots d'unité retirés en tête de ligne (après la quantité)
+var units = map[string]bool{
+	"cup": true, "cups": true, "c": true,
+	"tablespoon": true, "tablespoons": true, "tbsp": true, "tbs": true,
+	"teaspoon": true, "teaspoons": true, "tsp": true,
+	"ounce": true, "ounces": true, "oz": true, "fluid": true,
+	"pound": true, "pounds": true, "lb": true, "lbs": true,
+	"gram": true, "grams": true, "g": true, "kilogram": true, "kilograms": true, "kg": true,
+	"milliliter": true, "milliliters": true, "ml": true, "liter": true, "liters": true, "l": true,
+	"quart": true, "quarts": true, "pint": true, "pints": true, "gallon": true, "gallons": true,
+	"pinch": true, "pinches": true, "dash": true, "dashes": true, "drop": true, "drops": true,
+	"clove": true, "cloves": true, "slice": true, "slices": true, "piece": true, "pieces": true,
+	"can": true, "cans": true, "package": true, "packages": true, "packet": true, "packets": true,
+	"jar": true, "jars": true, "bottle": true, "bottles": true, "bag": true, "bags": true,
+	"box": true, "boxes": true, "container": true, "containers": true, "envelope": true,
+	"stick": true, "sticks": true, "sprig": true, "sprigs": true, "bunch": true, "bunches": true,
+	"head": true, "heads": true, "stalk": true, "stalks": true, "handful": true, "scoop": true,
+	"inch": true, "whole": true, "sheet": true, "sheets": true, "fillet": true, "fillets": true,
+}
+
+// descriptors mots de préparation ou de qualité retirés partout dans la ligne
+var descriptors = map[string]bool{
+	"chopped": true, "diced": true, "minced": true, "sliced": true, "grated": true, "shredded": true,
+	"crushed": true, "peeled": true, "halved": true, "quartered": true, "cubed": true, "mashed": true,
+	"melted": true, "softened": true, "beaten": true, "cooked": true, "uncooked": true, "drained": true,
+	"rinsed": true, "trimmed": true, "thawed": true, "frozen": true, "fresh": true, "freshly": true,
+	"finely": true, "thinly": true, "coarsely": true, "roughly": true, "lightly": true, "firmly": true,
+	"packed": true, "large": true, "small": true, "medium": true, "crumbled": true, "optional": true,
+	"divided": true, "room": true, "temperature": true, "cold": true, "warm": true, "hot": true,
+	"about": true, "plus": true, "more": true, "needed": true, "taste": true, "to": true, "as": true,
+	"of": true, "and": true, "the": true, "a": true, "an": true, "each": true, "into": true,
+	"cut": true, "pieces": true, "pressed": true, "toasted": true, "unpeeled": true, "seeded": true,
+	"pitted": true, "deveined": true, "skinless": true, "boneless": true, "prepared": true,
+}
+
+// cutMarkers marqueurs après lesquels le texte n'est plus le nom de l'ingrédient
+var cutMarkers = []string{" for ", " such as ", " to taste", " as needed"}
+
+// alternativeMarkers marqueurs d'alternative : on garde la partie gauche si elle nomme
+// un ingrédient ("chicken broth or stock"), sinon la partie droite ("fresh or dried bay leaf")
+var alternativeMarkers = []string{" or ", " plus "}
+
+// parenthesesPattern contenu entre parenthèses (poids de conditionnement, marques...)
+var parenthesesPattern = regexp.MustCompile(`\([^)]*\)`)
+
+// Text retourne la ligne brute d'un ingrédient
+// Le scraper stocke actuellement la ligne complète dans Quantity
+func Text(ing models.Ingredient) string {
+	return strings.TrimSpace(ing.Quantity + " " + ing.Unit)
+}
+
+// Names retourne les noms normalisés (non vides) des ingrédients d'une recette
+func Names(ings []models.Ingredient) []string {
+	names := make([]string, 0, len(ings))
+	for _, ing := range ings {
+		if name := Normalize(Text(ing)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Normalize extrait le nom normalisé d'un ingrédient à partir de sa ligne brute
+// ex: "2 Roma tomatoes, quartered" -> "roma tomato"
+func Normalize(line string) string {
+	line = strings.ToLower(line)
+	line = parenthesesPattern.ReplaceAllString(line, " ")
+
+	// Le nom se trouve normalement avant la première virgule ;
+	// si cette partie ne contient que des descripteurs, on essaie la suivante
+	fallback := ""
+	for _, part := range strings.Split(line, ",") {
+		name, lastUnit := normalizePart(part)
+		if name != "" {
+			return name
+		}
+		if fallback == "" && lastUnit != "" {
+			fallback = Singular(lastUnit)
+		}
+	}
+
+	// Ligne composée uniquement d'unités ("2 whole cloves") : l'unité est l'ingrédient
+	return fallback
+}
+
+// normalizePart normalise un segment de ligne d'ingrédient
+// Retourne le nom obtenu et la dernière unité retirée
+func normalizePart(part string) (string, string) {
+	part = " " + part + " "
+	for _, marker := range cutMarkers {
+		if idx := strings.Index(part, marker); idx >= 0 {
+			part = part[:idx] + " "
+		}
+	}
+
+	for _, marker := range alternativeMarkers {
+		if idx := strings.Index(part, marker); idx >= 0 {
+			if name, lastUnit := nameWords(part[:idx]); name != "" {
+				return name, lastUnit
+			}
+			return normalizePart(part[idx+len(marker):])
+		}
+	}
+
+	return nameWords(part)
+}
+
+// nameWords retire quantités, unités de tête et descripteurs d'un segment
+// Retourne le nom obtenu et la dernière unité retirée
+func nameWords(part string) (string, string) {
+	words := strings.FieldsFunc(part, func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '-' && r != '\''
+	})
+
+	var name []string
+	lastUnit := ""
+	leading := true
+	for _, word := range words {
+		word = strings.Trim(word, "-'")
+		if word == "" {
+			continue
+		}
+		if leading && units[word] {
+			lastUnit = word
+			continue
+		}
+		if descriptors[word] {
+			continue
+		}
+		leading = false
+		name = append(name, Singular(word))
+	}
+
+	return strings.Join(name, " "), lastUnit
+}
+
+// irregulars pluriels irréguliers (ou mots invariables) fréquents dans les recettes
+var irregulars = map[string]string{
+	"leaves": "leaf", "halves": "half", "loaves": "loaf", "knives": "knife",
+	"molasses": "molasses", "swiss": "swiss", "grits": "grits", "oats": "oat",
+}
+
+// Singular retourne une forme singulière approximative d'un mot anglais
+func Singular(word string) string {
+	if singular, ok := irregulars[word]; ok {
+		return singular
+	}
+
+	switch {
+	case len(word) <= 3:
+		return word
+	case strings.HasSuffix(word, "ss"), strings.HasSuffix(word, "us"), strings.HasSuffix(word, "is"):
+		return word
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "oes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
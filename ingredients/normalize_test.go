@@ -0,0 +1,63 @@
+package ingredients
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		line     string
+		expected string
+	}{
+		{"2 Roma tomatoes, quartered", "roma tomato"},
+		{"4 cloves garlic, peeled and halved", "garlic"},
+		{"salt to taste", "salt"},
+		{"8.25 fluid ounces bone broth", "bone broth"},
+		{"1 (5.6 ounce) packet chicken flavor rice", "chicken flavor rice"},
+		{"4 cups chopped, cooked chicken meat", "chicken meat"},
+		{"4 cups chicken broth or stock", "chicken broth"},
+		{"1 fresh or dried bay leaf", "dried bay leaf"},
+		{"2 tablespoons plus 1/4 cup vegetable oil", "vegetable oil"},
+		{"1 (12 fluid ounce) can or bottle beer", "beer"},
+		{"2 whole cloves", "clove"},
+		{"grated Parmesan cheese for garnish (optional)", "parmesan cheese"},
+		{"½ cup butter", "butter"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Normalize(tt.line))
+		})
+	}
+}
+
+func TestSingular(t *testing.T) {
+	tests := map[string]string{
+		"tomatoes":  "tomato",
+		"berries":   "berry",
+		"peaches":   "peach",
+		"olives":    "olive",
+		"leaves":    "leaf",
+		"molasses":  "molasses",
+		"asparagus": "asparagus",
+		"egg":       "egg",
+	}
+
+	for word, expected := range tests {
+		assert.Equal(t, expected, Singular(word), word)
+	}
+}
+
+func TestNames(t *testing.T) {
+	names := Names([]models.Ingredient{
+		{Quantity: "1 large egg"},
+		{Quantity: "1/2", Unit: "cup milk"},
+		{Quantity: "(optional)"},
+	})
+
+	assert.Equal(t, []string{"egg", "milk"}, names)
+}
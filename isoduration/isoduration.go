@@ -0,0 +1,77 @@
+package isoduration
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalid durée ISO-8601 invalide
+var ErrInvalid = errors.New("durée ISO-8601 invalide")
+
+// pattern durées de la forme PnDTnHnMnS (années, mois et semaines ne sont pas utilisés par les recettes)
+var pattern = regexp.MustCompile(`^P(?:(\d+)D)?(?:T(?:(\d+)H)?(?:(\d+)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// Format convertit une durée en ISO-8601 à la minute près (ex: 1h10m -> "PT1H10M")
+// Les durées d'une journée ou plus utilisent le jour ("P1DT2H")
+func Format(d time.Duration) string {
+	minutes := int64(d.Round(time.Minute) / time.Minute)
+	if minutes <= 0 {
+		return "PT0M"
+	}
+
+	days := minutes / (24 * 60)
+	hours := (minutes % (24 * 60)) / 60
+	minutes %= 60
+
+	var b strings.Builder
+	b.WriteString("P")
+	if days > 0 {
+		b.WriteString(strconv.FormatInt(days, 10) + "D")
+	}
+	if hours > 0 || minutes > 0 {
+		b.WriteString("T")
+		if hours > 0 {
+			b.WriteString(strconv.FormatInt(hours, 10) + "H")
+		}
+		if minutes > 0 {
+			b.WriteString(strconv.FormatInt(minutes, 10) + "M")
+		}
+	}
+	return b.String()
+}
+
+// Parse convertit une durée ISO-8601 ("PT1H10M", "P1DT2H") en time.Duration
+func Parse(value string) (time.Duration, error) {
+	value = strings.ToUpper(strings.TrimSpace(value))
+	match := pattern.FindStringSubmatch(value)
+	// "P" et "PT" seuls sont acceptés par l'expression mais ne désignent aucune durée
+	if match == nil || value == "P" || strings.HasSuffix(value, "T") {
+		return 0, ErrInvalid
+	}
+
+	units := []time.Duration{24 * time.Hour, time.Hour, time.Minute, time.Second}
+	var total time.Duration
+	for i, unit := range units {
+		if match[i+1] == "" {
+			continue
+		}
+		amount, err := strconv.ParseFloat(match[i+1], 64)
+		if err != nil {
+			return 0, ErrInvalid
+		}
+		total += time.Duration(amount * float64(unit))
+	}
+	return total, nil
+}
+
+// Minutes convertit une durée ISO-8601 en minutes (0 si la durée est vide ou invalide)
+func Minutes(value string) int {
+	d, err := Parse(value)
+	if err != nil {
+		return 0
+	}
+	return int(d.Round(time.Minute) / time.Minute)
+}
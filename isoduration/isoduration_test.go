@@ -0,0 +1,51 @@
+package isoduration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "PT15M", Format(15*time.Minute))
+	assert.Equal(t, "PT1H10M", Format(70*time.Minute))
+	assert.Equal(t, "PT2H", Format(2*time.Hour))
+	assert.Equal(t, "P1DT2H", Format(26*time.Hour))
+	assert.Equal(t, "P1D", Format(24*time.Hour))
+	assert.Equal(t, "PT0M", Format(0))
+}
+
+func TestParse(t *testing.T) {
+	tests := map[string]time.Duration{
+		"PT15M":     15 * time.Minute,
+		"PT1H10M":   70 * time.Minute,
+		"P1DT2H":    26 * time.Hour,
+		"pt30m":     30 * time.Minute,
+		"PT90S":     90 * time.Second,
+		"PT0.5S":    500 * time.Millisecond,
+		"P2D":       48 * time.Hour,
+		"PT1H0M30S": time.Hour + 30*time.Second,
+	}
+	for value, expected := range tests {
+		d, err := Parse(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, d, value)
+	}
+
+	for _, value := range []string{"", "P", "PT", "15M", "PT15", "P1DT", "1h"} {
+		_, err := Parse(value)
+		assert.ErrorIs(t, err, ErrInvalid, value)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, d := range []time.Duration{5 * time.Minute, 95 * time.Minute, 50 * time.Hour} {
+		parsed, err := Parse(Format(d))
+		require.NoError(t, err)
+		assert.Equal(t, d, parsed)
+	}
+	assert.Equal(t, 70, Minutes("PT1H10M"))
+	assert.Equal(t, 0, Minutes("n/a"))
+}
@@ -12,9 +12,14 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	fiberlogger "github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/cli"
+	"github.com/maxime-louis14/api-golang/controllers"
 	"github.com/maxime-louis14/api-golang/database"
+	"github.com/maxime-louis14/api-golang/i18n"
 	"github.com/maxime-louis14/api-golang/logger"
 	"github.com/maxime-louis14/api-golang/middleware"
+	"github.com/maxime-louis14/api-golang/repository"
 	"github.com/maxime-louis14/api-golang/routes"
 )
 
@@ -48,10 +53,7 @@ func metricsHandler(c *fiber.Ctx) error {
 	metricsJSON, err := logger.GetMetricsJSON()
 	if err != nil {
 		logger.LogError("Erreur lors de la récupération des métriques", err, nil)
-		return c.Status(500).JSON(fiber.Map{
-			"error":   true,
-			"message": "Erreur lors de la récupération des métriques",
-		})
+		return middleware.SendError(c, 500, i18n.MetricsFailed)
 	}
 
 	c.Set("Content-Type", "application/json")
@@ -59,6 +61,11 @@ func metricsHandler(c *fiber.Ctx) error {
 }
 
 func main() {
+	// Sous-commandes d'administration (import...) exécutées à la place du serveur
+	if len(os.Args) > 1 {
+		os.Exit(cli.Run(os.Args[1:]))
+	}
+
 	// Affichage des informations de version
 	fmt.Printf("Go API MongoDB Scrapper\n")
 	fmt.Printf("Version: %s\n", version)
@@ -104,6 +111,12 @@ func main() {
 	// Middleware de logging personnalisé
 	app.Use(middleware.LoggingMiddleware())
 
+	// Limitation de débit par IP avant toute vérification de clé (les clés invalides sont décomptées),
+	// puis authentification par clé d'API et limitation de débit par clé
+	app.Use(middleware.IPRateLimitMiddleware())
+	app.Use(middleware.APIKeyMiddleware(auth.FindByKey))
+	app.Use(middleware.KeyRateLimitMiddleware())
+
 	logger.LogInfo("Application Fiber initialisée avec les middlewares", nil)
 
 	// Connexion à MongoDB
@@ -118,8 +131,51 @@ func main() {
 		}
 		logger.LogInfo("Connexion MongoDB fermée", nil)
 	}()
+	db := database.OpenDatabase(client)
 	logger.LogInfo("Connecté à MongoDB", nil)
 
+	// Clé admin initiale (ADMIN_API_KEY) pour pouvoir créer les autres clés
+	auth.Open(db)
+	auth.EnsureBootstrapKey()
+
+	// Stockage des recettes (STORAGE=mongo par défaut, memory pour le développement local)
+	stores, err := controllers.NewStores(db)
+	if err != nil {
+		logger.LogError("Stockage des recettes invalide", err, nil)
+		log.Fatalf("Invalid recipe storage: %v", err)
+	}
+	logger.LogInfo("Stockage des recettes", map[string]interface{}{
+		"storage": repository.Storage(),
+	})
+	recettes := controllers.NewRecetteHandler(stores)
+
+	if repository.Storage() == repository.StorageMongo {
+		// Migrations des données en attente (dont la complétion des recettes existantes), avant toute lecture des recettes
+		controllers.RunMigrations(db)
+
+		// Création des index MongoDB déclarés puis construction des index en mémoire
+		// (similarité, autocomplétion) et des statistiques du corpus
+		go func() {
+			controllers.SyncIndexes(db)
+			recettes.RefreshIndexes()
+			recettes.RefreshRecetteStats()
+		}()
+	} else {
+		// Recettes en mémoire déjà complétées au chargement : seuls les index en mémoire
+		// et les statistiques du corpus sont construits
+		go func() {
+			recettes.RefreshIndexes()
+			recettes.RefreshRecetteStats()
+		}()
+	}
+
+	// Suppression définitive des recettes restées trop longtemps dans la corbeille
+	recettes.StartTrashPurge()
+
+	// Notification des webhooks abonnés aux importations et au scraper
+	webhooks := controllers.NewWebhookHandler(db, stores.Events)
+	webhooks.StartWebhookDispatcher()
+
 	// Route de health check
 	app.Get("/health", func(c *fiber.Ctx) error {
 		// Test de la connexion MongoDB
@@ -164,8 +220,13 @@ func main() {
 	// Route pour les métriques
 	app.Get("/metrics", metricsHandler)
 
-	// Configuration des routes API
-	routes.RecetteRoute(app)
+	// Configuration des routes API (/api/v1 + alias historiques dépréciés)
+	admin := controllers.NewAdminHandler(db)
+	v1 := routes.APIVersion{Name: "v1", Register: func(router fiber.Router) {
+		routes.V1(router, recettes, webhooks, admin)
+	}}
+	routes.APIRoute(app, []routes.APIVersion{v1})
+	routes.LegacyRoute(app, recettes)
 	logger.LogInfo("Routes configurées", nil)
 
 	// Démarrage du logger de métriques périodique (toutes les 30 secondes)
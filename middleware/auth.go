@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// extractAPIKey récupère la clé depuis l'en-tête X-API-Key ou Authorization: Bearer
+func extractAPIKey(c *fiber.Ctx) string {
+	if key := c.Get("X-API-Key"); key != "" {
+		return key
+	}
+	authorization := c.Get(fiber.HeaderAuthorization)
+	if strings.HasPrefix(authorization, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(authorization, "Bearer "))
+	}
+	return ""
+}
+
+// CurrentAPIKey retourne la clé d'API authentifiée pour la requête (nil si anonyme)
+func CurrentAPIKey(c *fiber.Ctx) *models.APIKey {
+	key, _ := c.Locals("apiKey").(*models.APIKey)
+	return key
+}
+
+// KeyFinder retrouve une clé active à partir de sa valeur en clair (auth.FindByKey en production)
+type KeyFinder func(ctx context.Context, plain string) (*models.APIKey, error)
+
+// APIKeyMiddleware authentifie la clé d'API si elle est fournie et comptabilise son utilisation
+// Les requêtes sans clé continuent en anonyme ; RequireScope décide ensuite de l'accès
+func APIKeyMiddleware(find KeyFinder) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		plain := extractAPIKey(c)
+		if plain == "" {
+			return c.Next()
+		}
+
+		requestID, _ := c.Locals("requestID").(string)
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		key, err := find(ctx, plain)
+		cancel()
+		if err != nil {
+			if errors.Is(err, auth.ErrInvalidKey) {
+				logger.LogError("Clé d'API refusée", err, map[string]interface{}{
+					"request_id": requestID,
+					"ip":         c.IP(),
+				})
+				return SendError(c, 401, i18n.APIKeyInvalid)
+			}
+			logger.LogError("Échec de vérification de la clé d'API", err, map[string]interface{}{
+				"request_id": requestID,
+			})
+			return SendError(c, 500, i18n.APIKeyCheckFailed)
+		}
+
+		c.Locals("apiKey", key)
+
+		err = c.Next()
+
+		// Comptabiliser l'utilisation sans bloquer la réponse
+		route := c.Method() + " " + c.Route().Path
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := auth.RecordUsage(ctx, key.ID, route); err != nil {
+				logger.LogError("Échec de mise à jour des compteurs de la clé d'API", err, map[string]interface{}{
+					"request_id": requestID,
+					"key_prefix": key.Prefix,
+				})
+			}
+		}()
+
+		return err
+	}
+}
+
+// RequireScope refuse la requête si aucune clé n'est fournie ou si elle n'a pas le scope demandé
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := CurrentAPIKey(c)
+		if key == nil {
+			return SendError(c, 401, i18n.APIKeyRequired)
+		}
+		if !auth.HasScope(key, scope) {
+			return SendError(c, 403, i18n.InsufficientScope, scope)
+		}
+		return c.Next()
+	}
+}
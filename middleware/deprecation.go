@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeprecationMiddleware signale qu'une route est dépréciée via les en-têtes Deprecation (RFC 9745) et Sunset (RFC 8594)
+// deprecated: date à laquelle la route a été dépréciée, envoyée sous la forme "@<timestamp unix>"
+// sunset: date à partir de laquelle la route ne sera plus servie
+// successor: préfixe de la version qui remplace la route (ex: "/api/v1")
+func DeprecationMiddleware(deprecated, sunset time.Time, successor string) fiber.Handler {
+	deprecationHeader := "@" + strconv.FormatInt(deprecated.Unix(), 10)
+	sunsetHeader := sunset.UTC().Format(http.TimeFormat)
+
+	return func(c *fiber.Ctx) error {
+		c.Set("Deprecation", deprecationHeader)
+		c.Set("Sunset", sunsetHeader)
+		c.Set(fiber.HeaderLink, "<"+successor+c.OriginalURL()+`>; rel="successor-version"`)
+		return c.Next()
+	}
+}
+
+// APIVersionMiddleware indique la version de l'API ayant servi la requête
+func APIVersionMiddleware(version string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		c.Set("X-API-Version", version)
+		return c.Next()
+	}
+}
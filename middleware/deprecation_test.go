@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeprecationHeaders(t *testing.T) {
+	deprecated := time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2027, time.April, 30, 0, 0, 0, 0, time.UTC)
+
+	app := fiber.New()
+	app.Get("/recettes", DeprecationMiddleware(deprecated, sunset, "/api/v1"), func(c *fiber.Ctx) error {
+		return c.SendStatus(200)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/recettes?limit=5", nil), -1)
+	require.NoError(t, err)
+	assert.Equal(t, "@1792368000", resp.Header.Get("Deprecation"))
+	assert.Equal(t, "Fri, 30 Apr 2027 00:00:00 GMT", resp.Header.Get("Sunset"))
+	assert.Equal(t, `</api/v1/recettes?limit=5>; rel="successor-version"`, resp.Header.Get("Link"))
+}
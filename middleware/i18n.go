@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/i18n"
+)
+
+// Language retourne la langue des réponses, négociée d'après l'en-tête Accept-Language
+func Language(c *fiber.Ctx) string {
+	if language, ok := c.Locals("language").(string); ok {
+		return language
+	}
+	language := i18n.Negotiate(c.Get(fiber.HeaderAcceptLanguage))
+	c.Locals("language", language)
+	return language
+}
+
+// Translate annonce la langue de la réponse et retourne le message traduit
+func Translate(c *fiber.Ctx, code i18n.Code, args ...interface{}) string {
+	language := Language(c)
+	c.Set(fiber.HeaderContentLanguage, language)
+	c.Vary(fiber.HeaderAcceptLanguage)
+	return i18n.Message(language, code, args...)
+}
+
+// SendError répond {"error": true, "code": ..., "message": ...} avec le message traduit
+// Le code reste stable d'une langue à l'autre ; les logs n'utilisent jamais le message traduit
+func SendError(c *fiber.Ctx, status int, code i18n.Code, args ...interface{}) error {
+	return c.Status(status).JSON(fiber.Map{
+		"error":   true,
+		"code":    code,
+		"message": Translate(c, code, args...),
+	})
+}
+
+// SendErr répond avec une erreur retournée par une fonction de validation (*i18n.Error de préférence)
+func SendErr(c *fiber.Ctx, status int, err error) error {
+	var localized *i18n.Error
+	if errors.As(err, &localized) {
+		return SendError(c, status, localized.Code, localized.Args...)
+	}
+	return SendError(c, status, i18n.InvalidRequest, err.Error())
+}
+
+// SendMessage répond par un message texte traduit (confirmation d'une opération)
+func SendMessage(c *fiber.Ctx, status int, code i18n.Code, args ...interface{}) error {
+	return c.Status(status).SendString(Translate(c, code, args...))
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/env"
+	"github.com/maxime-louis14/api-golang/i18n"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/ratelimit"
+)
+
+// rateLimitResult clé des Locals où IPRateLimitMiddleware conserve l'état du bucket de l'IP
+const rateLimitResult = "rateLimit"
+
+// setRateLimitHeaders expose l'état du bucket via les en-têtes X-RateLimit-*
+func setRateLimitHeaders(c *fiber.Ctx, result ratelimit.Result) {
+	c.Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+	c.Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+	c.Set("X-RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.Reset.Seconds()))))
+}
+
+// rejectRateLimited répond 429 avec le délai avant la prochaine requête autorisée
+func rejectRateLimited(c *fiber.Ctx, result ratelimit.Result) error {
+	requestID, _ := c.Locals("requestID").(string)
+	retryAfter := int(math.Ceil(result.RetryAfter.Seconds()))
+	logger.LogInfo("Limite de débit atteinte", map[string]interface{}{
+		"request_id": requestID,
+		"ip":         c.IP(),
+		"path":       c.Path(),
+	})
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+	return SendError(c, 429, i18n.RateLimited, retryAfter)
+}
+
+// IPRateLimitMiddleware applique un token bucket par IP à toutes les requêtes
+// Doit être enregistré avant APIKeyMiddleware : chaque tentative de clé, valide ou non,
+// est décomptée de la limite de l'IP avant toute recherche en base
+func IPRateLimitMiddleware() fiber.Handler {
+	limiter := ratelimit.NewLimiter(env.Int("RATE_LIMIT_IP_PER_MINUTE", 60))
+	limiter.StartJanitor(5 * time.Minute)
+
+	return func(c *fiber.Ctx) error {
+		result := limiter.Allow(c.IP())
+		c.Locals(rateLimitResult, result)
+		setRateLimitHeaders(c, result)
+		if !result.Allowed {
+			return rejectRateLimited(c, result)
+		}
+		return c.Next()
+	}
+}
+
+// KeyRateLimitMiddleware applique un token bucket par clé d'API aux requêtes authentifiées :
+// une clé divulguée ne contourne pas la limite par IP
+// L'état du bucket le plus restrictif est exposé via les en-têtes X-RateLimit-*
+// Doit être enregistré après APIKeyMiddleware pour connaître la clé
+func KeyRateLimitMiddleware() fiber.Handler {
+	limiter := ratelimit.NewLimiter(env.Int("RATE_LIMIT_KEY_PER_MINUTE", 300))
+	limiter.StartJanitor(5 * time.Minute)
+
+	return func(c *fiber.Ctx) error {
+		key := CurrentAPIKey(c)
+		if key == nil {
+			return c.Next()
+		}
+
+		result := limiter.AllowWithLimit(key.ID.Hex(), key.RateLimit)
+		if ipResult, ok := c.Locals(rateLimitResult).(ratelimit.Result); ok && result.Allowed && ipResult.Remaining <= result.Remaining {
+			return c.Next()
+		}
+		setRateLimitHeaders(c, result)
+		if !result.Allowed {
+			return rejectRateLimited(c, result)
+		}
+		return c.Next()
+	}
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvalidKeysAreRateLimitedByIP(t *testing.T) {
+	t.Setenv("RATE_LIMIT_IP_PER_MINUTE", "3")
+
+	lookups := 0
+	find := func(ctx context.Context, plain string) (*models.APIKey, error) {
+		lookups++
+		return nil, auth.ErrInvalidKey
+	}
+
+	app := fiber.New()
+	app.Use(IPRateLimitMiddleware())
+	app.Use(APIKeyMiddleware(find))
+	app.Use(KeyRateLimitMiddleware())
+	app.Get("/recettes", func(c *fiber.Ctx) error { return c.SendStatus(200) })
+
+	statuses := []int{}
+	for i := 0; i < 5; i++ {
+		req := httptest.NewRequest("GET", "/recettes", nil)
+		req.Header.Set("X-API-Key", "rk_invalide")
+		resp, err := app.Test(req, -1)
+		require.NoError(t, err)
+		statuses = append(statuses, resp.StatusCode)
+	}
+
+	// Les tentatives au-delà de la limite de l'IP sont refusées sans recherche de la clé
+	assert.Equal(t, []int{401, 401, 401, 429, 429}, statuses)
+	assert.Equal(t, 3, lookups)
+}
@@ -0,0 +1,48 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Enregistre dans MongoDB la mise à niveau des recettes antérieures à schema_version, appliquée
+// jusque-là à chaque lecture ; les copies des révisions et de la quarantaine restent mises à niveau à la lecture
+func init() {
+	register(Migration{
+		Version: 1,
+		Name:    "recettes_schema_version",
+		Up:      upgradeRecettesSchema,
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			// Sans schema_version, les documents sont de nouveau mis à niveau à la lecture
+			_, err := db.Collection("recettes").UpdateMany(ctx, bson.M{}, bson.M{"$unset": bson.M{"schema_version": ""}})
+			return err
+		},
+	})
+}
+
+// upgradeRecettesSchema remplace chaque recette sans schema_version par sa version mise à niveau
+func upgradeRecettesSchema(ctx context.Context, db *mongo.Database) error {
+	collection := db.Collection("recettes")
+	legacy := bson.M{"schema_version": bson.M{"$exists": false}}
+	cursor, err := collection.Find(ctx, legacy)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		upgraded, err := domain.UpgradeBSON(cursor.Current)
+		if err != nil {
+			return err
+		}
+		// Le filtre sur schema_version évite d'écraser une recette réenregistrée entre-temps
+		filter := bson.M{"_id": cursor.Current.Lookup("_id"), "schema_version": bson.M{"$exists": false}}
+		if _, err := collection.ReplaceOne(ctx, filter, bson.Raw(upgraded)); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
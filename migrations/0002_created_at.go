@@ -0,0 +1,36 @@
+package migrations
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// createdAtMarker champ marquant les recettes dont la date de création a été déduite par la migration
+// Une recette réenregistrée depuis perd ce champ et garde sa date à l'annulation, comme les recettes
+// d'une base migrée avant l'ajout du marqueur
+const createdAtMarker = "created_at_backfilled"
+
+// Date de création des recettes enregistrées avant l'ajout de created_at, déduite de leur
+// ObjectID (précision à la seconde) ; l'annulation retire les dates ainsi déduites, et seulement celles-ci
+func init() {
+	register(Migration{
+		Version: 2,
+		Name:    "recettes_created_at",
+		Up: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("recettes").UpdateMany(ctx,
+				bson.M{"created_at": bson.M{"$exists": false}},
+				mongo.Pipeline{{{Key: "$set", Value: bson.M{"created_at": bson.M{"$toDate": "$_id"}, createdAtMarker: true}}}},
+			)
+			return err
+		},
+		Down: func(ctx context.Context, db *mongo.Database) error {
+			_, err := db.Collection("recettes").UpdateMany(ctx,
+				bson.M{createdAtMarker: true},
+				bson.M{"$unset": bson.M{"created_at": "", createdAtMarker: ""}},
+			)
+			return err
+		},
+	})
+}
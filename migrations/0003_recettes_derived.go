@@ -0,0 +1,116 @@
+package migrations
+
+import (
+	"context"
+
+	"github.com/maxime-louis14/api-golang/enrich"
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// derivedMarker champ listant les champs ajoutés par la migration, seuls retirés par l'annulation
+// Une recette réenregistrée depuis perd ce champ : ses données dérivées ne sont plus celles de la migration
+const derivedMarker = "derived_backfilled"
+
+// derivedFields données dérivées (enrich.Recette) absentes des recettes importées avant leur ajout
+var derivedFields = []string{"ingredient_names", "diets", "allergens", "prep_minutes", "cook_minutes", "total_minutes"}
+
+// Données dérivées (ingrédients normalisés, régimes, allergènes, durées en minutes) des recettes
+// importées avant leur ajout, calculées jusque-là à chaque démarrage du serveur
+func init() {
+	register(Migration{
+		Version: 3,
+		Name:    "recettes_derived",
+		Up:      backfillDerived,
+		Down:    removeDerived,
+	})
+}
+
+// backfillDerived calcule les données dérivées manquantes et note dans derivedMarker les champs ajoutés
+func backfillDerived(ctx context.Context, db *mongo.Database) error {
+	collection := db.Collection("recettes")
+	missing := bson.A{}
+	for _, field := range derivedFields {
+		missing = append(missing, bson.M{field: bson.M{"$exists": false}})
+	}
+	cursor, err := collection.Find(ctx, bson.M{"$or": missing})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var recette models.Recette
+		if err := cursor.Decode(&recette); err != nil {
+			return err
+		}
+		totalTime := recette.TotalTime
+		enrich.Recette(&recette)
+		values := bson.M{
+			"ingredient_names": recette.IngredientNames,
+			"diets":            recette.Diets,
+			"allergens":        recette.Allergens,
+			"prep_minutes":     recette.PrepMinutes,
+			"cook_minutes":     recette.CookMinutes,
+			"total_minutes":    recette.TotalMinutes,
+		}
+
+		set := bson.M{}
+		added := bson.A{}
+		for _, field := range derivedFields {
+			if _, err := cursor.Current.LookupErr(field); err != nil {
+				set[field] = values[field]
+				added = append(added, field)
+			}
+		}
+		// Temps total déduit de la préparation et de la cuisson, quand il n'était pas renseigné
+		if totalTime == "" && recette.TotalTime != "" {
+			set["total_time"] = recette.TotalTime
+			added = append(added, "total_time")
+		}
+		set[derivedMarker] = added
+		if _, err := collection.UpdateByID(ctx, recette.ID, bson.M{"$set": set}); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// removeDerived retire les champs ajoutés par backfillDerived, d'après derivedMarker
+func removeDerived(ctx context.Context, db *mongo.Database) error {
+	collection := db.Collection("recettes")
+	cursor, err := collection.Find(ctx, bson.M{derivedMarker: bson.M{"$exists": true}})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID    interface{} `bson:"_id"`
+			Added []string    `bson:"derived_backfilled"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		set := bson.M{}
+		unset := bson.M{derivedMarker: ""}
+		for _, field := range doc.Added {
+			if field == "total_time" {
+				// Temps total de nouveau non renseigné
+				set[field] = ""
+				continue
+			}
+			unset[field] = ""
+		}
+		update := bson.M{"$unset": unset}
+		if len(set) > 0 {
+			update["$set"] = set
+		}
+		if _, err := collection.UpdateByID(ctx, doc.ID, update); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
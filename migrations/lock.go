@@ -0,0 +1,89 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// lockTTL durée après laquelle un verrou non renouvelé (instance arrêtée en pleine migration) est repris
+const lockTTL = 10 * time.Minute
+
+// lockID identifiant de l'unique document de verrou
+const lockID = "migrations"
+
+// ErrLocked des migrations sont déjà en cours sur une autre instance
+var ErrLocked = errors.New("migrations déjà en cours sur une autre instance")
+
+// lock verrou distribué : un document de la collection migrations_lock, dont l'_id unique
+// garantit qu'une seule instance le détient
+type lock struct {
+	collection *mongo.Collection
+	owner      string
+}
+
+// acquireLock prend le verrou, ou retourne ErrLocked s'il est détenu et n'a pas expiré
+func acquireLock(ctx context.Context, db *mongo.Database) (*lock, error) {
+	hostname, _ := os.Hostname()
+	l := &lock{
+		collection: db.Collection("migrations_lock"),
+		owner:      fmt.Sprintf("%s/%d/%s", hostname, os.Getpid(), primitive.NewObjectID().Hex()),
+	}
+
+	// Le filtre ne correspond qu'à un verrou expiré : si le verrou est détenu, l'upsert tente
+	// d'insérer un second document de même _id et échoue sur la clé dupliquée
+	now := time.Now().UTC()
+	filter := bson.M{"_id": lockID, "expires_at": bson.M{"$lt": now}}
+	update := bson.M{"$set": bson.M{"owner": l.owner, "acquired_at": now, "expires_at": now.Add(lockTTL)}}
+	if _, err := l.collection.UpdateOne(ctx, filter, update, options.Update().SetUpsert(true)); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrLocked
+		}
+		return nil, err
+	}
+	return l, nil
+}
+
+// heartbeat renouvelle le verrou à chaque intervalle jusqu'à l'annulation du contexte des migrations
+// Un renouvellement en échec annule ce contexte avec l'erreur pour cause
+func (l *lock) heartbeat(ctx context.Context, interval time.Duration, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.refresh(ctx); err != nil {
+				cancel(err)
+				return
+			}
+		}
+	}
+}
+
+// refresh prolonge le verrou
+func (l *lock) refresh(ctx context.Context) error {
+	update := bson.M{"$set": bson.M{"expires_at": time.Now().UTC().Add(lockTTL)}}
+	result, err := l.collection.UpdateOne(ctx, bson.M{"_id": lockID, "owner": l.owner}, update)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("verrou des migrations perdu (expiré après %s)", lockTTL)
+	}
+	return nil
+}
+
+// release libère le verrou s'il est toujours détenu par cette instance
+func (l *lock) release(ctx context.Context) error {
+	_, err := l.collection.DeleteOne(ctx, bson.M{"_id": lockID, "owner": l.owner})
+	return err
+}
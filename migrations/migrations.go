@@ -0,0 +1,123 @@
+// Package migrations fait évoluer les données MongoDB par étapes numérotées, réversibles
+// Chaque migration est déclarée dans un fichier NNNN_nom.go ; les versions appliquées sont
+// enregistrées dans la collection migrations et un verrou empêche deux instances de migrer en même temps
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Migration étape de migration : Up applique la modification, Down l'annule
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, db *mongo.Database) error
+	Down    func(ctx context.Context, db *mongo.Database) error
+}
+
+// Record migration appliquée, enregistrée dans la collection migrations
+type Record struct {
+	Version   int       `json:"version" bson:"_id"`
+	Name      string    `json:"name" bson:"name"`
+	AppliedAt time.Time `json:"applied_at" bson:"applied_at"`
+	Duration  string    `json:"duration" bson:"duration"`
+}
+
+// State état d'une migration pour la commande status
+type State struct {
+	Version   int        `json:"version"`
+	Name      string     `json:"name"`
+	Applied   bool       `json:"applied"`
+	AppliedAt *time.Time `json:"applied_at,omitempty"`
+	Unknown   bool       `json:"unknown,omitempty"` // appliquée par une version plus récente de l'application
+}
+
+// registry migrations déclarées par les fichiers du package
+var registry = []Migration{}
+
+// register déclare une migration ; une version en double est une erreur de programmation
+func register(migration Migration) {
+	for _, existing := range registry {
+		if existing.Version == migration.Version {
+			panic(fmt.Sprintf("migration %d déclarée deux fois (%s, %s)", migration.Version, existing.Name, migration.Name))
+		}
+	}
+	registry = append(registry, migration)
+}
+
+// All migrations déclarées, par version croissante
+func All() []Migration {
+	all := append([]Migration(nil), registry...)
+	sort.Slice(all, func(i, j int) bool { return all[i].Version < all[j].Version })
+	return all
+}
+
+// pending migrations non appliquées jusqu'à la version target incluse (toutes si target vaut 0)
+func pending(all []Migration, applied map[int]Record, target int) []Migration {
+	result := []Migration{}
+	for _, migration := range all {
+		if target > 0 && migration.Version > target {
+			break
+		}
+		if _, ok := applied[migration.Version]; !ok {
+			result = append(result, migration)
+		}
+	}
+	return result
+}
+
+// toRevert dernières migrations appliquées à annuler, de la plus récente à la plus ancienne
+// Une migration appliquée mais inconnue de cette version de l'application ne peut pas être annulée
+func toRevert(all []Migration, applied map[int]Record, steps int) ([]Migration, error) {
+	byVersion := map[int]Migration{}
+	for _, migration := range all {
+		byVersion[migration.Version] = migration
+	}
+	versions := make([]int, 0, len(applied))
+	for version := range applied {
+		versions = append(versions, version)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(versions)))
+
+	result := []Migration{}
+	for _, version := range versions {
+		if len(result) == steps {
+			break
+		}
+		migration, ok := byVersion[version]
+		if !ok {
+			return nil, fmt.Errorf("migration %d (%s) inconnue de cette version de l'application", version, applied[version].Name)
+		}
+		result = append(result, migration)
+	}
+	return result, nil
+}
+
+// states état de chaque migration déclarée ou appliquée, par version croissante
+func states(all []Migration, applied map[int]Record) []State {
+	result := []State{}
+	known := map[int]bool{}
+	for _, migration := range all {
+		known[migration.Version] = true
+		state := State{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			state.Applied = true
+			state.AppliedAt = &appliedAt
+		}
+		result = append(result, state)
+	}
+	for version, record := range applied {
+		if !known[version] {
+			appliedAt := record.AppliedAt
+			result = append(result, State{Version: version, Name: record.Name, Applied: true, AppliedAt: &appliedAt, Unknown: true})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result
+}
@@ -0,0 +1,79 @@
+//go:build integration
+
+package migrations
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testDatabase base MongoDB jetable sur le serveur de MONGODB_URI, supprimée en fin de test
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		t.Skip("MONGODB_URI n'est pas défini")
+	}
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	db := client.Database("api_golang_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		db.Drop(ctx)
+		client.Disconnect(ctx)
+	})
+	return db
+}
+
+// L'annulation retire seulement les valeurs ajoutées par les migrations
+func TestBackfillUpAndDown(t *testing.T) {
+	ctx := context.Background()
+	db := testDatabase(t)
+	collection := db.Collection("recettes")
+
+	createdAt := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	legacy := bson.M{
+		"_id": primitive.NewObjectID(), "schema_version": 1, "name": "Omelette",
+		"ingredients": bson.A{bson.M{"quantity": "3 eggs", "unit": ""}},
+		"prep_time":   "PT10M", "cook_time": "PT5M", "total_time": "",
+	}
+	recent := bson.M{
+		"_id": primitive.NewObjectID(), "schema_version": 1, "name": "Crêpes", "created_at": createdAt,
+		"ingredients":      bson.A{bson.M{"quantity": "1 cup flour", "unit": ""}},
+		"ingredient_names": bson.A{"flour"}, "diets": bson.A{}, "allergens": bson.A{"gluten"},
+		"prep_minutes": 0, "cook_minutes": 0, "total_minutes": 0,
+	}
+	_, err := collection.InsertMany(ctx, []interface{}{legacy, recent})
+	require.NoError(t, err)
+
+	_, err = Up(ctx, db, 0)
+	require.NoError(t, err)
+	var doc bson.M
+	require.NoError(t, collection.FindOne(ctx, bson.M{"_id": legacy["_id"]}).Decode(&doc))
+	assert.Contains(t, doc, "created_at")
+	assert.Equal(t, bson.A{"eggs"}, doc["allergens"])
+	assert.EqualValues(t, 15, doc["total_minutes"])
+	assert.Equal(t, "PT15M", doc["total_time"])
+
+	_, err = Down(ctx, db, 2)
+	require.NoError(t, err)
+	doc = bson.M{}
+	require.NoError(t, collection.FindOne(ctx, bson.M{"_id": legacy["_id"]}).Decode(&doc))
+	for _, field := range append(derivedFields, "created_at", createdAtMarker, derivedMarker) {
+		assert.NotContains(t, doc, field)
+	}
+	assert.Equal(t, "", doc["total_time"])
+	doc = bson.M{}
+	require.NoError(t, collection.FindOne(ctx, bson.M{"_id": recent["_id"]}).Decode(&doc))
+	assert.Equal(t, primitive.NewDateTimeFromTime(createdAt), doc["created_at"])
+	assert.Equal(t, bson.A{"gluten"}, doc["allergens"])
+}
@@ -0,0 +1,77 @@
+package migrations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	all := All()
+	require.NotEmpty(t, all)
+	for i, migration := range all {
+		// Versions consécutives à partir de 1, chaque migration est réversible
+		assert.Equal(t, i+1, migration.Version)
+		assert.NotEmpty(t, migration.Name)
+		assert.NotNil(t, migration.Up, migration.Name)
+		assert.NotNil(t, migration.Down, migration.Name)
+	}
+
+	assert.Panics(t, func() { register(Migration{Version: 1, Name: "doublon"}) })
+}
+
+func testMigrations() []Migration {
+	return []Migration{{Version: 1, Name: "un"}, {Version: 2, Name: "deux"}, {Version: 3, Name: "trois"}}
+}
+
+func versions(migrations []Migration) []int {
+	result := []int{}
+	for _, migration := range migrations {
+		result = append(result, migration.Version)
+	}
+	return result
+}
+
+func TestPending(t *testing.T) {
+	all := testMigrations()
+	assert.Equal(t, []int{1, 2, 3}, versions(pending(all, map[int]Record{}, 0)))
+	assert.Equal(t, []int{2, 3}, versions(pending(all, map[int]Record{1: {Version: 1}}, 0)))
+	assert.Equal(t, []int{2}, versions(pending(all, map[int]Record{1: {Version: 1}}, 2)))
+	assert.Empty(t, pending(all, map[int]Record{1: {}, 2: {}, 3: {}}, 0))
+}
+
+func TestToRevert(t *testing.T) {
+	all := testMigrations()
+	applied := map[int]Record{1: {Version: 1}, 2: {Version: 2}}
+
+	migrations, err := toRevert(all, applied, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, versions(migrations))
+
+	migrations, err = toRevert(all, applied, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int{2, 1}, versions(migrations))
+
+	migrations, err = toRevert(all, map[int]Record{}, 1)
+	require.NoError(t, err)
+	assert.Empty(t, migrations)
+
+	_, err = toRevert(all, map[int]Record{4: {Version: 4, Name: "future"}}, 1)
+	assert.EqualError(t, err, "migration 4 (future) inconnue de cette version de l'application")
+}
+
+func TestStates(t *testing.T) {
+	appliedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	result := states(testMigrations(), map[int]Record{
+		1: {Version: 1, Name: "un", AppliedAt: appliedAt},
+		4: {Version: 4, Name: "future", AppliedAt: appliedAt},
+	})
+
+	require.Len(t, result, 4)
+	assert.Equal(t, State{Version: 1, Name: "un", Applied: true, AppliedAt: &appliedAt}, result[0])
+	assert.Equal(t, State{Version: 2, Name: "deux"}, result[1])
+	assert.False(t, result[2].Applied)
+	assert.Equal(t, State{Version: 4, Name: "future", Applied: true, AppliedAt: &appliedAt, Unknown: true}, result[3])
+}
@@ -0,0 +1,121 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Up applique les migrations en attente jusqu'à la version target incluse (toutes si target vaut 0)
+// et retourne celles qui ont été appliquées ; la première erreur interrompt la série
+func Up(ctx context.Context, db *mongo.Database, target int) ([]Record, error) {
+	return withLock(ctx, db, func(ctx context.Context, applied map[int]Record) ([]Record, error) {
+		done := []Record{}
+		for _, migration := range pending(All(), applied, target) {
+			start := time.Now()
+			if err := migration.Up(ctx, db); err != nil {
+				return done, fmt.Errorf("migration %d (%s): %w", migration.Version, migration.Name, err)
+			}
+			record := Record{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now().UTC(),
+				Duration:  time.Since(start).String(),
+			}
+			if _, err := db.Collection("migrations").InsertOne(ctx, record); err != nil {
+				return done, fmt.Errorf("migration %d (%s) appliquée mais non enregistrée: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, record)
+		}
+		return done, nil
+	})
+}
+
+// Down annule les steps dernières migrations appliquées, de la plus récente à la plus ancienne
+func Down(ctx context.Context, db *mongo.Database, steps int) ([]Record, error) {
+	return withLock(ctx, db, func(ctx context.Context, applied map[int]Record) ([]Record, error) {
+		migrations, err := toRevert(All(), applied, steps)
+		if err != nil {
+			return nil, err
+		}
+		done := []Record{}
+		for _, migration := range migrations {
+			start := time.Now()
+			if err := migration.Down(ctx, db); err != nil {
+				return done, fmt.Errorf("annulation de la migration %d (%s): %w", migration.Version, migration.Name, err)
+			}
+			if _, err := db.Collection("migrations").DeleteOne(ctx, bson.M{"_id": migration.Version}); err != nil {
+				return done, fmt.Errorf("migration %d (%s) annulée mais toujours enregistrée: %w", migration.Version, migration.Name, err)
+			}
+			done = append(done, Record{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: applied[migration.Version].AppliedAt,
+				Duration:  time.Since(start).String(),
+			})
+		}
+		return done, nil
+	})
+}
+
+// Status état de chaque migration, sans prendre le verrou
+func Status(ctx context.Context, db *mongo.Database) ([]State, error) {
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	return states(All(), applied), nil
+}
+
+// withLock exécute run avec le verrou des migrations et la liste des migrations appliquées
+// Le verrou est renouvelé toutes les lockTTL/3 pendant l'exécution ; si un renouvellement échoue,
+// le contexte passé à run est annulé pour ne pas poursuivre sans verrou
+func withLock(ctx context.Context, db *mongo.Database, run func(ctx context.Context, applied map[int]Record) ([]Record, error)) ([]Record, error) {
+	l, err := acquireLock(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	// Libération même si le contexte a expiré pendant les migrations
+	defer func() {
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		l.release(releaseCtx)
+	}()
+
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	go l.heartbeat(ctx, lockTTL/3, cancel)
+
+	applied, err := appliedMigrations(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	done, err := run(ctx, applied)
+	if err != nil && context.Cause(ctx) != ctx.Err() {
+		// Verrou perdu : la cause explique l'annulation mieux que "context canceled"
+		err = fmt.Errorf("%w (%v)", err, context.Cause(ctx))
+	}
+	return done, err
+}
+
+// appliedMigrations migrations enregistrées dans la collection migrations, par version
+func appliedMigrations(ctx context.Context, db *mongo.Database) (map[int]Record, error) {
+	cursor, err := db.Collection("migrations").Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var records []Record
+	if err := cursor.All(ctx, &records); err != nil {
+		return nil, err
+	}
+	applied := map[int]Record{}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
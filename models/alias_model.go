@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// RecetteAlias recette fusionnée dans une recette canonique lors du dédoublonnage
+type RecetteAlias struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id" swagger:"description(Identifiant de la recette fusionnée)"`
+	RecetteID primitive.ObjectID `json:"recette_id" bson:"recette_id" swagger:"description(Identifiant de la recette canonique)"`
+	Name      string             `json:"name" swagger:"description(Nom de la recette fusionnée)"`
+	Page      string             `json:"page" swagger:"description(Page de la recette fusionnée)"`
+	Author    string             `json:"author,omitempty" bson:"author,omitempty" swagger:"description(Nom de la clé d'API ayant fusionné la recette)"`
+	MergedAt  time.Time          `json:"merged_at" bson:"merged_at" swagger:"description(Date de la fusion)"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// APIKey représente une clé d'API stockée en base (seul le hash de la clé est conservé)
+type APIKey struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name         string             `json:"name" bson:"name"`
+	Prefix       string             `json:"prefix" bson:"prefix"`
+	KeyHash      string             `json:"-" bson:"key_hash"`
+	Scopes       []string           `json:"scopes" bson:"scopes"`
+	RateLimit    int                `json:"rate_limit" bson:"rate_limit"`
+	Revoked      bool               `json:"revoked" bson:"revoked"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	LastUsedAt   time.Time          `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+	RequestCount int64              `json:"request_count" bson:"request_count"`
+	Usage        map[string]int64   `json:"usage,omitempty" bson:"usage,omitempty"`
+}
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// QualityIssue règle de qualité non respectée par une recette
+type QualityIssue struct {
+	Rule    string `json:"rule" swagger:"description(Règle : required, min_ingredients, min_instructions, valid_url, duplicate_step)"`
+	Field   string `json:"field,omitempty" bson:"field,omitempty" swagger:"description(Champ concerné)"`
+	Message string `json:"message" swagger:"description(Description du problème)"`
+}
+
+// QuarantinedRecette recette écartée à l'importation, en attente de correction
+type QuarantinedRecette struct {
+	ID        primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty" swagger:"description(Identifiant de l'élément en quarantaine)"`
+	Recette   Recette            `json:"recette" swagger:"description(Recette telle qu'importée ou corrigée)"`
+	Issues    []QualityIssue     `json:"issues" swagger:"description(Règles non respectées)"`
+	RunID     string             `json:"run_id,omitempty" bson:"run_id,omitempty" swagger:"description(Importation ayant écarté la recette)"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at" swagger:"description(Date de mise en quarantaine)"`
+	UpdatedAt time.Time          `json:"updated_at" bson:"updated_at" swagger:"description(Date de la dernière correction ou réimportation)"`
+}
@@ -1,19 +1,127 @@
 package models
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Recette recette enregistrée : format partagé avec le scraper (domain.Recipe) et données calculées par l'API
 type Recette struct {
-	Name         string        `json:"name" swagger:"description(Nom de la recette)"`
-	Page         string        `json:"page" swagger:"description(URL de la page de la recette)"`
-	Image        string        `json:"image" swagger:"description(URL de l'image de la recette)"`
-	Ingredients  []Ingredient  `json:"ingredients" swagger:"description(Liste des ingrédients de la recette)"`
-	Instructions []Instruction `json:"Instructions" swagger:"description(Liste des instructions de la recette)"`
+	ID              primitive.ObjectID `json:"id,omitempty" bson:"_id,omitempty" swagger:"description(Identifiant de la recette)"`
+	domain.Recipe   `bson:",inline"`
+	Tags            []string   `json:"tags,omitempty" bson:"tags,omitempty" swagger:"description(Étiquettes libres)"`
+	IngredientNames []string   `json:"-" bson:"ingredient_names" swagger:"description(Noms normalisés et uniques des ingrédients, pour les statistiques)"`
+	Diets           []DietTag  `json:"diets,omitempty" swagger:"description(Classification alimentaire calculée à l'importation)"`
+	Allergens       []string   `json:"allergens" swagger:"description(Allergènes réglementés détectés dans les ingrédients)"`
+	PrepMinutes     int        `json:"prep_minutes,omitempty" bson:"prep_minutes" swagger:"description(Temps de préparation en minutes)"`
+	CookMinutes     int        `json:"cook_minutes,omitempty" bson:"cook_minutes" swagger:"description(Temps de cuisson en minutes)"`
+	TotalMinutes    int        `json:"total_minutes,omitempty" bson:"total_minutes" swagger:"description(Temps total en minutes)"`
+	CreatedAt       *time.Time `json:"created_at,omitempty" bson:"created_at,omitempty" swagger:"description(Date du premier enregistrement)"`
+	DeletedAt       *time.Time `json:"deleted_at,omitempty" bson:"deleted_at,omitempty" swagger:"description(Date de mise à la corbeille)"`
+	ImportRun       string     `json:"import_run,omitempty" bson:"import_run,omitempty" swagger:"description(Identifiant de l'importation ayant écrit cette version)"`
+	Aliases         []string   `json:"aliases,omitempty" bson:"aliases,omitempty" swagger:"description(Pages des recettes fusionnées dans celle-ci)"`
+}
+
+// Ingredient et Instruction sont définis par le package domain
+type (
+	Ingredient  = domain.Ingredient
+	Instruction = domain.Instruction
+)
+
+// UnmarshalJSON met à niveau les recettes écrites avec un schéma antérieur (data.json, corps de requête) avant de les décoder
+func (r *Recette) UnmarshalJSON(data []byte) error {
+	data, err := domain.UpgradeJSON(data)
+	if err != nil {
+		return err
+	}
+	type plain Recette
+	return json.Unmarshal(data, (*plain)(r))
+}
+
+// RecetteV1 représentation JSON d'une recette dans l'API v1
+// Les étapes restent sous la clé "Instructions" des premières versions de l'API, alors que le format
+// partagé (domain.Recipe) les écrit sous "instructions"
+type RecetteV1 struct {
+	ID            primitive.ObjectID `json:"id,omitempty"`
+	SchemaVersion int                `json:"schema_version"`
+	Name          string             `json:"name"`
+	Page          string             `json:"page"`
+	Image         string             `json:"image"`
+	ImageKey      string             `json:"image_key,omitempty"`
+	Category      string             `json:"category,omitempty"`
+	Ingredients   []Ingredient       `json:"ingredients"`
+	Instructions  []Instruction      `json:"Instructions"`
+	PrepTime      string             `json:"prep_time,omitempty"`
+	CookTime      string             `json:"cook_time,omitempty"`
+	TotalTime     string             `json:"total_time,omitempty"`
+	Servings      int                `json:"servings,omitempty"`
+	Tags          []string           `json:"tags,omitempty"`
+	Diets         []DietTag          `json:"diets,omitempty"`
+	Allergens     []string           `json:"allergens"`
+	PrepMinutes   int                `json:"prep_minutes,omitempty"`
+	CookMinutes   int                `json:"cook_minutes,omitempty"`
+	TotalMinutes  int                `json:"total_minutes,omitempty"`
+	CreatedAt     *time.Time         `json:"created_at,omitempty"`
+	DeletedAt     *time.Time         `json:"deleted_at,omitempty"`
+	ImportRun     string             `json:"import_run,omitempty"`
+	Aliases       []string           `json:"aliases,omitempty"`
+}
+
+// V1 convertit la recette dans sa représentation de l'API v1
+func (r Recette) V1() RecetteV1 {
+	return RecetteV1{
+		ID:            r.ID,
+		SchemaVersion: r.SchemaVersion,
+		Name:          r.Name,
+		Page:          r.Page,
+		Image:         r.Image,
+		ImageKey:      r.ImageKey,
+		Category:      r.Category,
+		Ingredients:   r.Ingredients,
+		Instructions:  r.Instructions,
+		PrepTime:      r.PrepTime,
+		CookTime:      r.CookTime,
+		TotalTime:     r.TotalTime,
+		Servings:      r.Servings,
+		Tags:          r.Tags,
+		Diets:         r.Diets,
+		Allergens:     r.Allergens,
+		PrepMinutes:   r.PrepMinutes,
+		CookMinutes:   r.CookMinutes,
+		TotalMinutes:  r.TotalMinutes,
+		CreatedAt:     r.CreatedAt,
+		DeletedAt:     r.DeletedAt,
+		ImportRun:     r.ImportRun,
+		Aliases:       r.Aliases,
+	}
+}
+
+// MarshalJSON encode la recette au format de l'API v1 (voir RecetteV1)
+// Le décodage accepte les deux clés : UnmarshalJSON met à niveau "Instructions" et encoding/json ignore la casse
+func (r Recette) MarshalJSON() ([]byte, error) {
+	return json.Marshal(r.V1())
 }
 
-type Ingredient struct {
-	Quantity string `json:"quantity" swagger:"description(Quantité de l'ingrédient)"`
-	Unit     string `json:"unit" swagger:"description(Unité de mesure de l'ingrédient)"`
+// UnmarshalBSON met à niveau les documents MongoDB écrits avec un schéma antérieur avant de les décoder
+func (r *Recette) UnmarshalBSON(data []byte) error {
+	data, err := domain.UpgradeBSON(data)
+	if err != nil {
+		return err
+	}
+	type plain Recette
+	return bson.Unmarshal(data, (*plain)(r))
 }
 
-type Instruction struct {
-	Number      string `json:"number" swagger:"description(Numéro de l'instruction)"`
-	Description string `json:"description" swagger:"description(Description de l'instruction)"`
+// DietTag résultat de la classification d'une recette pour un régime alimentaire
+type DietTag struct {
+	Name       string   `json:"name" swagger:"description(Régime : vegetarian, vegan, pescatarian, gluten-free, dairy-free, keto)"`
+	Compatible bool     `json:"compatible" swagger:"description(La recette respecte le régime)"`
+	Confidence float64  `json:"confidence" swagger:"description(Confiance dans le résultat, de 0 à 1)"`
+	Triggers   []string `json:"triggers" swagger:"description(Ingrédients en faveur du régime)"`
+	Blockers   []string `json:"blockers" swagger:"description(Ingrédients incompatibles avec le régime)"`
+	Uncertain  []string `json:"uncertain" swagger:"description(Ingrédients pouvant être incompatibles (optionnels, alternatives, composition variable))"`
 }
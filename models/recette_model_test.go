@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecetteJSONKeepsV1InstructionsKey(t *testing.T) {
+	recette := Recette{Recipe: domain.Recipe{
+		SchemaVersion: domain.SchemaVersion,
+		Name:          "Soupe",
+		Instructions:  []Instruction{{Number: "1", Description: "Chauffer."}},
+	}}
+
+	data, err := json.Marshal(recette)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Instructions":[{"number":"1","description":"Chauffer."}]`)
+	assert.NotContains(t, string(data), `"instructions"`)
+	assert.Contains(t, string(data), `"name":"Soupe"`)
+
+	// Une réponse v1 renvoyée telle quelle se relit sans perte
+	var decoded Recette
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, recette.Instructions, decoded.Instructions)
+
+	// Le format partagé reste en minuscules
+	data, err = json.Marshal(recette.Recipe)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"instructions"`)
+}
+
+// Chaque champ exposé d'une recette a son équivalent dans la représentation v1
+func TestRecetteV1CoversAllFields(t *testing.T) {
+	v1 := map[string]bool{}
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(RecetteV1{})) {
+		v1[jsonName(field)] = true
+	}
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(Recette{})) {
+		name := jsonName(field)
+		if field.Anonymous || name == "-" {
+			continue
+		}
+		if name == "instructions" {
+			name = "Instructions"
+		}
+		assert.True(t, v1[name], "champ %s absent de RecetteV1", field.Name)
+	}
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
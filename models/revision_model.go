@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Origines d'une révision de recette
+const (
+	RevisionSourceScrape  = "scrape"  // Importation du fichier produit par le scraper
+	RevisionSourceImport  = "import"  // Importation depuis un autre format
+	RevisionSourceManual  = "manual"  // Modification via l'API
+	RevisionSourceInitial = "initial" // Version existante avant la mise en place de l'historique
+)
+
+// RecetteRevision version enregistrée d'une recette (copie complète au moment de la modification)
+type RecetteRevision struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	RecetteID primitive.ObjectID `json:"recette_id" bson:"recette_id"`
+	Number    int                `json:"number" bson:"number"`
+	Source    string             `json:"source" bson:"source"`
+	Author    string             `json:"author,omitempty" bson:"author,omitempty"`
+	RunID     string             `json:"run_id,omitempty" bson:"run_id,omitempty"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+	Recette   *Recette           `json:"recette,omitempty" bson:"recette,omitempty"`
+}
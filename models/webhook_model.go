@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Statuts d'une livraison de webhook
+const (
+	DeliveryPending   = "pending"   // Tentatives en cours
+	DeliverySucceeded = "succeeded" // Réponse 2xx reçue
+	DeliveryFailed    = "failed"    // Abandon après la dernière tentative ou refus définitif
+)
+
+// Webhook abonnement d'un système externe aux événements de l'API
+type Webhook struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	URL       string             `json:"url" bson:"url"`
+	Events    []string           `json:"events" bson:"events"`
+	Secret    string             `json:"-" bson:"secret"` // Clé de signature HMAC-SHA256, retournée une seule fois à la création
+	Active    bool               `json:"active" bson:"active"`
+	CreatedAt time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// WebhookAttempt tentative d'envoi d'un événement
+type WebhookAttempt struct {
+	Number     int       `json:"number" bson:"number"`
+	At         time.Time `json:"at" bson:"at"`
+	StatusCode int       `json:"status_code,omitempty" bson:"status_code,omitempty"`
+	Error      string    `json:"error,omitempty" bson:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms" bson:"duration_ms"`
+}
+
+// WebhookDelivery journal de livraison d'un événement à un webhook
+type WebhookDelivery struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	WebhookID   primitive.ObjectID `json:"webhook_id" bson:"webhook_id"`
+	EventID     uint64             `json:"event_id" bson:"event_id"`
+	EventType   string             `json:"event_type" bson:"event_type"`
+	Status      string             `json:"status" bson:"status"`
+	Attempts    []WebhookAttempt   `json:"attempts" bson:"attempts"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+	CompletedAt *time.Time         `json:"completed_at,omitempty" bson:"completed_at,omitempty"`
+}
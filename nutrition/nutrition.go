@@ -0,0 +1,162 @@
+package nutrition
+
+import (
+	"math"
+
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// DefaultServings nombre de portions utilisé quand la recette ne l'indique pas
+const DefaultServings = 4
+
+// Statuts d'estimation d'un ingrédient
+const (
+	StatusMatched      = "matched"      // Ingrédient trouvé et quantité convertie en grammes
+	StatusUnquantified = "unquantified" // Ingrédient trouvé mais quantité absente ou non convertible
+	StatusUnmatched    = "unmatched"    // Ingrédient absent de la table
+)
+
+// Nutrients valeurs nutritionnelles (grammes, sauf kcal et sodium en mg)
+type Nutrients struct {
+	Kcal    float64 `json:"kcal"`
+	Protein float64 `json:"protein_g"`
+	Fat     float64 `json:"fat_g"`
+	Carbs   float64 `json:"carbs_g"`
+	Fibre   float64 `json:"fibre_g"`
+	Sodium  float64 `json:"sodium_mg"`
+}
+
+// IngredientEstimate détail de l'estimation pour une ligne d'ingrédient
+type IngredientEstimate struct {
+	Line      string     `json:"line"`
+	Name      string     `json:"name"`
+	Food      string     `json:"food,omitempty"`
+	Quantity  *Quantity  `json:"quantity,omitempty"`
+	Grams     float64    `json:"grams"`
+	Status    string     `json:"status"`
+	Nutrients *Nutrients `json:"nutrients,omitempty"`
+}
+
+// Report estimation nutritionnelle d'une recette
+type Report struct {
+	Total        Nutrients            `json:"total"`
+	PerServing   Nutrients            `json:"per_serving"`
+	Servings     int                  `json:"servings"`
+	Coverage     float64              `json:"coverage"` // part des ingrédients pris en compte (0 à 1)
+	Ingredients  []IngredientEstimate `json:"ingredients"`
+	Unmatched    []string             `json:"unmatched"`
+	Unquantified []string             `json:"unquantified"`
+}
+
+// Estimate calcule les valeurs nutritionnelles totales et par portion d'une liste d'ingrédients
+func (t *Table) Estimate(ings []models.Ingredient, servings int) Report {
+	if servings <= 0 {
+		servings = DefaultServings
+	}
+
+	report := Report{
+		Servings:     servings,
+		Ingredients:  []IngredientEstimate{},
+		Unmatched:    []string{},
+		Unquantified: []string{},
+	}
+
+	lines := 0
+	for _, ing := range ings {
+		line := ingredients.Text(ing)
+		name := ingredients.Normalize(line)
+		if name == "" {
+			continue
+		}
+		lines++
+
+		estimate := IngredientEstimate{Line: line, Name: name}
+		food, found := t.Lookup(name)
+		if !found {
+			estimate.Status = StatusUnmatched
+			report.Unmatched = append(report.Unmatched, line)
+			report.Ingredients = append(report.Ingredients, estimate)
+			continue
+		}
+		estimate.Food = food.Name
+
+		quantity, hasQuantity := ParseQuantity(line)
+		grams, converted := 0.0, false
+		if hasQuantity {
+			estimate.Quantity = &quantity
+			grams, converted = Grams(food, quantity)
+		}
+		if !converted {
+			estimate.Status = StatusUnquantified
+			report.Unquantified = append(report.Unquantified, line)
+			report.Ingredients = append(report.Ingredients, estimate)
+			continue
+		}
+
+		nutrients := food.scaled(grams)
+		estimate.Status = StatusMatched
+		estimate.Grams = round(grams)
+		estimate.Nutrients = &nutrients
+		report.Total = report.Total.add(nutrients)
+		report.Ingredients = append(report.Ingredients, estimate)
+	}
+
+	if lines > 0 {
+		report.Coverage = round(float64(lines-len(report.Unmatched)-len(report.Unquantified)) / float64(lines))
+	}
+	report.Total = report.Total.rounded()
+	report.PerServing = report.Total.divided(float64(servings))
+	return report
+}
+
+// scaled retourne les valeurs nutritionnelles pour une masse donnée
+func (f *Food) scaled(grams float64) Nutrients {
+	factor := grams / 100
+	return Nutrients{
+		Kcal:    f.Kcal * factor,
+		Protein: f.Protein * factor,
+		Fat:     f.Fat * factor,
+		Carbs:   f.Carbs * factor,
+		Fibre:   f.Fibre * factor,
+		Sodium:  f.Sodium * factor,
+	}.rounded()
+}
+
+func (n Nutrients) add(o Nutrients) Nutrients {
+	return Nutrients{
+		Kcal:    n.Kcal + o.Kcal,
+		Protein: n.Protein + o.Protein,
+		Fat:     n.Fat + o.Fat,
+		Carbs:   n.Carbs + o.Carbs,
+		Fibre:   n.Fibre + o.Fibre,
+		Sodium:  n.Sodium + o.Sodium,
+	}
+}
+
+func (n Nutrients) divided(by float64) Nutrients {
+	return Nutrients{
+		Kcal:    n.Kcal / by,
+		Protein: n.Protein / by,
+		Fat:     n.Fat / by,
+		Carbs:   n.Carbs / by,
+		Fibre:   n.Fibre / by,
+		Sodium:  n.Sodium / by,
+	}.rounded()
+}
+
+func (n Nutrients) rounded() Nutrients {
+	return Nutrients{
+		Kcal:    round(n.Kcal),
+		Protein: round(n.Protein),
+		Fat:     round(n.Fat),
+		Carbs:   round(n.Carbs),
+		Fibre:   round(n.Fibre),
+		Sodium:  round(n.Sodium),
+	}
+}
+
+// round arrondit à deux décimales
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,86 @@
+package nutrition
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		line   string
+		amount float64
+		unit   string
+	}{
+		{"1 1/2 cups milk", 1.5, "cup"},
+		{"½ cup butter, melted", 0.5, "cup"},
+		{"1½ teaspoons salt", 1.5, "tsp"},
+		{"2 (15 ounce) cans black beans, drained", 30, "oz"},
+		{"2 large eggs", 2, "piece"},
+		{"3 cloves garlic, minced", 3, "clove"},
+		{"2 to 3 tablespoons olive oil", 2.5, "tbsp"},
+		{"1 8oz package cream cheese", 8, "oz"},
+		{"0.5 pound ground beef", 0.5, "lb"},
+		{"4 fluid ounces heavy cream", 4, "fl oz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.line, func(t *testing.T) {
+			q, ok := ParseQuantity(tt.line)
+			require.True(t, ok)
+			assert.InDelta(t, tt.amount, q.Amount, 0.001)
+			assert.Equal(t, tt.unit, q.Unit)
+		})
+	}
+
+	_, ok := ParseQuantity("salt and pepper to taste")
+	assert.False(t, ok)
+}
+
+func TestLookup(t *testing.T) {
+	table := DefaultTable()
+	require.Greater(t, table.Len(), 100)
+
+	food, ok := table.Lookup("egg")
+	require.True(t, ok)
+	assert.Equal(t, "egg", food.Name)
+
+	// Repli sur une sous-expression connue
+	food, ok = table.Lookup("smoked paprika powder blend")
+	if assert.True(t, ok) {
+		assert.Equal(t, "paprika", food.Name)
+	}
+
+	_, ok = table.Lookup("dragon fruit nectar")
+	assert.False(t, ok)
+}
+
+func TestEstimate(t *testing.T) {
+	ings := []models.Ingredient{
+		{Quantity: "2 large eggs"},
+		{Quantity: "1 cup milk"},
+		{Quantity: "salt to taste"},
+		{Quantity: "1 tablespoon unicorn dust"},
+	}
+
+	report := DefaultTable().Estimate(ings, 2)
+
+	assert.Equal(t, 2, report.Servings)
+	require.Len(t, report.Ingredients, 4)
+	assert.Equal(t, StatusMatched, report.Ingredients[0].Status)
+	assert.Equal(t, StatusMatched, report.Ingredients[1].Status)
+	assert.Equal(t, StatusUnquantified, report.Ingredients[2].Status)
+	assert.Equal(t, StatusUnmatched, report.Ingredients[3].Status)
+	assert.Equal(t, []string{"1 tablespoon unicorn dust"}, report.Unmatched)
+	assert.Equal(t, []string{"salt to taste"}, report.Unquantified)
+	assert.InDelta(t, 0.5, report.Coverage, 0.001)
+
+	assert.Greater(t, report.Total.Kcal, 0.0)
+	assert.InDelta(t, report.Total.Kcal/2, report.PerServing.Kcal, 0.01)
+	assert.InDelta(t, report.Total.Protein/2, report.PerServing.Protein, 0.01)
+
+	// Nombre de portions par défaut
+	assert.Equal(t, DefaultServings, DefaultTable().Estimate(ings, 0).Servings)
+}
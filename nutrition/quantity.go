@@ -0,0 +1,235 @@
+package nutrition
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Quantity est la quantité extraite d'une ligne d'ingrédient
+type Quantity struct {
+	Amount float64 `json:"amount"`
+	Unit   string  `json:"unit"` // unité canonique ("cup", "g", "piece"...)
+}
+
+// Unités canoniques par catégorie
+var (
+	// gramsPerUnit unités de poids
+	gramsPerUnit = map[string]float64{"g": 1, "kg": 1000, "oz": 28.3495, "lb": 453.592}
+
+	// cupsPerUnit unités de volume exprimées en tasses
+	cupsPerUnit = map[string]float64{
+		"cup": 1, "tbsp": 1.0 / 16, "tsp": 1.0 / 48, "fl oz": 1.0 / 8,
+		"ml": 1 / 236.6, "l": 1000 / 236.6, "pint": 2, "quart": 4, "gallon": 16,
+	}
+
+	// fixedGrams petites mesures au poids quasi constant
+	fixedGrams = map[string]float64{"pinch": 0.35, "dash": 0.6}
+
+	// pieceUnits unités comptées à la pièce
+	pieceUnits = map[string]bool{
+		"piece": true, "clove": true, "slice": true, "stalk": true, "rib": true, "sprig": true,
+		"head": true, "bunch": true, "stick": true, "leaf": true, "fillet": true, "ear": true, "wedge": true,
+	}
+)
+
+// unitAliases associe les formes rencontrées dans les recettes aux unités canoniques
+var unitAliases = map[string]string{
+	"g": "g", "gram": "g", "grams": "g", "kg": "kg", "kilogram": "kg", "kilograms": "kg",
+	"oz": "oz", "ounce": "oz", "ounces": "oz", "lb": "lb", "lbs": "lb", "pound": "lb", "pounds": "lb",
+	"cup": "cup", "cups": "cup", "c": "cup",
+	"tablespoon": "tbsp", "tablespoons": "tbsp", "tbsp": "tbsp", "tbs": "tbsp",
+	"teaspoon": "tsp", "teaspoons": "tsp", "tsp": "tsp",
+	"ml": "ml", "milliliter": "ml", "milliliters": "ml", "l": "l", "liter": "l", "liters": "l",
+	"pint": "pint", "pints": "pint", "quart": "quart", "quarts": "quart", "gallon": "gallon", "gallons": "gallon",
+	"pinch": "pinch", "pinches": "pinch", "dash": "dash", "dashes": "dash",
+	"piece": "piece", "pieces": "piece", "clove": "clove", "cloves": "clove", "slice": "slice", "slices": "slice",
+	"stalk": "stalk", "stalks": "stalk", "rib": "rib", "ribs": "rib", "sprig": "sprig", "sprigs": "sprig",
+	"head": "head", "heads": "head", "bunch": "bunch", "bunches": "bunch", "stick": "stick", "sticks": "stick",
+	"leaf": "leaf", "leaves": "leaf", "fillet": "fillet", "fillets": "fillet", "ear": "ear", "ears": "ear",
+	"wedge": "wedge", "wedges": "wedge",
+	"can": "can", "cans": "can", "package": "package", "packages": "package", "packet": "package",
+	"packets": "package", "jar": "jar", "jars": "jar", "bottle": "bottle", "bottles": "bottle",
+	"bag": "bag", "bags": "bag", "box": "box", "boxes": "box", "container": "container",
+	"containers": "container", "envelope": "envelope", "envelopes": "envelope",
+}
+
+// sizeWords adjectifs ignorés entre la quantité et l'unité ("1 heaping tablespoon", "2 large eggs")
+var sizeWords = map[string]bool{
+	"large": true, "small": true, "medium": true, "heaping": true, "level": true, "scant": true,
+	"generous": true, "whole": true, "extra-large": true, "jumbo": true,
+}
+
+// unicodeFractions fractions typographiques utilisées par AllRecipes
+var unicodeFractions = map[rune]string{
+	'½': "1/2", '⅓': "1/3", '⅔': "2/3", '¼': "1/4", '¾': "3/4", '⅕': "1/5",
+	'⅛': "1/8", '⅜': "3/8", '⅝': "5/8", '⅞': "7/8", '⅙': "1/6",
+}
+
+// numberUnitPattern sépare un nombre collé à une unité ("6-ounce", "8oz")
+var numberUnitPattern = regexp.MustCompile(`(\d)-?([a-z])`)
+
+// ParseQuantity extrait la quantité en tête d'une ligne d'ingrédient
+// ex: "1 1/2 cups milk" -> {1.5 cup}, "2 (15 ounce) cans beans" -> {30 oz}, "2 large eggs" -> {2 piece}
+// Retourne false si la ligne ne commence pas par une quantité ("salt to taste")
+func ParseQuantity(line string) (Quantity, bool) {
+	tokens := tokenize(line)
+
+	amount, next, ok := parseAmount(tokens, 0)
+	if !ok {
+		return Quantity{}, false
+	}
+
+	// Conditionnement précisé entre parenthèses : "2 (15 ounce) cans"
+	if next < len(tokens) && tokens[next] == "(" {
+		closing := next + 1
+		for closing < len(tokens) && tokens[closing] != ")" {
+			closing++
+		}
+		if inner, ok := parseInner(tokens[next+1 : closing]); ok {
+			return Quantity{Amount: amount * inner.Amount, Unit: inner.Unit}, true
+		}
+		next = closing + 1
+	} else if inner, ok := parseInner(tokens[next:]); ok {
+		// Conditionnement sans parenthèses : "1 8oz package"
+		return Quantity{Amount: amount * inner.Amount, Unit: inner.Unit}, true
+	}
+
+	for next < len(tokens) && sizeWords[tokens[next]] {
+		next++
+	}
+
+	unit := "piece"
+	if next < len(tokens) {
+		word := strings.Trim(tokens[next], ".,")
+		if word == "fluid" && next+1 < len(tokens) && unitAliases[tokens[next+1]] == "oz" {
+			unit = "fl oz"
+		} else if canonical, exists := unitAliases[word]; exists {
+			unit = canonical
+		}
+	}
+
+	return Quantity{Amount: amount, Unit: unit}, true
+}
+
+// parseInner analyse le contenu d'une parenthèse de conditionnement ("15 ounce", "4 to 6 ounce")
+func parseInner(tokens []string) (Quantity, bool) {
+	amount, next, ok := parseAmount(tokens, 0)
+	if !ok || next >= len(tokens) {
+		return Quantity{}, false
+	}
+
+	unit := ""
+	if tokens[next] == "fluid" && next+1 < len(tokens) {
+		if unitAliases[tokens[next+1]] == "oz" {
+			unit = "fl oz"
+		}
+	} else {
+		unit = unitAliases[tokens[next]]
+	}
+
+	if _, isWeight := gramsPerUnit[unit]; isWeight {
+		return Quantity{Amount: amount, Unit: unit}, true
+	}
+	if _, isVolume := cupsPerUnit[unit]; isVolume {
+		return Quantity{Amount: amount, Unit: unit}, true
+	}
+	return Quantity{}, false
+}
+
+// parseAmount lit un nombre éventuellement mixte ("1 1/2") ou une plage ("2 to 3", "2-3")
+func parseAmount(tokens []string, i int) (float64, int, bool) {
+	if i >= len(tokens) {
+		return 0, i, false
+	}
+
+	// Plage dans un seul jeton : "2-3"
+	if low, high, found := strings.Cut(tokens[i], "-"); found {
+		a, okA := parseNumber(low)
+		b, okB := parseNumber(high)
+		if okA && okB {
+			return (a + b) / 2, i + 1, true
+		}
+	}
+
+	amount, ok := parseNumber(tokens[i])
+	if !ok {
+		return 0, i, false
+	}
+	i++
+
+	// Nombre mixte : "1 1/2"
+	if i < len(tokens) && strings.Contains(tokens[i], "/") {
+		if fraction, ok := parseNumber(tokens[i]); ok {
+			amount += fraction
+			i++
+		}
+	}
+
+	// Plage : "2 to 3", "2 or 3"
+	if i+1 < len(tokens) && (tokens[i] == "to" || tokens[i] == "or" || tokens[i] == "-") {
+		if high, next, ok := parseAmount(tokens, i+1); ok {
+			return (amount + high) / 2, next, true
+		}
+	}
+
+	return amount, i, true
+}
+
+// parseNumber lit un entier, un décimal ou une fraction simple
+func parseNumber(token string) (float64, bool) {
+	if numerator, denominator, found := strings.Cut(token, "/"); found {
+		n, errN := strconv.ParseFloat(numerator, 64)
+		d, errD := strconv.ParseFloat(denominator, 64)
+		if errN != nil || errD != nil || d == 0 {
+			return 0, false
+		}
+		return n / d, true
+	}
+
+	value, err := strconv.ParseFloat(token, 64)
+	if err != nil || value < 0 {
+		return 0, false
+	}
+	return value, true
+}
+
+// tokenize découpe la ligne en jetons (fractions typographiques développées, parenthèses isolées)
+func tokenize(line string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(line) {
+		switch {
+		case unicodeFractions[r] != "":
+			b.WriteString(" " + unicodeFractions[r] + " ")
+		case r == '(' || r == ')':
+			b.WriteString(" " + string(r) + " ")
+		case r == ',' || r == ' ':
+			b.WriteRune(' ')
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return strings.Fields(numberUnitPattern.ReplaceAllString(b.String(), "$1 $2"))
+}
+
+// Grams convertit une quantité en grammes pour un aliment donné
+// Retourne false si la conversion est impossible (conditionnement de taille inconnue, densité absente)
+func Grams(food *Food, q Quantity) (float64, bool) {
+	if grams, ok := gramsPerUnit[q.Unit]; ok {
+		return q.Amount * grams, true
+	}
+	if cups, ok := cupsPerUnit[q.Unit]; ok {
+		density := food.GramsPerCup
+		if density == 0 {
+			return 0, false
+		}
+		return q.Amount * cups * density, true
+	}
+	if grams, ok := fixedGrams[q.Unit]; ok {
+		return q.Amount * grams, true
+	}
+	if pieceUnits[q.Unit] && food.GramsPerPiece > 0 {
+		return q.Amount * food.GramsPerPiece, true
+	}
+	return 0, false
+}
@@ -0,0 +1,96 @@
+package nutrition
+
+import (
+	_ "embed"
+	"encoding/json"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/ingredients"
+)
+
+// tableData table nutritionnelle embarquée dans le binaire (valeurs pour 100 g)
+//
+//go:embed table.json
+var tableData []byte
+
+// Food est une entrée de la table nutritionnelle
+type Food struct {
+	Name          string   `json:"name"`
+	Aliases       []string `json:"aliases,omitempty"`
+	Kcal          float64  `json:"kcal"`
+	Protein       float64  `json:"protein"`
+	Fat           float64  `json:"fat"`
+	Carbs         float64  `json:"carbs"`
+	Fibre         float64  `json:"fibre"`
+	Sodium        float64  `json:"sodium"`                    // mg pour 100 g
+	GramsPerCup   float64  `json:"grams_per_cup,omitempty"`   // densité pour les mesures en volume
+	GramsPerPiece float64  `json:"grams_per_piece,omitempty"` // poids d'une unité (oeuf, gousse, tranche...)
+}
+
+// Table associe des noms d'ingrédients normalisés aux entrées nutritionnelles
+type Table struct {
+	foods  []Food
+	byName map[string]*Food
+}
+
+// defaultTable table chargée depuis table.json
+var defaultTable = mustLoadTable(tableData)
+
+// DefaultTable retourne la table nutritionnelle embarquée
+func DefaultTable() *Table {
+	return defaultTable
+}
+
+// LoadTable construit une table à partir de son contenu JSON
+func LoadTable(data []byte) (*Table, error) {
+	var foods []Food
+	if err := json.Unmarshal(data, &foods); err != nil {
+		return nil, err
+	}
+
+	table := &Table{foods: foods, byName: make(map[string]*Food)}
+	for i := range foods {
+		food := &table.foods[i]
+		for _, name := range append([]string{food.Name}, food.Aliases...) {
+			// Les clés passent par la même normalisation que les lignes d'ingrédients
+			key := ingredients.Normalize(name)
+			if _, exists := table.byName[key]; key != "" && !exists {
+				table.byName[key] = food
+			}
+		}
+	}
+	return table, nil
+}
+
+// mustLoadTable charge la table embarquée (erreur de build si le JSON est invalide)
+func mustLoadTable(data []byte) *Table {
+	table, err := LoadTable(data)
+	if err != nil {
+		panic("nutrition: table.json invalide: " + err.Error())
+	}
+	return table
+}
+
+// Len retourne le nombre d'entrées de la table
+func (t *Table) Len() int {
+	return len(t.foods)
+}
+
+// Lookup retrouve l'entrée correspondant à un nom d'ingrédient normalisé
+// Essaie le nom complet, puis les sous-expressions les plus longues en privilégiant
+// les derniers mots ("lower-sodium soy sauce" -> "soy sauce", "pork shoulder roast" -> "pork")
+func (t *Table) Lookup(name string) (*Food, bool) {
+	if food, ok := t.byName[name]; ok {
+		return food, true
+	}
+
+	words := strings.Fields(name)
+	for size := len(words) - 1; size > 0; size-- {
+		for start := len(words) - size; start >= 0; start-- {
+			if food, ok := t.byName[strings.Join(words[start:start+size], " ")]; ok {
+				return food, true
+			}
+		}
+	}
+	return nil, false
+}
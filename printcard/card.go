@@ -0,0 +1,72 @@
+package printcard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// Card contenu d'une fiche recette imprimable, transmis aux modèles
+type Card struct {
+	Language     string   // langue de la fiche ("fr", "en")
+	Name         string   // titre de la recette
+	Image        string   // URL de l'image (vide si la recette n'en a pas)
+	Category     string   // catégorie d'origine
+	Servings     int      // nombre de portions (0 si inconnu)
+	TotalTime    string   // temps total lisible ("1 h 15 min", vide si inconnu)
+	Ingredients  []string // lignes d'ingrédients, à cocher
+	Instructions []string // texte des étapes, numérotées par le modèle
+	Source       string   // page d'origine de la recette
+	Labels       Labels   // libellés traduits
+}
+
+// Labels libellés de la fiche, traduits selon la langue de la requête
+type Labels struct {
+	Ingredients  string
+	Instructions string
+	Servings     string
+	TotalTime    string
+	Source       string
+}
+
+// FromRecette prépare la fiche d'une recette (les libellés et la langue restent à renseigner)
+// Les ingrédients et étapes vides sont ignorés
+func FromRecette(recette models.Recette) Card {
+	card := Card{
+		Name:         strings.TrimSpace(recette.Name),
+		Image:        strings.TrimSpace(recette.Image),
+		Category:     strings.TrimSpace(recette.Category),
+		Servings:     recette.Servings,
+		TotalTime:    formatMinutes(recette.TotalMinutes),
+		Ingredients:  []string{},
+		Instructions: []string{},
+		Source:       strings.TrimSpace(recette.Page),
+	}
+	for _, ing := range recette.Ingredients {
+		if line := ingredients.Text(ing); line != "" {
+			card.Ingredients = append(card.Ingredients, line)
+		}
+	}
+	for _, step := range recette.Instructions {
+		if line := strings.TrimSpace(step.Description); line != "" {
+			card.Instructions = append(card.Instructions, line)
+		}
+	}
+	return card
+}
+
+// formatMinutes formate une durée en minutes ("45 min", "1 h", "1 h 15 min")
+func formatMinutes(minutes int) string {
+	switch {
+	case minutes <= 0:
+		return ""
+	case minutes < 60:
+		return fmt.Sprintf("%d min", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%d h", minutes/60)
+	default:
+		return fmt.Sprintf("%d h %d min", minutes/60, minutes%60)
+	}
+}
@@ -0,0 +1,165 @@
+package printcard
+
+import "unicode/utf8"
+
+// Polices standard PDF utilisées (toujours disponibles dans les lecteurs, rien à embarquer)
+const (
+	fontRegular = "F1" // Helvetica
+	fontBold    = "F2" // Helvetica-Bold
+	fontItalic  = "F3" // Helvetica-Oblique (mêmes chasses que Helvetica)
+)
+
+// Chasses des caractères ASCII 32 à 126, en millièmes de la taille de police (métriques AFM Adobe)
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+)
+
+// latinBase lettre de base des caractères Latin-1 0xC0 à 0xFF, pour estimer leur chasse
+const latinBase = "AAAAAAACEEEEIIIIDNOOOOO+OUUUUYPsaaaaaaaceeeeiiiidnooooo+ouuuuypy"
+
+// specialWidths chasses Helvetica des autres caractères WinAnsi courants
+var specialWidths = map[byte]int{
+	0x80: 556,  // €
+	0x85: 1000, // …
+	0x91: 222,  // ‘
+	0x92: 222,  // ’
+	0x93: 333,  // “
+	0x94: 333,  // ”
+	0x95: 350,  // •
+	0x96: 556,  // –
+	0x97: 1000, // —
+	0xA0: 278,  // espace insécable
+	0xAB: 556,  // «
+	0xB0: 400,  // °
+	0xB7: 278,  // ·
+	0xBB: 556,  // »
+	0xBC: 834,  // ¼
+	0xBD: 834,  // ½
+	0xBE: 834,  // ¾
+}
+
+// winAnsiSpecial caractères Unicode de la plage 0x80-0x9F de WinAnsiEncoding
+var winAnsiSpecial = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87, 'ˆ': 0x88,
+	'‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91, '’': 0x92, '“': 0x93,
+	'”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98, '™': 0x99, 'š': 0x9A, '›': 0x9B,
+	'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// winAnsi convertit un texte UTF-8 dans l'encodage des polices standard
+// Les espaces fines et tabulations deviennent des espaces, les caractères sans équivalent un "?"
+func winAnsi(text string) []byte {
+	result := make([]byte, 0, len(text))
+	for len(text) > 0 {
+		r, size := utf8.DecodeRuneInString(text)
+		text = text[size:]
+		switch {
+		case r == '\t' || r == '\u2009' || r == '\u202f':
+			result = append(result, ' ')
+		case r >= 0x20 && r <= 0x7E, r >= 0xA0 && r <= 0xFF:
+			result = append(result, byte(r))
+		case winAnsiSpecial[r] != 0:
+			result = append(result, winAnsiSpecial[r])
+		case r < 0x20:
+			// caractères de contrôle ignorés
+		default:
+			result = append(result, '?')
+		}
+	}
+	return result
+}
+
+// charWidth chasse d'un caractère WinAnsi, en millièmes de la taille de police
+func charWidth(font string, c byte) int {
+	widths := &helveticaWidths
+	if font == fontBold {
+		widths = &helveticaBoldWidths
+	}
+	switch {
+	case c >= 0x20 && c <= 0x7E:
+		return widths[c-0x20]
+	case c >= 0xC0:
+		return widths[latinBase[c-0xC0]-0x20]
+	case specialWidths[c] != 0:
+		return specialWidths[c]
+	default:
+		return 556
+	}
+}
+
+// textWidth largeur d'un texte WinAnsi en points
+func textWidth(font string, size float64, text []byte) float64 {
+	total := 0
+	for _, c := range text {
+		total += charWidth(font, c)
+	}
+	return float64(total) * size / 1000
+}
+
+// wrap découpe un texte WinAnsi en lignes d'au plus width points, aux espaces
+// Un mot plus long qu'une ligne est coupé
+func wrap(font string, size, width float64, text []byte) [][]byte {
+	lines := [][]byte{}
+	var line []byte
+	for _, word := range splitWords(text) {
+		candidate := word
+		if len(line) > 0 {
+			candidate = append(append(append([]byte{}, line...), ' '), word...)
+		}
+		if textWidth(font, size, candidate) <= width {
+			line = candidate
+			continue
+		}
+		if len(line) > 0 {
+			lines = append(lines, line)
+		}
+		for textWidth(font, size, word) > width && len(word) > 1 {
+			cut := 1
+			for cut < len(word) && textWidth(font, size, word[:cut+1]) <= width {
+				cut++
+			}
+			lines = append(lines, word[:cut])
+			word = word[cut:]
+		}
+		line = word
+	}
+	if len(line) > 0 || len(lines) == 0 {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// splitWords découpe un texte aux espaces (les espaces insécables sont conservées)
+func splitWords(text []byte) [][]byte {
+	words := [][]byte{}
+	start := -1
+	for i, c := range text {
+		if c == ' ' {
+			if start >= 0 {
+				words = append(words, text[start:i])
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, text[start:])
+	}
+	return words
+}
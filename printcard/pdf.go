@@ -0,0 +1,292 @@
+package printcard
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // Décodage des images GIF
+	"image/jpeg"
+	_ "image/png" // Décodage des images PNG
+	"io"
+	"regexp"
+	"strings"
+
+	_ "golang.org/x/image/webp" // Décodage des images WebP
+)
+
+// Mise en page A4, en points
+const (
+	pageWidth      = 595.28
+	pageHeight     = 841.89
+	margin         = 56.0
+	contentWidth   = pageWidth - 2*margin
+	maxImageHeight = 260.0
+	lineSpacing    = 1.3
+	bodySize       = 11.0
+)
+
+var (
+	imagePattern    = regexp.MustCompile(`^!\[[^\]]*\]\([^)]*\)$`)
+	numberedPattern = regexp.MustCompile(`^(\d+)\.\s+(.*)$`)
+	autolinkPattern = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	linkPattern     = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]*)\)`)
+)
+
+// pdfDocument document PDF en cours de composition
+type pdfDocument struct {
+	pages   []*bytes.Buffer // flux de contenu de chaque page
+	content *bytes.Buffer   // page courante
+	y       float64         // position verticale courante (origine en bas de page)
+	image   *pdfImage
+}
+
+// pdfImage image JPEG insérée telle quelle dans le PDF
+type pdfImage struct {
+	data          []byte
+	width, height int
+}
+
+// writePDF compose une fiche A4 à partir de son rendu Markdown
+// Seul le sous-ensemble produit par les modèles est interprété : titres (#, ##), image, cases à cocher,
+// listes, listes numérotées, séparateur (---), ligne en italique (_texte_) et liens
+// L'image remplace la ligne ![...](...) ; une image illisible est omise
+func writePDF(w io.Writer, title, markdown string, imageData []byte) error {
+	doc := &pdfDocument{}
+	if len(imageData) > 0 {
+		if img, err := prepareImage(imageData); err == nil {
+			doc.image = img
+		}
+	}
+	doc.newPage()
+	doc.layout(markdown)
+	return doc.write(w, title)
+}
+
+// prepareImage réencode une image en JPEG sur fond blanc (transparence aplatie)
+func prepareImage(data []byte) (*pdfImage, error) {
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	bounds := src.Bounds()
+	flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, bounds.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, flat, &jpeg.Options{Quality: 85}); err != nil {
+		return nil, err
+	}
+	return &pdfImage{data: buf.Bytes(), width: bounds.Dx(), height: bounds.Dy()}, nil
+}
+
+// layout met en page les lignes Markdown
+func (d *pdfDocument) layout(markdown string) {
+	for _, raw := range strings.Split(markdown, "\n") {
+		line := strings.TrimSpace(raw)
+		switch {
+		case line == "":
+			d.space(4)
+		case strings.HasPrefix(line, "# "):
+			d.paragraph(fontBold, 22, inline(line[2:]))
+			d.space(4)
+		case strings.HasPrefix(line, "## "):
+			d.space(12)
+			d.paragraph(fontBold, 14, inline(line[3:]))
+			d.rule()
+		case line == "---" || line == "***":
+			d.space(8)
+			d.rule()
+		case imagePattern.MatchString(line):
+			d.drawImage()
+		case strings.HasPrefix(line, "- [ ] "), strings.HasPrefix(line, "- [x] "):
+			d.item(18, inline(line[6:]), func(x, y float64) {
+				fmt.Fprintf(d.content, "0.8 w %.2f %.2f 8 8 re S\n", x+1, y-1)
+			})
+		case strings.HasPrefix(line, "- "), strings.HasPrefix(line, "* "):
+			d.item(14, inline(line[2:]), func(x, y float64) {
+				d.text(fontRegular, bodySize, x+3, y, []byte{0x95})
+			})
+		case numberedPattern.MatchString(line):
+			match := numberedPattern.FindStringSubmatch(line)
+			d.item(22, inline(match[2]), func(x, y float64) {
+				d.text(fontBold, bodySize, x, y, []byte(match[1]+"."))
+			})
+		case len(line) > 2 && (line[0] == '_' || line[0] == '*') && line[len(line)-1] == line[0]:
+			d.paragraph(fontItalic, bodySize, inline(line[1:len(line)-1]))
+		default:
+			d.paragraph(fontRegular, bodySize, inline(line))
+		}
+	}
+}
+
+// inline remplace les liens Markdown par leur texte (suivi de l'URL si elle diffère)
+func inline(text string) string {
+	text = autolinkPattern.ReplaceAllString(text, "$1")
+	text = linkPattern.ReplaceAllStringFunc(text, func(link string) string {
+		match := linkPattern.FindStringSubmatch(link)
+		if match[1] == "" || match[1] == match[2] {
+			return match[2]
+		}
+		return match[1] + " (" + match[2] + ")"
+	})
+	return strings.ReplaceAll(text, "**", "")
+}
+
+// newPage commence une nouvelle page
+func (d *pdfDocument) newPage() {
+	d.content = &bytes.Buffer{}
+	d.pages = append(d.pages, d.content)
+	d.y = pageHeight - margin
+}
+
+// ensure passe à la page suivante si height points ne tiennent plus sur la page courante
+func (d *pdfDocument) ensure(height float64) {
+	if d.y-height < margin {
+		d.newPage()
+	}
+}
+
+// space ajoute un espace vertical (ignoré en haut de page)
+func (d *pdfDocument) space(height float64) {
+	if d.y < pageHeight-margin {
+		d.y -= height
+	}
+}
+
+// text écrit un texte WinAnsi sur la ligne de base y
+func (d *pdfDocument) text(font string, size, x, y float64, text []byte) {
+	fmt.Fprintf(d.content, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+// paragraph écrit un texte sur toute la largeur, renvoyé à la ligne si besoin
+func (d *pdfDocument) paragraph(font string, size float64, text string) {
+	for _, line := range wrap(font, size, contentWidth, winAnsi(text)) {
+		d.ensure(size * lineSpacing)
+		d.y -= size * lineSpacing
+		d.text(font, size, margin, d.y, line)
+	}
+}
+
+// item écrit un élément de liste : marker dessine le repère sur la première ligne,
+// le texte est aligné à indent points de la marge
+func (d *pdfDocument) item(indent float64, text string, marker func(x, y float64)) {
+	for i, line := range wrap(fontRegular, bodySize, contentWidth-indent, winAnsi(text)) {
+		d.ensure(bodySize * lineSpacing)
+		d.y -= bodySize * lineSpacing
+		if i == 0 {
+			marker(margin, d.y)
+		}
+		d.text(fontRegular, bodySize, margin+indent, d.y, line)
+	}
+	d.y -= 3
+}
+
+// rule trace un filet horizontal sur toute la largeur
+func (d *pdfDocument) rule() {
+	d.ensure(6)
+	d.y -= 4
+	fmt.Fprintf(d.content, "0.6 G 0.5 w %.2f %.2f m %.2f %.2f l S 0 G\n", margin, d.y, pageWidth-margin, d.y)
+	d.y -= 2
+}
+
+// drawImage insère l'image à sa place, réduite pour tenir dans la largeur et maxImageHeight
+func (d *pdfDocument) drawImage() {
+	if d.image == nil || d.image.width == 0 || d.image.height == 0 {
+		return
+	}
+	width := contentWidth
+	height := width * float64(d.image.height) / float64(d.image.width)
+	if height > maxImageHeight {
+		height = maxImageHeight
+		width = height * float64(d.image.width) / float64(d.image.height)
+	}
+	d.space(6)
+	d.ensure(height)
+	d.y -= height
+	fmt.Fprintf(d.content, "q %.2f 0 0 %.2f %.2f %.2f cm /Im1 Do Q\n", width, height, margin, d.y)
+	d.y -= 6
+}
+
+// escape protège les caractères spéciaux d'une chaîne PDF
+func escape(text []byte) []byte {
+	result := make([]byte, 0, len(text))
+	for _, c := range text {
+		if c == '(' || c == ')' || c == '\\' {
+			result = append(result, '\\')
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+// write écrit le fichier PDF : catalogue, pages, informations, polices, image puis pages et leurs contenus
+func (d *pdfDocument) write(w io.Writer, title string) error {
+	var out bytes.Buffer
+	offsets := []int{}
+	object := func(body []byte) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n", len(offsets))
+		out.Write(body)
+		out.WriteString("\nendobj\n")
+	}
+
+	// Numéros d'objets : 1 catalogue, 2 pages, 3 informations, 4 à 6 polices, 7 image éventuelle
+	firstPage := 7
+	xobjects := ""
+	if d.image != nil {
+		firstPage = 8
+		xobjects = " /XObject << /Im1 7 0 R >>"
+	}
+	kids := make([]string, len(d.pages))
+	for i := range d.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	object([]byte("<< /Type /Catalog /Pages 2 0 R >>"))
+	object([]byte(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages))))
+	object([]byte(fmt.Sprintf("<< /Title (%s) /Producer (api-golang) >>", escape(winAnsi(title)))))
+	for _, font := range []string{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique"} {
+		object([]byte("<< /Type /Font /Subtype /Type1 /BaseFont /" + font + " /Encoding /WinAnsiEncoding >>"))
+	}
+	if d.image != nil {
+		object(stream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode",
+			d.image.width, d.image.height), d.image.data))
+	}
+	for i, page := range d.pages {
+		object([]byte(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 4 0 R /F2 5 0 R /F3 6 0 R >>%s >> /Contents %d 0 R >>",
+			pageWidth, pageHeight, xobjects, firstPage+2*i+1)))
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		if _, err := zw.Write(page.Bytes()); err != nil {
+			return err
+		}
+		if err := zw.Close(); err != nil {
+			return err
+		}
+		object(stream("/Filter /FlateDecode", compressed.Bytes()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R /Info 3 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	_, err := w.Write(out.Bytes())
+	return err
+}
+
+// stream construit un objet flux avec son dictionnaire
+func stream(dict string, data []byte) []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<< %s /Length %d >>\nstream\n", dict, len(data))
+	buf.Write(data)
+	buf.WriteString("\nendstream")
+	return buf.Bytes()
+}
@@ -0,0 +1,129 @@
+package printcard
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	htmltemplate "html/template"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	texttemplate "text/template"
+)
+
+// Formats de fiche disponibles
+const (
+	FormatHTML     = "html"
+	FormatMarkdown = "md"
+	FormatPDF      = "pdf"
+)
+
+// ErrUnknownFormat format de fiche non pris en charge
+var ErrUnknownFormat = errors.New("format de fiche inconnu")
+
+// Noms des modèles, recherchés dans le répertoire de surcharge
+const (
+	HTMLTemplate     = "card.html.tmpl"
+	MarkdownTemplate = "card.md.tmpl"
+)
+
+// defaultTemplates modèles fournis avec l'API
+//
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+// funcs fonctions disponibles dans les modèles
+var funcs = map[string]interface{}{
+	"inc": func(i int) int { return i + 1 },
+}
+
+// Renderer met en page les fiches à partir des modèles HTML et Markdown
+// La fiche PDF est composée à partir du rendu Markdown
+type Renderer struct {
+	html     *htmltemplate.Template
+	markdown *texttemplate.Template
+}
+
+// Default retourne le moteur de rendu utilisant les modèles fournis avec l'API
+func Default() *Renderer {
+	renderer, err := NewRenderer("")
+	if err != nil {
+		panic(err)
+	}
+	return renderer
+}
+
+// NewRenderer charge les modèles de dir (card.html.tmpl, card.md.tmpl) ; un modèle absent
+// de dir (ou dir vide) est remplacé par le modèle fourni avec l'API
+func NewRenderer(dir string) (*Renderer, error) {
+	htmlSource, err := readTemplate(dir, HTMLTemplate)
+	if err != nil {
+		return nil, err
+	}
+	markdownSource, err := readTemplate(dir, MarkdownTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	html, err := htmltemplate.New(HTMLTemplate).Funcs(funcs).Parse(htmlSource)
+	if err != nil {
+		return nil, err
+	}
+	markdown, err := texttemplate.New(MarkdownTemplate).Funcs(funcs).Parse(markdownSource)
+	if err != nil {
+		return nil, err
+	}
+	return &Renderer{html: html, markdown: markdown}, nil
+}
+
+// readTemplate lit un modèle dans dir, ou le modèle fourni s'il n'y est pas
+func readTemplate(dir, name string) (string, error) {
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err == nil {
+			return string(data), nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+	}
+	data, err := defaultTemplates.ReadFile("templates/" + name)
+	return string(data), err
+}
+
+// Render écrit la fiche au format demandé (html, md ou pdf)
+// image (JPEG, PNG, GIF ou WebP) n'est utilisée que par le PDF, qui ne télécharge rien ; nil l'omet
+func (r *Renderer) Render(w io.Writer, format string, card Card, image []byte) error {
+	switch format {
+	case FormatHTML:
+		return r.html.Execute(w, card)
+	case FormatMarkdown:
+		return r.markdown.Execute(w, card)
+	case FormatPDF:
+		var markdown bytes.Buffer
+		if err := r.markdown.Execute(&markdown, card); err != nil {
+			return err
+		}
+		return writePDF(w, card.Name, markdown.String(), image)
+	default:
+		return ErrUnknownFormat
+	}
+}
+
+// ValidFormat indique si le format est pris en charge
+func ValidFormat(format string) bool {
+	return format == FormatHTML || format == FormatMarkdown || format == FormatPDF
+}
+
+// ContentType type MIME d'une fiche
+func ContentType(format string) string {
+	switch format {
+	case FormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case FormatPDF:
+		return "application/pdf"
+	default:
+		return "text/html; charset=utf-8"
+	}
+}
@@ -0,0 +1,223 @@
+package printcard
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testCard() Card {
+	card := FromRecette(models.Recette{
+		Recipe: domain.Recipe{
+			Name:  "Crème brûlée",
+			Page:  "https://www.allrecipes.com/recipe/creme-brulee/",
+			Image: "https://images.example.com/creme.jpg",
+			Ingredients: []models.Ingredient{
+				{Quantity: "4 egg yolks"},
+				{Quantity: " "},
+				{Quantity: "2 cups", Unit: "heavy cream"},
+			},
+			Instructions: []models.Instruction{
+				{Number: "1", Description: "Preheat the oven (150 °C)."},
+				{Number: "2", Description: ""},
+				{Number: "3", Description: "Bake <until> set & golden."},
+			},
+			Servings: 6,
+		},
+		TotalMinutes: 75,
+	})
+	card.Language = "fr"
+	card.Labels = Labels{Ingredients: "Ingrédients", Instructions: "Préparation", Servings: "Portions", TotalTime: "Temps total", Source: "Source"}
+	return card
+}
+
+func TestFromRecette(t *testing.T) {
+	card := testCard()
+	assert.Equal(t, []string{"4 egg yolks", "2 cups heavy cream"}, card.Ingredients)
+	assert.Equal(t, []string{"Preheat the oven (150 °C).", "Bake <until> set & golden."}, card.Instructions)
+	assert.Equal(t, "1 h 15 min", card.TotalTime)
+	assert.Equal(t, "https://www.allrecipes.com/recipe/creme-brulee/", card.Source)
+
+	assert.Equal(t, "", formatMinutes(0))
+	assert.Equal(t, "45 min", formatMinutes(45))
+	assert.Equal(t, "2 h", formatMinutes(120))
+}
+
+func TestRenderHTML(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Default().Render(&buf, FormatHTML, testCard(), nil))
+	html := buf.String()
+
+	assert.Contains(t, html, `<html lang="fr">`)
+	assert.Contains(t, html, "<h1>Crème brûlée</h1>")
+	assert.Contains(t, html, `<img src="https://images.example.com/creme.jpg"`)
+	assert.Equal(t, 2, strings.Count(html, `<input type="checkbox">`))
+	assert.Contains(t, html, "<li>Bake &lt;until&gt; set &amp; golden.</li>")
+	assert.Contains(t, html, "Portions : 6 · Temps total : 1 h 15 min")
+	assert.Contains(t, html, `Source : <a href="https://www.allrecipes.com/recipe/creme-brulee/">`)
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Default().Render(&buf, FormatMarkdown, testCard(), nil))
+	expected := `# Crème brûlée
+
+_Portions : 6 · Temps total : 1 h 15 min_
+
+![Crème brûlée](https://images.example.com/creme.jpg)
+
+## Ingrédients
+
+- [ ] 4 egg yolks
+- [ ] 2 cups heavy cream
+
+## Préparation
+
+1. Preheat the oven (150 °C).
+2. Bake <until> set & golden.
+
+---
+
+Source : <https://www.allrecipes.com/recipe/creme-brulee/>
+`
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	assert.ErrorIs(t, Default().Render(io.Discard, "docx", testCard(), nil), ErrUnknownFormat)
+	assert.True(t, ValidFormat("pdf"))
+	assert.False(t, ValidFormat("docx"))
+	assert.Equal(t, "application/pdf", ContentType(FormatPDF))
+}
+
+func TestTemplateOverride(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, MarkdownTemplate), []byte("{{.Name}} ({{len .Ingredients}})"), 0o644))
+
+	renderer, err := NewRenderer(dir)
+	require.NoError(t, err)
+
+	var md, html bytes.Buffer
+	require.NoError(t, renderer.Render(&md, FormatMarkdown, testCard(), nil))
+	assert.Equal(t, "Crème brûlée (2)", md.String())
+	// Le modèle HTML absent du répertoire reste celui fourni
+	require.NoError(t, renderer.Render(&html, FormatHTML, testCard(), nil))
+	assert.Contains(t, html.String(), "<h1>Crème brûlée</h1>")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, HTMLTemplate), []byte("{{.Name"), 0o644))
+	_, err = NewRenderer(dir)
+	assert.Error(t, err)
+}
+
+func testPNG(t *testing.T) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 40, 20))
+	for x := 0; x < 40; x++ {
+		for y := 0; y < 20; y++ {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 6), G: 100, B: 50, A: 200})
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+// pageContents décompresse les flux de contenu des pages
+func pageContents(t *testing.T, pdf []byte) string {
+	var contents strings.Builder
+	pattern := regexp.MustCompile(`(?s)<< /Filter /FlateDecode /Length (\d+) >>\nstream\n`)
+	for _, loc := range pattern.FindAllSubmatchIndex(pdf, -1) {
+		length, err := strconv.Atoi(string(pdf[loc[2]:loc[3]]))
+		require.NoError(t, err)
+		reader, err := zlib.NewReader(bytes.NewReader(pdf[loc[1] : loc[1]+length]))
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		contents.Write(data)
+	}
+	return contents.String()
+}
+
+func TestRenderPDF(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Default().Render(&buf, FormatPDF, testCard(), testPNG(t)))
+	pdf := buf.Bytes()
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/Subtype /Image /Width 40 /Height 20")
+	assert.Contains(t, string(pdf), "/Title (Cr\xe8me br\xfbl\xe9e)")
+
+	// Chaque entrée de la table de références pointe sur son objet
+	xref := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, xref)
+	start, err := strconv.Atoi(string(xref[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[start:], []byte("xref\n")))
+	offsets := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[start:], -1)
+	require.Len(t, offsets, 9)
+	for i, match := range offsets {
+		offset, err := strconv.Atoi(string(match[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(fmt.Sprintf("%d 0 obj\n", i+1))), "objet %d", i+1)
+	}
+
+	contents := pageContents(t, pdf)
+	assert.Contains(t, contents, "(Cr\xe8me br\xfbl\xe9e) Tj")
+	assert.Contains(t, contents, "/Im1 Do")
+	assert.Equal(t, 2, strings.Count(contents, "8 8 re S"))
+	assert.Contains(t, contents, "(Preheat the oven \\(150 \xb0C\\).) Tj")
+	assert.Contains(t, contents, "(2.) Tj")
+	assert.Contains(t, contents, "/F3 11.0 Tf")
+	assert.Contains(t, contents, "(Source : https://www.allrecipes.com/recipe/creme-brulee/) Tj")
+}
+
+func TestRenderPDFPages(t *testing.T) {
+	card := testCard()
+	for i := 0; i < 80; i++ {
+		card.Instructions = append(card.Instructions, strings.Repeat("Stir gently and keep warm. ", 4))
+	}
+	var buf bytes.Buffer
+	// Une image illisible est omise
+	require.NoError(t, Default().Render(&buf, FormatPDF, card, []byte("not an image")))
+	assert.NotContains(t, buf.String(), "/Subtype /Image")
+	count := regexp.MustCompile(`/Count (\d+)`).FindStringSubmatch(buf.String())
+	require.NotNil(t, count)
+	pages, _ := strconv.Atoi(count[1])
+	assert.Greater(t, pages, 1)
+}
+
+func TestWinAnsi(t *testing.T) {
+	assert.Equal(t, []byte("Cr\xe8me \x93br\xfbl\xe9e\x94 \x80 ?"), winAnsi("Crème “brûlée” € 😀"))
+	assert.Equal(t, []byte("a b"), winAnsi("a b\n"))
+}
+
+func TestWrap(t *testing.T) {
+	text := winAnsi("the quick brown fox jumps over the lazy dog")
+	lines := wrap(fontRegular, 10, 100, text)
+	require.Greater(t, len(lines), 1)
+	for _, line := range lines {
+		assert.LessOrEqual(t, textWidth(fontRegular, 10, line), 100.0)
+	}
+	assert.Equal(t, string(text), string(bytes.Join(lines, []byte(" "))))
+
+	// Un mot trop long est coupé
+	long := wrap(fontBold, 10, 30, []byte("abcdefghijklmnop"))
+	assert.Greater(t, len(long), 1)
+	assert.Equal(t, "abcdefghijklmnop", string(bytes.Join(long, nil)))
+	assert.Equal(t, [][]byte{nil}, wrap(fontRegular, 10, 100, nil))
+}
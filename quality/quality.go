@@ -0,0 +1,169 @@
+package quality
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// Identifiants des règles de validation
+const (
+	RuleRequired        = "required"
+	RuleMinIngredients  = "min_ingredients"
+	RuleMinInstructions = "min_instructions"
+	RuleValidURL        = "valid_url"
+	RuleDuplicateStep   = "duplicate_step"
+)
+
+// fields champs pouvant être déclarés obligatoires, avec le test de présence associé
+var fields = map[string]func(models.Recette) bool{
+	"name":         func(r models.Recette) bool { return strings.TrimSpace(r.Name) != "" },
+	"page":         func(r models.Recette) bool { return strings.TrimSpace(r.Page) != "" },
+	"image":        func(r models.Recette) bool { return strings.TrimSpace(r.Image) != "" },
+	"category":     func(r models.Recette) bool { return strings.TrimSpace(r.Category) != "" },
+	"ingredients":  func(r models.Recette) bool { return countIngredients(r) > 0 },
+	"instructions": func(r models.Recette) bool { return countInstructions(r) > 0 },
+	"servings":     func(r models.Recette) bool { return r.Servings > 0 },
+	"total_time":   func(r models.Recette) bool { return r.TotalTime != "" },
+}
+
+// Rules règles appliquées aux recettes avant leur enregistrement
+// Une valeur nulle désactive la règle correspondante
+type Rules struct {
+	Required        []string `json:"required"`         // champs obligatoires
+	MinIngredients  int      `json:"min_ingredients"`  // nombre minimal d'ingrédients renseignés
+	MinInstructions int      `json:"min_instructions"` // nombre minimal d'étapes renseignées
+	ValidURLs       bool     `json:"valid_urls"`       // page et image doivent être des URL http(s) absolues
+	UniqueSteps     bool     `json:"unique_steps"`     // pas deux étapes identiques
+}
+
+// DefaultRules règles par défaut : nom et page obligatoires, au moins un ingrédient et une étape
+func DefaultRules() Rules {
+	return Rules{
+		Required:        []string{"name", "page"},
+		MinIngredients:  1,
+		MinInstructions: 1,
+		ValidURLs:       true,
+		UniqueSteps:     true,
+	}
+}
+
+// ParseFields lit une liste de champs obligatoires séparés par des virgules
+func ParseFields(value string) ([]string, error) {
+	result := []string{}
+	for _, field := range strings.Split(value, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" {
+			continue
+		}
+		if _, ok := fields[field]; !ok {
+			return nil, fmt.Errorf("champ inconnu : %s", field)
+		}
+		result = append(result, field)
+	}
+	return result, nil
+}
+
+// Validate retourne les règles non respectées par la recette (vide si elle est valide)
+func (r Rules) Validate(recette models.Recette) []models.QualityIssue {
+	issues := []models.QualityIssue{}
+
+	required := append([]string(nil), r.Required...)
+	sort.Strings(required)
+	for _, field := range required {
+		if present, ok := fields[field]; ok && !present(recette) {
+			issues = append(issues, models.QualityIssue{
+				Rule:    RuleRequired,
+				Field:   field,
+				Message: "Champ obligatoire manquant : " + field,
+			})
+		}
+	}
+
+	if n := countIngredients(recette); n < r.MinIngredients {
+		issues = append(issues, models.QualityIssue{
+			Rule:    RuleMinIngredients,
+			Field:   "ingredients",
+			Message: fmt.Sprintf("%d ingrédient(s) renseigné(s), %d minimum", n, r.MinIngredients),
+		})
+	}
+	if n := countInstructions(recette); n < r.MinInstructions {
+		issues = append(issues, models.QualityIssue{
+			Rule:    RuleMinInstructions,
+			Field:   "instructions",
+			Message: fmt.Sprintf("%d étape(s) renseignée(s), %d minimum", n, r.MinInstructions),
+		})
+	}
+
+	if r.ValidURLs {
+		for _, u := range []struct{ field, value string }{{"page", recette.Page}, {"image", recette.Image}} {
+			if u.value != "" && !validURL(u.value) {
+				issues = append(issues, models.QualityIssue{
+					Rule:    RuleValidURL,
+					Field:   u.field,
+					Message: "URL invalide : " + u.value,
+				})
+			}
+		}
+	}
+
+	if r.UniqueSteps {
+		seen := map[string]int{}
+		for i, instruction := range recette.Instructions {
+			step := normalizeStep(instruction.Description)
+			if step == "" {
+				continue
+			}
+			if first, ok := seen[step]; ok {
+				issues = append(issues, models.QualityIssue{
+					Rule:    RuleDuplicateStep,
+					Field:   "instructions",
+					Message: fmt.Sprintf("L'étape %d est identique à l'étape %d", i+1, first),
+				})
+				continue
+			}
+			seen[step] = i + 1
+		}
+	}
+
+	return issues
+}
+
+// countIngredients nombre de lignes d'ingrédient non vides
+func countIngredients(recette models.Recette) int {
+	n := 0
+	for _, ingredient := range recette.Ingredients {
+		if strings.TrimSpace(ingredient.Quantity+" "+ingredient.Unit) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// countInstructions nombre d'étapes non vides
+func countInstructions(recette models.Recette) int {
+	n := 0
+	for _, instruction := range recette.Instructions {
+		if strings.TrimSpace(instruction.Description) != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// validURL vérifie qu'une URL est absolue, en http ou https
+func validURL(value string) bool {
+	u, err := url.ParseRequestURI(value)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// normalizeStep texte d'une étape en minuscules, espaces réduits
+func normalizeStep(description string) string {
+	return strings.Join(strings.Fields(strings.ToLower(description)), " ")
+}
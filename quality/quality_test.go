@@ -0,0 +1,92 @@
+package quality
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validRecette() models.Recette {
+	return models.Recette{
+		Recipe: domain.Recipe{
+			Name:  "Tomato Soup",
+			Page:  "https://www.allrecipes.com/recipe/1/tomato-soup/",
+			Image: "https://www.allrecipes.com/thmb/soup.jpg",
+			Ingredients: []models.Ingredient{
+				{Quantity: "2 tomatoes"},
+				{Quantity: "1 cup broth"},
+			},
+			Instructions: []models.Instruction{
+				{Number: "1", Description: "Roast the tomatoes."},
+				{Number: "2", Description: "Blend with the broth."},
+			},
+		},
+	}
+}
+
+func rules(issues []models.QualityIssue) []string {
+	result := []string{}
+	for _, issue := range issues {
+		result = append(result, issue.Rule+":"+issue.Field)
+	}
+	return result
+}
+
+func TestValidateValid(t *testing.T) {
+	assert.Empty(t, DefaultRules().Validate(validRecette()))
+}
+
+func TestValidateRequired(t *testing.T) {
+	recette := validRecette()
+	recette.Name = "  "
+	recette.Page = ""
+
+	issues := DefaultRules().Validate(recette)
+	assert.Equal(t, []string{"required:name", "required:page"}, rules(issues))
+}
+
+func TestValidateMinCounts(t *testing.T) {
+	recette := validRecette()
+	recette.Ingredients = []models.Ingredient{{Quantity: " ", Unit: ""}}
+	recette.Instructions = nil
+
+	issues := DefaultRules().Validate(recette)
+	assert.Equal(t, []string{"min_ingredients:ingredients", "min_instructions:instructions"}, rules(issues))
+	assert.Equal(t, "0 ingrédient(s) renseigné(s), 1 minimum", issues[0].Message)
+
+	custom := Rules{MinIngredients: 3}
+	assert.Equal(t, []string{"min_ingredients:ingredients"}, rules(custom.Validate(validRecette())))
+}
+
+func TestValidateURLs(t *testing.T) {
+	recette := validRecette()
+	recette.Page = "/recipe/1"
+	recette.Image = "ftp://example.com/soup.jpg"
+
+	issues := DefaultRules().Validate(recette)
+	assert.Equal(t, []string{"valid_url:page", "valid_url:image"}, rules(issues))
+
+	assert.Empty(t, Rules{}.Validate(recette))
+}
+
+func TestValidateDuplicateSteps(t *testing.T) {
+	recette := validRecette()
+	recette.Instructions = append(recette.Instructions, models.Instruction{Number: "3", Description: "roast  the TOMATOES."})
+
+	issues := DefaultRules().Validate(recette)
+	require.Len(t, issues, 1)
+	assert.Equal(t, RuleDuplicateStep, issues[0].Rule)
+	assert.Equal(t, "L'étape 3 est identique à l'étape 1", issues[0].Message)
+}
+
+func TestParseFields(t *testing.T) {
+	parsed, err := ParseFields(" Name, image ,,category")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "image", "category"}, parsed)
+
+	_, err = ParseFields("name,colour")
+	assert.Error(t, err)
+}
@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Result décrit l'état d'un bucket après une demande de jeton
+type Result struct {
+	Allowed    bool          // La requête peut-elle passer ?
+	Limit      int           // Capacité du bucket (requêtes par fenêtre)
+	Remaining  int           // Jetons restants après la demande
+	Reset      time.Duration // Temps avant que le bucket soit de nouveau plein
+	RetryAfter time.Duration // Temps d'attente avant le prochain jeton (si refusé)
+}
+
+// bucket représente un token bucket individuel
+type bucket struct {
+	capacity float64   // Nombre maximum de jetons
+	rate     float64   // Jetons ajoutés par seconde
+	tokens   float64   // Jetons disponibles
+	last     time.Time // Dernier remplissage
+}
+
+// Limiter gère un ensemble de token buckets indexés par une clé (IP, clé API...)
+// Thread-safe grâce au Mutex
+type Limiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	perMinute int
+	window    time.Duration
+	now       func() time.Time
+}
+
+// NewLimiter crée un limiteur autorisant perMinute requêtes par minute et par clé
+func NewLimiter(perMinute int) *Limiter {
+	return &Limiter{
+		buckets:   make(map[string]*bucket),
+		perMinute: perMinute,
+		window:    time.Minute,
+		now:       time.Now,
+	}
+}
+
+// Allow consomme un jeton pour la clé donnée avec la limite par défaut
+func (l *Limiter) Allow(key string) Result {
+	return l.AllowWithLimit(key, 0)
+}
+
+// AllowWithLimit consomme un jeton pour la clé donnée
+// perMinute: limite spécifique à la clé (0 pour utiliser la limite par défaut)
+func (l *Limiter) AllowWithLimit(key string, perMinute int) Result {
+	if perMinute <= 0 {
+		perMinute = l.perMinute
+	}
+	capacity := float64(perMinute)
+	rate := capacity / l.window.Seconds()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, exists := l.buckets[key]
+	if !exists || b.capacity != capacity {
+		// Nouveau bucket (ou limite modifiée) : on démarre plein
+		b = &bucket{capacity: capacity, rate: rate, tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+
+	// Remplissage proportionnel au temps écoulé
+	elapsed := now.Sub(b.last).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(b.capacity, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+
+	result := Result{Limit: perMinute}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = secondsToDuration((1 - b.tokens) / b.rate)
+	}
+
+	result.Remaining = int(math.Floor(b.tokens))
+	result.Reset = secondsToDuration((b.capacity - b.tokens) / b.rate)
+	return result
+}
+
+// Cleanup supprime les buckets inactifs depuis plus de idle
+// Avec idle supérieur à la fenêtre, un bucket supprimé serait de toute façon de nouveau plein
+func (l *Limiter) Cleanup(idle time.Duration) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	removed := 0
+	for key, b := range l.buckets {
+		if now.Sub(b.last) > idle {
+			delete(l.buckets, key)
+			removed++
+		}
+	}
+	return removed
+}
+
+// StartJanitor démarre une goroutine de nettoyage périodique des buckets inactifs
+func (l *Limiter) StartJanitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for range ticker.C {
+			l.Cleanup(l.window * 2)
+		}
+	}()
+}
+
+// secondsToDuration convertit un nombre de secondes flottant en durée
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
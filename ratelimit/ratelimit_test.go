@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestLimiter crée un limiteur avec une horloge contrôlée
+func newTestLimiter(perMinute int, now *time.Time) *Limiter {
+	l := NewLimiter(perMinute)
+	l.now = func() time.Time { return *now }
+	return l
+}
+
+func TestLimiterAllowUntilEmpty(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(3, &now)
+
+	for i := 0; i < 3; i++ {
+		res := l.Allow("1.2.3.4")
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 3, res.Limit)
+		assert.Equal(t, 2-i, res.Remaining)
+	}
+
+	res := l.Allow("1.2.3.4")
+	assert.False(t, res.Allowed)
+	assert.Equal(t, 0, res.Remaining)
+	assert.Equal(t, 20*time.Second, res.RetryAfter)
+	assert.Equal(t, time.Minute, res.Reset)
+}
+
+func TestLimiterRefill(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(60, &now)
+
+	for i := 0; i < 60; i++ {
+		l.Allow("key")
+	}
+	assert.False(t, l.Allow("key").Allowed)
+
+	// 60 requêtes par minute = 1 jeton par seconde
+	now = now.Add(time.Second)
+	assert.True(t, l.Allow("key").Allowed)
+	assert.False(t, l.Allow("key").Allowed)
+}
+
+func TestLimiterKeysAreIndependent(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(1, &now)
+
+	assert.True(t, l.Allow("a").Allowed)
+	assert.False(t, l.Allow("a").Allowed)
+	assert.True(t, l.Allow("b").Allowed)
+}
+
+func TestLimiterCustomLimit(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(1, &now)
+
+	res := l.AllowWithLimit("premium", 100)
+	assert.True(t, res.Allowed)
+	assert.Equal(t, 100, res.Limit)
+	assert.Equal(t, 99, res.Remaining)
+}
+
+func TestLimiterCleanup(t *testing.T) {
+	now := time.Now()
+	l := newTestLimiter(10, &now)
+
+	l.Allow("a")
+	l.Allow("b")
+	now = now.Add(5 * time.Minute)
+	l.Allow("c")
+
+	assert.Equal(t, 2, l.Cleanup(time.Minute))
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	l := NewLimiter(1000)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	allowed := 0
+
+	for i := 0; i < 1500; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if l.Allow("shared").Allowed {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Le remplissage pendant le test peut accorder quelques jetons supplémentaires
+	assert.GreaterOrEqual(t, allowed, 1000)
+	assert.Less(t, allowed, 1100)
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"sync"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AliasRepository recettes fusionnées, conservées pour rediriger vers leur recette canonique
+type AliasRepository interface {
+	// Get retourne l'alias d'une recette fusionnée (ErrNotFound si la recette n'a pas été fusionnée)
+	Get(ctx context.Context, id primitive.ObjectID) (*models.RecetteAlias, error)
+	// Pages retourne les pages des recettes fusionnées
+	Pages(ctx context.Context) ([]string, error)
+	// Add enregistre les alias de recettes venant d'être fusionnées
+	Add(ctx context.Context, aliases []models.RecetteAlias) error
+	// Reassign rattache à une autre recette canonique les alias de recettes fusionnées à leur tour
+	Reassign(ctx context.Context, from []primitive.ObjectID, to primitive.ObjectID) error
+}
+
+// MongoAliases alias dans une collection MongoDB
+type MongoAliases struct {
+	collection *mongo.Collection
+}
+
+// NewMongoAliases crée un stockage des alias sur la collection fournie
+func NewMongoAliases(collection *mongo.Collection) *MongoAliases {
+	return &MongoAliases{collection: collection}
+}
+
+// Get retourne l'alias d'une recette fusionnée
+func (m *MongoAliases) Get(ctx context.Context, id primitive.ObjectID) (*models.RecetteAlias, error) {
+	var alias models.RecetteAlias
+	err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&alias)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &alias, nil
+}
+
+// Pages retourne les pages (non vides) des recettes fusionnées
+func (m *MongoAliases) Pages(ctx context.Context) ([]string, error) {
+	values, err := m.collection.Distinct(ctx, "page", bson.M{})
+	if err != nil {
+		return nil, err
+	}
+	pages := make([]string, 0, len(values))
+	for _, value := range values {
+		if page, ok := value.(string); ok && page != "" {
+			pages = append(pages, page)
+		}
+	}
+	return pages, nil
+}
+
+// Add enregistre les alias de recettes venant d'être fusionnées
+func (m *MongoAliases) Add(ctx context.Context, aliases []models.RecetteAlias) error {
+	if len(aliases) == 0 {
+		return nil
+	}
+	docs := make([]interface{}, 0, len(aliases))
+	for _, alias := range aliases {
+		docs = append(docs, alias)
+	}
+	_, err := m.collection.InsertMany(ctx, docs)
+	return err
+}
+
+// Reassign rattache les alias des recettes from à la recette to
+func (m *MongoAliases) Reassign(ctx context.Context, from []primitive.ObjectID, to primitive.ObjectID) error {
+	_, err := m.collection.UpdateMany(ctx, bson.M{"recette_id": bson.M{"$in": from}}, bson.M{"$set": bson.M{"recette_id": to}})
+	return err
+}
+
+// MemoryAliases alias en mémoire
+type MemoryAliases struct {
+	mu      sync.RWMutex
+	aliases map[primitive.ObjectID]models.RecetteAlias
+}
+
+// NewMemoryAliases crée un stockage des alias en mémoire vide
+func NewMemoryAliases() *MemoryAliases {
+	return &MemoryAliases{aliases: map[primitive.ObjectID]models.RecetteAlias{}}
+}
+
+// Get retourne l'alias d'une recette fusionnée
+func (m *MemoryAliases) Get(ctx context.Context, id primitive.ObjectID) (*models.RecetteAlias, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	alias, ok := m.aliases[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &alias, nil
+}
+
+// Pages retourne les pages (non vides et distinctes) des recettes fusionnées
+func (m *MemoryAliases) Pages(ctx context.Context) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	seen := map[string]bool{}
+	pages := []string{}
+	for _, alias := range m.aliases {
+		if alias.Page != "" && !seen[alias.Page] {
+			seen[alias.Page] = true
+			pages = append(pages, alias.Page)
+		}
+	}
+	return pages, nil
+}
+
+// Add enregistre les alias de recettes venant d'être fusionnées
+func (m *MemoryAliases) Add(ctx context.Context, aliases []models.RecetteAlias) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, alias := range aliases {
+		m.aliases[alias.ID] = alias
+	}
+	return nil
+}
+
+// Reassign rattache les alias des recettes from à la recette to
+func (m *MemoryAliases) Reassign(ctx context.Context, from []primitive.ObjectID, to primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, alias := range m.aliases {
+		for _, previous := range from {
+			if alias.RecetteID == previous {
+				alias.RecetteID = to
+				m.aliases[id] = alias
+				break
+			}
+		}
+	}
+	return nil
+}
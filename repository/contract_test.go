@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// searchContract comportement de Search commun à tous les stockages de recettes
+// Les différences documentées sur RecipeRepository.Search (morceaux de mots, racines) n'en font pas partie
+func searchContract(t *testing.T, store RecipeRepository) {
+	ctx := context.Background()
+	recettes := seed(t, store,
+		testRecette("Soupe", "https://example.com/soupe", 30, "2 carottes", "1 l de bouillon de légumes"),
+		testRecette("Tarte", "https://example.com/tarte", 90, "3 pommes", "1 pâte brisée"),
+		testRecette("Gratin", "https://example.com/gratin", 60, "50 cl de Bouillon de Légumes"),
+		testRecette("Risotto", "https://example.com/risotto", 40, "1 l de bouillon de volaille", "des légumes"),
+	)
+
+	// Expression entière, sans tenir compte de la casse
+	found, err := store.Search(ctx, "Bouillon de légumes", Query{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Soupe", "Gratin"}, names(found))
+
+	// Les mots doivent se suivre dans l'ordre de l'expression
+	found, err = store.Search(ctx, "légumes de bouillon", Query{})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+
+	// Tri et filtres de la requête
+	found, err = store.Search(ctx, "bouillon de légumes", Query{Sort: "total_time", Desc: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Gratin", "Soupe"}, names(found))
+	found, err = store.Search(ctx, "bouillon", Query{MaxTotalMinutes: 45, Sort: "total_time"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe", "Risotto"}, names(found))
+
+	// Les recettes de la corbeille sont ignorées
+	require.NoError(t, store.Delete(ctx, recettes[0].ID))
+	found, err = store.Search(ctx, "bouillon de légumes", Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Gratin"}, names(found))
+
+	found, err = store.Search(ctx, "chocolat", Query{})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+	assert.NotNil(t, found)
+}
+
+func TestMemorySearchContract(t *testing.T) {
+	searchContract(t, NewMemory())
+}
@@ -0,0 +1,278 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/facets"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/stats"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Memory stockage des recettes en mémoire, pour le développement local et les tests
+// Les recettes sont copiées à l'entrée et à la sortie : une recette retournée peut être modifiée
+// sans effet sur le stockage. Le contenu est perdu à l'arrêt du processus
+type Memory struct {
+	mu       sync.RWMutex
+	recettes map[primitive.ObjectID]models.Recette
+	order    []primitive.ObjectID // ordre d'insertion, ordre des listes sans tri
+}
+
+// NewMemory crée un stockage en mémoire vide
+func NewMemory() *Memory {
+	return &Memory{recettes: map[primitive.ObjectID]models.Recette{}}
+}
+
+// Get retourne une recette hors corbeille par son identifiant
+func (m *Memory) Get(ctx context.Context, id primitive.ObjectID) (*models.Recette, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recette, ok := m.recettes[id]
+	if !ok || recette.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	recette = clone(recette)
+	return &recette, nil
+}
+
+// FindByName recherche par nom sans tenir compte de la casse, dans l'ordre d'insertion
+func (m *Memory) FindByName(ctx context.Context, name string, query Query) (*models.Recette, error) {
+	matches := m.filter(query, func(r *models.Recette) bool { return strings.EqualFold(r.Name, name) })
+	if len(matches) == 0 {
+		return nil, ErrNotFound
+	}
+	return &matches[0], nil
+}
+
+// Search recherche l'expression dans les lignes d'ingrédients, sans tenir compte de la casse ni des espaces
+// Contrairement à l'index texte de MongoDB, une partie de mot suffit (voir RecipeRepository.Search)
+func (m *Memory) Search(ctx context.Context, ingredient string, query Query) ([]models.Recette, error) {
+	phrase := normalizeText(ingredient)
+	recettes := m.filter(query, func(r *models.Recette) bool {
+		return containsIngredient(r, phrase)
+	})
+	sortRecettes(recettes, query)
+	return recettes, nil
+}
+
+// normalizeText texte en minuscules, espaces successifs réduits à un seul
+func normalizeText(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// List retourne les recettes correspondant aux critères
+func (m *Memory) List(ctx context.Context, query Query) ([]models.Recette, error) {
+	recettes := m.filter(query, nil)
+	sortRecettes(recettes, query)
+	return recettes, nil
+}
+
+// Facets calcule les facettes des recettes retournées par List ou Search
+func (m *Memory) Facets(ctx context.Context, ingredient string, query Query) (facets.Facets, error) {
+	var recettes []models.Recette
+	var err error
+	if ingredient == "" {
+		recettes, err = m.List(ctx, query)
+	} else {
+		recettes, err = m.Search(ctx, ingredient, query)
+	}
+	if err != nil {
+		return facets.Facets{}, err
+	}
+	return facets.Compute(recettes), nil
+}
+
+// Stats calcule les statistiques des recettes hors corbeille
+func (m *Memory) Stats(ctx context.Context) (stats.Report, error) {
+	recettes, err := m.List(ctx, Query{})
+	if err != nil {
+		return stats.Report{}, err
+	}
+	return stats.Compute(recettes, stats.DefaultTopIngredients, now()), nil
+}
+
+// Upsert remplace la recette de même identifiant ou l'insère
+func (m *Memory) Upsert(ctx context.Context, recette *models.Recette) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.upsert(recette)
+	return nil
+}
+
+// Delete place la recette dans la corbeille
+func (m *Memory) Delete(ctx context.Context, id primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recette, ok := m.recettes[id]
+	if !ok || recette.DeletedAt != nil {
+		return ErrNotFound
+	}
+	deletedAt := now()
+	recette.DeletedAt = &deletedAt
+	m.recettes[id] = recette
+	return nil
+}
+
+// Restore sort une recette de la corbeille
+func (m *Memory) Restore(ctx context.Context, id primitive.ObjectID) (*models.Recette, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recette, ok := m.recettes[id]
+	if !ok || recette.DeletedAt == nil {
+		return nil, ErrNotFound
+	}
+	recette.DeletedAt = nil
+	m.recettes[id] = recette
+	recette = clone(recette)
+	return &recette, nil
+}
+
+// ListDeleted retourne les recettes de la corbeille, les plus récemment supprimées en premier
+func (m *Memory) ListDeleted(ctx context.Context) ([]models.Recette, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recettes := []models.Recette{}
+	for _, id := range m.order {
+		if recette := m.recettes[id]; recette.DeletedAt != nil {
+			recettes = append(recettes, clone(recette))
+		}
+	}
+	sort.SliceStable(recettes, func(i, j int) bool {
+		if !recettes[i].DeletedAt.Equal(*recettes[j].DeletedAt) {
+			return recettes[i].DeletedAt.After(*recettes[j].DeletedAt)
+		}
+		return recettes[i].ID.Hex() < recettes[j].ID.Hex()
+	})
+	return recettes, nil
+}
+
+// Remove supprime définitivement des recettes
+func (m *Memory) Remove(ctx context.Context, ids []primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range ids {
+		delete(m.recettes, id)
+	}
+	order := m.order[:0]
+	for _, id := range m.order {
+		if _, ok := m.recettes[id]; ok {
+			order = append(order, id)
+		}
+	}
+	m.order = order
+	return nil
+}
+
+// Purge supprime définitivement les recettes mises à la corbeille avant la date
+func (m *Memory) Purge(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ids []primitive.ObjectID
+	order := m.order[:0]
+	for _, id := range m.order {
+		if recette := m.recettes[id]; recette.DeletedAt != nil && recette.DeletedAt.Before(before) {
+			ids = append(ids, id)
+			delete(m.recettes, id)
+			continue
+		}
+		order = append(order, id)
+	}
+	m.order = order
+	return ids, nil
+}
+
+// BulkImport rapproche les recettes par page, corbeille comprise ; le lot est importé d'un bloc
+func (m *Memory) BulkImport(ctx context.Context, recettes []models.Recette) ([]Imported, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	findByPage := func(ctx context.Context, page string) (*models.Recette, error) {
+		for _, id := range m.order {
+			if recette := m.recettes[id]; recette.Page == page {
+				recette = clone(recette)
+				return &recette, nil
+			}
+		}
+		return nil, nil
+	}
+	upsert := func(ctx context.Context, recette *models.Recette) error {
+		m.upsert(recette)
+		return nil
+	}
+	return bulkImport(ctx, recettes, findByPage, upsert)
+}
+
+// upsert enregistre une copie de la recette, verrou d'écriture détenu
+func (m *Memory) upsert(recette *models.Recette) {
+	prepare(recette)
+	if _, ok := m.recettes[recette.ID]; !ok {
+		m.order = append(m.order, recette.ID)
+	}
+	m.recettes[recette.ID] = clone(*recette)
+}
+
+// filter copies des recettes hors corbeille correspondant aux critères et au prédicat optionnel
+func (m *Memory) filter(query Query, predicate func(r *models.Recette) bool) []models.Recette {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	recettes := []models.Recette{}
+	for _, id := range m.order {
+		recette := m.recettes[id]
+		if query.Match(&recette) && (predicate == nil || predicate(&recette)) {
+			recettes = append(recettes, clone(recette))
+		}
+	}
+	return recettes
+}
+
+// clone copie une recette et ses listes (une liste nulle reste nulle, une liste vide reste vide)
+func clone(r models.Recette) models.Recette {
+	if r.Ingredients != nil {
+		r.Ingredients = append([]models.Ingredient{}, r.Ingredients...)
+	}
+	if r.Instructions != nil {
+		r.Instructions = append([]models.Instruction{}, r.Instructions...)
+	}
+	if r.Diets != nil {
+		diets := make([]models.DietTag, len(r.Diets))
+		for i, tag := range r.Diets {
+			tag.Triggers = cloneStrings(tag.Triggers)
+			tag.Blockers = cloneStrings(tag.Blockers)
+			tag.Uncertain = cloneStrings(tag.Uncertain)
+			diets[i] = tag
+		}
+		r.Diets = diets
+	}
+	r.Tags = cloneStrings(r.Tags)
+	r.IngredientNames = cloneStrings(r.IngredientNames)
+	r.Allergens = cloneStrings(r.Allergens)
+	r.Aliases = cloneStrings(r.Aliases)
+	if r.CreatedAt != nil {
+		createdAt := *r.CreatedAt
+		r.CreatedAt = &createdAt
+	}
+	if r.DeletedAt != nil {
+		deletedAt := *r.DeletedAt
+		r.DeletedAt = &deletedAt
+	}
+	return r
+}
+
+func cloneStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/facets"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Le stockage en mémoire doit rester interchangeable avec MongoDB
+var _ RecipeRepository = (*Memory)(nil)
+var _ RecipeRepository = (*Mongo)(nil)
+
+func testRecette(name, page string, totalMinutes int, ingredients ...string) models.Recette {
+	recette := models.Recette{
+		Recipe:       domain.Recipe{Name: name, Page: page},
+		TotalMinutes: totalMinutes,
+	}
+	for _, ingredient := range ingredients {
+		recette.Ingredients = append(recette.Ingredients, models.Ingredient{Quantity: ingredient})
+	}
+	return recette
+}
+
+func names(recettes []models.Recette) []string {
+	result := []string{}
+	for _, recette := range recettes {
+		result = append(result, recette.Name)
+	}
+	return result
+}
+
+func seed(t *testing.T, store RecipeRepository, recettes ...models.Recette) []models.Recette {
+	for i := range recettes {
+		require.NoError(t, store.Upsert(context.Background(), &recettes[i]))
+	}
+	return recettes
+}
+
+func TestMemoryUpsertAndGet(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+
+	recette := testRecette("Soupe", "https://example.com/soupe", 30, "2 carottes")
+	require.NoError(t, store.Upsert(ctx, &recette))
+	assert.False(t, recette.ID.IsZero())
+	assert.Equal(t, domain.SchemaVersion, recette.SchemaVersion)
+	require.NotNil(t, recette.CreatedAt)
+
+	found, err := store.Get(ctx, recette.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Soupe", found.Name)
+
+	// Les recettes retournées sont des copies
+	found.Ingredients[0].Quantity = "modifié"
+	found, err = store.Get(ctx, recette.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "2 carottes", found.Ingredients[0].Quantity)
+
+	// Remplacement : même identifiant, date de création conservée
+	createdAt := *recette.CreatedAt
+	recette.Name = "Soupe de carottes"
+	require.NoError(t, store.Upsert(ctx, &recette))
+	found, err = store.Get(ctx, recette.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Soupe de carottes", found.Name)
+	assert.Equal(t, createdAt, *found.CreatedAt)
+
+	_, err = store.Get(ctx, primitive.NewObjectID())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMemoryDelete(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	recettes := seed(t, store, testRecette("Soupe", "", 0), testRecette("Tarte", "", 0))
+
+	require.NoError(t, store.Delete(ctx, recettes[0].ID))
+	assert.ErrorIs(t, store.Delete(ctx, recettes[0].ID), ErrNotFound)
+	assert.ErrorIs(t, store.Delete(ctx, primitive.NewObjectID()), ErrNotFound)
+
+	_, err := store.Get(ctx, recettes[0].ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	list, err := store.List(ctx, Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tarte"}, names(list))
+}
+
+func TestMemoryTrash(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	recettes := seed(t, store, testRecette("Soupe", "", 0), testRecette("Tarte", "", 0), testRecette("Gratin", "", 0))
+
+	_, err := store.Restore(ctx, recettes[0].ID)
+	assert.ErrorIs(t, err, ErrNotFound, "une recette hors corbeille ne se restaure pas")
+
+	require.NoError(t, store.Delete(ctx, recettes[0].ID))
+	require.NoError(t, store.Delete(ctx, recettes[1].ID))
+	deleted, err := store.ListDeleted(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Soupe", "Tarte"}, names(deleted))
+
+	restored, err := store.Restore(ctx, recettes[0].ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	_, err = store.Get(ctx, recettes[0].ID)
+	assert.NoError(t, err)
+
+	// Seules les recettes supprimées avant la date sont purgées
+	ids, err := store.Purge(ctx, time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	ids, err = store.Purge(ctx, time.Now().UTC().Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.ObjectID{recettes[1].ID}, ids)
+
+	deleted, err = store.ListDeleted(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, deleted)
+	_, err = store.Restore(ctx, recettes[1].ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	list, err := store.List(ctx, Query{})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"Soupe", "Gratin"}, names(list))
+}
+
+func TestMemoryFindByName(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	seed(t, store, testRecette("Soupe de Carottes", "", 0), testRecette("Tarte", "", 90))
+
+	found, err := store.FindByName(ctx, "soupe de carottes", Query{})
+	require.NoError(t, err)
+	assert.Equal(t, "Soupe de Carottes", found.Name)
+
+	_, err = store.FindByName(ctx, "tarte", Query{MaxTotalMinutes: 30})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+// Comportements propres au stockage en mémoire ; le comportement commun est vérifié par searchContract
+func TestMemorySearch(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	seed(t, store,
+		testRecette("Soupe", "", 30, "2 carottes", "1 l de bouillon de légumes"),
+		testRecette("Tarte", "", 90, "3 pommes", "1 pâte brisée"),
+		testRecette("Gratin", "", 60, "Bouillon  de légumes"),
+	)
+
+	// Espaces successifs ignorés
+	found, err := store.Search(ctx, "bouillon de légumes", Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe", "Gratin"}, names(found))
+
+	// Une partie de mot suffit, les mots ne sont pas ramenés à leur racine
+	found, err = store.Search(ctx, "pom", Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tarte"}, names(found))
+	found, err = store.Search(ctx, "carotte", Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe"}, names(found))
+	found, err = store.Search(ctx, "pommes brisées", Query{})
+	require.NoError(t, err)
+	assert.Empty(t, found)
+}
+
+func TestMemoryListFilterAndSort(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	soupe := testRecette("Soupe", "", 30)
+	soupe.Category = "soup"
+	soupe.Diets = []models.DietTag{{Name: "vegan", Compatible: true}}
+	tarte := testRecette("Tarte", "", 90)
+	tarte.Category = "desserts"
+	tarte.Allergens = []string{"gluten", "milk"}
+	salade := testRecette("Salade", "", 0)
+	salade.Category = "soup"
+	seed(t, store, soupe, tarte, salade)
+
+	list, err := store.List(ctx, Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe", "Tarte", "Salade"}, names(list))
+
+	list, err = store.List(ctx, Query{Categories: []string{"soup"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe", "Salade"}, names(list))
+
+	list, err = store.List(ctx, Query{Diets: []string{"vegan"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe"}, names(list))
+
+	list, err = store.List(ctx, Query{ExcludeAllergens: []string{"milk"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe", "Salade"}, names(list))
+
+	// Durée inconnue exclue par un maximum, placée en dernier par un tri
+	list, err = store.List(ctx, Query{MaxTotalMinutes: 120})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe", "Tarte"}, names(list))
+
+	list, err = store.List(ctx, Query{Sort: "total_time", Desc: true})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Tarte", "Soupe", "Salade"}, names(list))
+
+	list, err = store.List(ctx, Query{Sort: "name"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Salade", "Soupe", "Tarte"}, names(list))
+}
+
+func TestMemoryFacets(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+	recettes := []models.Recette{
+		testRecette("Soupe", "", 0, "2 carottes"),
+		testRecette("Velouté", "", 0, "1 poireau"),
+		testRecette("Tarte", "", 0, "3 carottes"),
+	}
+	recettes[0].Category, recettes[1].Category, recettes[2].Category = "soupes", "soupes", "desserts"
+	seed(t, store, recettes...)
+
+	all, err := store.Facets(ctx, "", Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []facets.Count{{Value: "soupes", Count: 2}, {Value: "desserts", Count: 1}}, all.Category)
+
+	// Mêmes critères que la recherche par ingrédient
+	carottes, err := store.Facets(ctx, "carottes", Query{Categories: []string{"soupes"}})
+	require.NoError(t, err)
+	assert.Equal(t, []facets.Count{{Value: "soupes", Count: 1}}, carottes.Category)
+}
+
+func TestMemoryBulkImport(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemory()
+
+	imported, err := store.BulkImport(ctx, []models.Recette{
+		testRecette("Soupe", "https://example.com/soupe", 30, "2 carottes"),
+		testRecette("Tarte", "https://example.com/tarte", 90, "3 pommes"),
+	})
+	require.NoError(t, err)
+	require.Len(t, imported, 2)
+	assert.Equal(t, ImportInserted, imported[0].Status)
+	assert.Nil(t, imported[0].Previous)
+	soupeID := imported[0].Recette.ID
+
+	// L'état propre à la recette enregistrée survit à la réimportation
+	tagged := imported[0].Recette
+	tagged.Tags = []string{"hiver"}
+	require.NoError(t, store.Upsert(ctx, &tagged))
+	require.NoError(t, store.Delete(ctx, imported[1].Recette.ID))
+
+	imported, err = store.BulkImport(ctx, []models.Recette{
+		testRecette("Soupe", "https://example.com/soupe", 30, "3 carottes"),
+		testRecette("Tarte", "https://example.com/tarte", 90, "3 pommes"),
+		testRecette("Gratin", "https://example.com/gratin", 60),
+	})
+	require.NoError(t, err)
+	require.Len(t, imported, 3)
+
+	assert.Equal(t, ImportUpdated, imported[0].Status)
+	assert.Equal(t, soupeID, imported[0].Recette.ID)
+	assert.Equal(t, []string{"hiver"}, imported[0].Recette.Tags)
+	require.NotNil(t, imported[0].Previous)
+	assert.Equal(t, "2 carottes", imported[0].Previous.Ingredients[0].Quantity)
+
+	assert.Equal(t, ImportUnchanged, imported[1].Status)
+	assert.NotNil(t, imported[1].Recette.DeletedAt)
+	assert.Equal(t, ImportInserted, imported[2].Status)
+
+	list, err := store.List(ctx, Query{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Soupe", "Gratin"}, names(list))
+	assert.Equal(t, "3 carottes", list[0].Ingredients[0].Quantity)
+}
+
+func TestFilter(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{"deleted_at": nil}, map[string]interface{}(Filter(Query{})))
+
+	filter := Filter(Query{Categories: []string{"soup"}, MaxTotalMinutes: 30})
+	conditions, ok := filter["$and"]
+	require.True(t, ok)
+	assert.Len(t, conditions, 3)
+}
+
+func TestMatchBulkCriteria(t *testing.T) {
+	recette := testRecette("Soupe", "", 0, "2  Grosses carottes")
+	recette.ImportRun = "run-1"
+
+	assert.True(t, Query{Ingredient: "grosses carottes"}.Match(&recette))
+	assert.False(t, Query{Ingredient: "poireau"}.Match(&recette))
+	assert.True(t, Query{MissingInstructions: true, ImportRun: "run-1"}.Match(&recette))
+	assert.False(t, Query{ImportRun: "run-2"}.Match(&recette))
+
+	recette.Instructions = []models.Instruction{{Description: "Cuire"}}
+	assert.False(t, Query{MissingInstructions: true}.Match(&recette))
+}
+
+func TestSortNames(t *testing.T) {
+	assert.Equal(t, []string{"cook_time", "name", "prep_time", "servings", "total_time"}, SortNames())
+	assert.True(t, ValidSort("servings"))
+	assert.False(t, ValidSort("-servings"))
+}
+
+func TestIngredientPhrase(t *testing.T) {
+	filter := ingredientPhrase(" bouillon  de (légumes) ")
+	pattern := filter["ingredients"].(bson.M)["$elemMatch"].(bson.M)["$or"].(bson.A)[0].(bson.M)["quantity"]
+	assert.Equal(t, primitive.Regex{Pattern: `bouillon\s+de\s+\(légumes\)`, Options: "i"}, pattern)
+}
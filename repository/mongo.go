@@ -0,0 +1,281 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/facets"
+	"github.com/maxime-louis14/api-golang/indexes"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/stats"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// Mongo stockage des recettes dans une collection MongoDB
+// La recherche par nom et par ingrédient s'appuie sur les index name_ci et ingredients_text
+type Mongo struct {
+	collection *mongo.Collection
+}
+
+// NewMongo crée un stockage sur la collection des recettes
+func NewMongo(collection *mongo.Collection) *Mongo {
+	return &Mongo{collection: collection}
+}
+
+// Get retourne une recette hors corbeille par son identifiant
+func (m *Mongo) Get(ctx context.Context, id primitive.ObjectID) (*models.Recette, error) {
+	return m.findOne(ctx, And(bson.M{"_id": id}, bson.M{"deleted_at": nil}))
+}
+
+// FindByName recherche par nom avec la collation de l'index name_ci
+func (m *Mongo) FindByName(ctx context.Context, name string, query Query) (*models.Recette, error) {
+	collation := &options.Collation{Locale: indexes.NameCollation.Locale, Strength: indexes.NameCollation.Strength}
+	return m.findOne(ctx, And(bson.M{"name": name}, Filter(query)), options.FindOne().SetCollation(collation))
+}
+
+// errIndexNotFound code d'erreur MongoDB d'une recherche $text sans index texte
+const errIndexNotFound = 27
+
+// Search recherche l'expression dans l'index texte des ingrédients (mots ramenés à leur racine)
+// Tant que l'index ingredients_text n'existe pas (synchronisation en cours ou INDEX_SYNC=false),
+// l'expression est recherchée telle quelle, sans tenir compte de la casse ni des espaces
+func (m *Mongo) Search(ctx context.Context, ingredient string, query Query) ([]models.Recette, error) {
+	recettes, err := m.find(ctx, And(textSearch(ingredient), Filter(query)), query)
+	if missingTextIndex(err) {
+		return m.find(ctx, And(ingredientPhrase(ingredient), Filter(query)), query)
+	}
+	return recettes, err
+}
+
+// textSearch filtre $text recherchant l'expression entière
+func textSearch(ingredient string) bson.M {
+	phrase := `"` + strings.ReplaceAll(ingredient, `"`, " ") + `"`
+	return bson.M{"$text": bson.M{"$search": phrase}}
+}
+
+// missingTextIndex indique si la recherche $text a échoué faute d'index texte
+func missingTextIndex(err error) bool {
+	var serverErr mongo.ServerError
+	return errors.As(err, &serverErr) && serverErr.HasErrorCode(errIndexNotFound)
+}
+
+// ingredientPhrase filtre des recettes dont une ligne d'ingrédient contient l'expression
+func ingredientPhrase(ingredient string) bson.M {
+	words := strings.Fields(ingredient)
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	pattern := primitive.Regex{Pattern: strings.Join(words, `\s+`), Options: "i"}
+	return bson.M{"ingredients": bson.M{"$elemMatch": bson.M{"$or": bson.A{
+		bson.M{"quantity": pattern},
+		bson.M{"unit": pattern},
+	}}}}
+}
+
+// List retourne les recettes correspondant aux critères
+func (m *Mongo) List(ctx context.Context, query Query) ([]models.Recette, error) {
+	return m.find(ctx, Filter(query), query)
+}
+
+// Facets calcule les facettes en une agrégation, avec le même filtre que List ou Search
+func (m *Mongo) Facets(ctx context.Context, ingredient string, query Query) (facets.Facets, error) {
+	if ingredient == "" {
+		return m.facets(ctx, Filter(query))
+	}
+	result, err := m.facets(ctx, And(textSearch(ingredient), Filter(query)))
+	if missingTextIndex(err) {
+		return m.facets(ctx, And(ingredientPhrase(ingredient), Filter(query)))
+	}
+	return result, err
+}
+
+// facets exécute l'étape $facet sur les recettes correspondant au filtre
+func (m *Mongo) facets(ctx context.Context, filter bson.M) (facets.Facets, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		facets.Stage(),
+	}
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return facets.Facets{}, err
+	}
+	defer cursor.Close(ctx)
+
+	if !cursor.Next(ctx) {
+		if err := cursor.Err(); err != nil {
+			return facets.Facets{}, err
+		}
+		return facets.Decode(nil)
+	}
+	return facets.Decode(cursor.Current)
+}
+
+// Stats calcule les statistiques du corpus en une agrégation
+func (m *Mongo) Stats(ctx context.Context) (stats.Report, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: Filter(Query{})}},
+		stats.Stage(stats.DefaultTopIngredients),
+	}
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return stats.Report{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var doc bson.Raw
+	if cursor.Next(ctx) {
+		doc = cursor.Current
+	}
+	if err := cursor.Err(); err != nil {
+		return stats.Report{}, err
+	}
+	return stats.Decode(doc, now())
+}
+
+// Upsert remplace la recette de même identifiant ou l'insère
+func (m *Mongo) Upsert(ctx context.Context, recette *models.Recette) error {
+	prepare(recette)
+	_, err := m.collection.ReplaceOne(ctx, bson.M{"_id": recette.ID}, recette, options.Replace().SetUpsert(true))
+	return err
+}
+
+// Delete place la recette dans la corbeille
+func (m *Mongo) Delete(ctx context.Context, id primitive.ObjectID) error {
+	filter := bson.M{"_id": id, "deleted_at": nil}
+	result, err := m.collection.UpdateOne(ctx, filter, bson.M{"$set": bson.M{"deleted_at": now()}})
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Restore sort une recette de la corbeille
+func (m *Mongo) Restore(ctx context.Context, id primitive.ObjectID) (*models.Recette, error) {
+	filter := bson.M{"_id": id, "deleted_at": bson.M{"$ne": nil}}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	var recette models.Recette
+	err := m.collection.FindOneAndUpdate(ctx, filter, bson.M{"$unset": bson.M{"deleted_at": ""}}, opts).Decode(&recette)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &recette, nil
+}
+
+// ListDeleted retourne les recettes de la corbeille, les plus récemment supprimées en premier
+func (m *Mongo) ListDeleted(ctx context.Context) ([]models.Recette, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}, {Key: "_id", Value: 1}})
+	cursor, err := m.collection.Find(ctx, bson.M{"deleted_at": bson.M{"$ne": nil}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	recettes := []models.Recette{}
+	if err := cursor.All(ctx, &recettes); err != nil {
+		return nil, err
+	}
+	return recettes, nil
+}
+
+// Remove supprime définitivement des recettes
+func (m *Mongo) Remove(ctx context.Context, ids []primitive.ObjectID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	_, err := m.collection.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	return err
+}
+
+// Purge supprime définitivement les recettes mises à la corbeille avant la date
+func (m *Mongo) Purge(ctx context.Context, before time.Time) ([]primitive.ObjectID, error) {
+	filter := bson.M{"deleted_at": bson.M{"$ne": nil, "$lt": before}}
+	cursor, err := m.collection.Find(ctx, filter, options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	var expired []models.Recette
+	if err := cursor.All(ctx, &expired); err != nil {
+		return nil, err
+	}
+	if len(expired) == 0 {
+		return nil, nil
+	}
+
+	ids := make([]primitive.ObjectID, 0, len(expired))
+	for _, recette := range expired {
+		ids = append(ids, recette.ID)
+	}
+	// Le filtre est répété : une recette restaurée entre-temps n'est pas supprimée
+	if _, err := m.collection.DeleteMany(ctx, And(filter, bson.M{"_id": bson.M{"$in": ids}})); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// BulkImport rapproche les recettes par page, corbeille comprise
+func (m *Mongo) BulkImport(ctx context.Context, recettes []models.Recette) ([]Imported, error) {
+	return bulkImport(ctx, recettes, m.findByPage, m.Upsert)
+}
+
+// findByPage retourne la recette importée depuis cette page, même dans la corbeille (nil si aucune)
+func (m *Mongo) findByPage(ctx context.Context, page string) (*models.Recette, error) {
+	recette, err := m.findOne(ctx, bson.M{"page": page})
+	if err == ErrNotFound {
+		return nil, nil
+	}
+	return recette, err
+}
+
+func (m *Mongo) findOne(ctx context.Context, filter bson.M, opts ...*options.FindOneOptions) (*models.Recette, error) {
+	var recette models.Recette
+	err := m.collection.FindOne(ctx, filter, opts...).Decode(&recette)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &recette, nil
+}
+
+// find exécute une recherche avec le tri demandé
+// Les recettes sans valeur pour le champ trié (durée ou portions inconnues) sont placées en dernier
+func (m *Mongo) find(ctx context.Context, filter bson.M, query Query) ([]models.Recette, error) {
+	var cursor *mongo.Cursor
+	var err error
+	if field, ok := sortFields[query.Sort]; !ok {
+		cursor, err = m.collection.Find(ctx, filter)
+	} else {
+		direction := 1
+		if query.Desc {
+			direction = -1
+		}
+		pipeline := mongo.Pipeline{
+			{{Key: "$match", Value: filter}},
+			{{Key: "$addFields", Value: bson.M{"_missing": bson.M{"$cond": bson.A{bson.M{"$gt": bson.A{"$" + field.field, 0}}, 0, 1}}}}},
+			{{Key: "$sort", Value: bson.D{{Key: "_missing", Value: 1}, {Key: field.field, Value: direction}, {Key: "_id", Value: 1}}}},
+			{{Key: "$project", Value: bson.M{"_missing": 0}}},
+		}
+		cursor, err = m.collection.Aggregate(ctx, pipeline)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	recettes := []models.Recette{}
+	if err := cursor.All(ctx, &recettes); err != nil {
+		return nil, err
+	}
+	return recettes, nil
+}
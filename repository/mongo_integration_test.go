@@ -0,0 +1,48 @@
+//go:build integration
+
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/indexes"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// testDatabase base MongoDB jetable sur le serveur de MONGODB_URI, supprimée en fin de test
+func testDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	uri := os.Getenv("MONGODB_URI")
+	if uri == "" {
+		t.Skip("MONGODB_URI n'est pas défini")
+	}
+	ctx := context.Background()
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(uri))
+	require.NoError(t, err)
+	db := client.Database("api_golang_test_" + primitive.NewObjectID().Hex())
+	t.Cleanup(func() {
+		db.Drop(ctx)
+		client.Disconnect(ctx)
+	})
+	return db
+}
+
+func TestMongoSearchContract(t *testing.T) {
+	t.Run("index texte", func(t *testing.T) {
+		db := testDatabase(t)
+		_, err := indexes.Sync(context.Background(), db)
+		require.NoError(t, err)
+		searchContract(t, NewMongo(db.Collection("recettes")))
+	})
+
+	// Sans index texte, la recherche se replie sur l'expression telle quelle
+	t.Run("sans index texte", func(t *testing.T) {
+		db := testDatabase(t)
+		searchContract(t, NewMongo(db.Collection("recettes")))
+	})
+}
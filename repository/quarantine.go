@@ -0,0 +1,323 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RuleCount nombre d'éléments en quarantaine ne respectant pas une règle
+type RuleCount struct {
+	Rule  string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// QuarantineRepository recettes écartées à l'importation, en attente de correction
+type QuarantineRepository interface {
+	// Put place une recette en quarantaine ; un élément existant pour la même page est remplacé
+	// en conservant sa date de mise en quarantaine
+	Put(ctx context.Context, item models.QuarantinedRecette) error
+	// Release retire de la quarantaine les recettes de ces pages
+	Release(ctx context.Context, pages []string) error
+	// Get retourne un élément en quarantaine (ErrNotFound si absent)
+	Get(ctx context.Context, id primitive.ObjectID) (*models.QuarantinedRecette, error)
+	// Replace remplace un élément en quarantaine (ErrNotFound si absent)
+	Replace(ctx context.Context, item models.QuarantinedRecette) error
+	// Delete abandonne un élément en quarantaine (ErrNotFound si absent)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	// Count nombre d'éléments ne respectant pas la règle (tous si elle est vide)
+	Count(ctx context.Context, rule string) (int64, error)
+	// CountByRule nombre d'éléments par règle non respectée, les plus fréquentes en premier
+	// Un élément ne respectant pas plusieurs fois la même règle n'est compté qu'une fois
+	CountByRule(ctx context.Context) ([]RuleCount, error)
+	// List retourne les éléments ne respectant pas la règle (tous si elle est vide), les plus récents en premier
+	List(ctx context.Context, rule string, limit int) ([]models.QuarantinedRecette, error)
+}
+
+// MongoQuarantine quarantaine dans une collection MongoDB
+type MongoQuarantine struct {
+	collection *mongo.Collection
+}
+
+// NewMongoQuarantine crée une quarantaine sur la collection fournie
+func NewMongoQuarantine(collection *mongo.Collection) *MongoQuarantine {
+	return &MongoQuarantine{collection: collection}
+}
+
+// Put place une recette en quarantaine, par page si elle en a une
+func (m *MongoQuarantine) Put(ctx context.Context, item models.QuarantinedRecette) error {
+	if item.Recette.Page == "" {
+		_, err := m.collection.InsertOne(ctx, item)
+		return err
+	}
+
+	update := bson.M{
+		"$set": bson.M{
+			"recette":    item.Recette,
+			"issues":     item.Issues,
+			"run_id":     item.RunID,
+			"updated_at": item.UpdatedAt,
+		},
+		"$setOnInsert": bson.M{"created_at": item.CreatedAt},
+	}
+	_, err := m.collection.UpdateOne(ctx, bson.M{"recette.page": item.Recette.Page}, update, options.Update().SetUpsert(true))
+	return err
+}
+
+// Release retire de la quarantaine les recettes de ces pages
+func (m *MongoQuarantine) Release(ctx context.Context, pages []string) error {
+	if len(pages) == 0 {
+		return nil
+	}
+	_, err := m.collection.DeleteMany(ctx, bson.M{"recette.page": bson.M{"$in": pages}})
+	return err
+}
+
+// Get retourne un élément en quarantaine
+func (m *MongoQuarantine) Get(ctx context.Context, id primitive.ObjectID) (*models.QuarantinedRecette, error) {
+	var item models.QuarantinedRecette
+	err := m.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&item)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// Replace remplace un élément en quarantaine
+func (m *MongoQuarantine) Replace(ctx context.Context, item models.QuarantinedRecette) error {
+	result, err := m.collection.ReplaceOne(ctx, bson.M{"_id": item.ID}, item)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// Delete abandonne un élément en quarantaine
+func (m *MongoQuarantine) Delete(ctx context.Context, id primitive.ObjectID) error {
+	result, err := m.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+	if result.DeletedCount == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// ruleFilter filtre des éléments ne respectant pas la règle
+func ruleFilter(rule string) bson.M {
+	if rule == "" {
+		return bson.M{}
+	}
+	return bson.M{"issues.rule": rule}
+}
+
+// Count nombre d'éléments ne respectant pas la règle
+func (m *MongoQuarantine) Count(ctx context.Context, rule string) (int64, error) {
+	return m.collection.CountDocuments(ctx, ruleFilter(rule))
+}
+
+// CountByRule nombre d'éléments par règle non respectée
+func (m *MongoQuarantine) CountByRule(ctx context.Context) ([]RuleCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$unwind", Value: "$issues"}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"item": "$_id", "rule": "$issues.rule"}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$_id.rule", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+	cursor, err := m.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	counts := []RuleCount{}
+	if err := cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// List retourne les éléments ne respectant pas la règle, les plus récents en premier
+func (m *MongoQuarantine) List(ctx context.Context, rule string, limit int) ([]models.QuarantinedRecette, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "updated_at", Value: -1}, {Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := m.collection.Find(ctx, ruleFilter(rule), opts)
+	if err != nil {
+		return nil, err
+	}
+	items := []models.QuarantinedRecette{}
+	if err := cursor.All(ctx, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// MemoryQuarantine quarantaine en mémoire
+type MemoryQuarantine struct {
+	mu    sync.RWMutex
+	items map[primitive.ObjectID]models.QuarantinedRecette
+}
+
+// NewMemoryQuarantine crée une quarantaine en mémoire vide
+func NewMemoryQuarantine() *MemoryQuarantine {
+	return &MemoryQuarantine{items: map[primitive.ObjectID]models.QuarantinedRecette{}}
+}
+
+// Put place une copie de la recette en quarantaine, par page si elle en a une
+func (m *MemoryQuarantine) Put(ctx context.Context, item models.QuarantinedRecette) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	item.ID = primitive.NewObjectID()
+	if item.Recette.Page != "" {
+		for id, existing := range m.items {
+			if existing.Recette.Page == item.Recette.Page {
+				item.ID, item.CreatedAt = id, existing.CreatedAt
+				break
+			}
+		}
+	}
+	item.Recette = clone(item.Recette)
+	m.items[item.ID] = item
+	return nil
+}
+
+// Release retire de la quarantaine les recettes de ces pages
+func (m *MemoryQuarantine) Release(ctx context.Context, pages []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, item := range m.items {
+		if contains(pages, item.Recette.Page) {
+			delete(m.items, id)
+		}
+	}
+	return nil
+}
+
+// Get retourne une copie d'un élément en quarantaine
+func (m *MemoryQuarantine) Get(ctx context.Context, id primitive.ObjectID) (*models.QuarantinedRecette, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	item, ok := m.items[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	item.Recette = clone(item.Recette)
+	return &item, nil
+}
+
+// Replace remplace un élément en quarantaine
+func (m *MemoryQuarantine) Replace(ctx context.Context, item models.QuarantinedRecette) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.items[item.ID]; !ok {
+		return ErrNotFound
+	}
+	item.Recette = clone(item.Recette)
+	m.items[item.ID] = item
+	return nil
+}
+
+// Delete abandonne un élément en quarantaine
+func (m *MemoryQuarantine) Delete(ctx context.Context, id primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.items[id]; !ok {
+		return ErrNotFound
+	}
+	delete(m.items, id)
+	return nil
+}
+
+// Count nombre d'éléments ne respectant pas la règle
+func (m *MemoryQuarantine) Count(ctx context.Context, rule string) (int64, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var count int64
+	for _, item := range m.items {
+		if breaks(item, rule) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// CountByRule nombre d'éléments par règle non respectée
+func (m *MemoryQuarantine) CountByRule(ctx context.Context) ([]RuleCount, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	byRule := map[string]int{}
+	for _, item := range m.items {
+		seen := map[string]bool{}
+		for _, issue := range item.Issues {
+			if !seen[issue.Rule] {
+				seen[issue.Rule] = true
+				byRule[issue.Rule]++
+			}
+		}
+	}
+	counts := make([]RuleCount, 0, len(byRule))
+	for rule, count := range byRule {
+		counts = append(counts, RuleCount{Rule: rule, Count: count})
+	}
+	sort.Slice(counts, func(i, j int) bool {
+		if counts[i].Count != counts[j].Count {
+			return counts[i].Count > counts[j].Count
+		}
+		return counts[i].Rule < counts[j].Rule
+	})
+	return counts, nil
+}
+
+// List retourne les éléments ne respectant pas la règle, les plus récents en premier
+func (m *MemoryQuarantine) List(ctx context.Context, rule string, limit int) ([]models.QuarantinedRecette, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	items := []models.QuarantinedRecette{}
+	for _, item := range m.items {
+		if breaks(item, rule) {
+			item.Recette = clone(item.Recette)
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].UpdatedAt.Equal(items[j].UpdatedAt) {
+			return items[i].UpdatedAt.After(items[j].UpdatedAt)
+		}
+		return items[i].ID.Hex() > items[j].ID.Hex()
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// breaks indique si l'élément ne respecte pas la règle (toujours vrai si elle est vide)
+func breaks(item models.QuarantinedRecette, rule string) bool {
+	if rule == "" {
+		return true
+	}
+	for _, issue := range item.Issues {
+		if issue.Rule == rule {
+			return true
+		}
+	}
+	return false
+}
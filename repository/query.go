@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Query critères communs aux listes et recherches de recettes (valeurs vides : pas de critère)
+type Query struct {
+	Diets            []string // compatibles avec tous ces régimes
+	ExcludeAllergens []string // sans aucun de ces allergènes
+	Categories       []string // de l'une de ces catégories
+	MaxTotalMinutes  int      // durée connue inférieure ou égale
+	MaxPrepMinutes   int
+	MaxCookMinutes   int
+	Sort             string // tri : name, total_time, prep_time, cook_time ou servings
+	Desc             bool   // tri décroissant
+
+	// Critères des opérations en masse
+	Ingredient          string // une ligne d'ingrédient contient ce texte, sans tenir compte de la casse
+	MissingInstructions bool   // sans aucune étape
+	ImportRun           string // écrites par cette importation
+}
+
+// sortField champ triable : nom dans MongoDB et valeur d'une recette
+// Un nombre nul est une valeur inconnue : la recette est placée en dernier quel que soit l'ordre
+type sortField struct {
+	field string
+	value func(r *models.Recette) interface{}
+}
+
+var sortFields = map[string]sortField{
+	"name":       {field: "name", value: func(r *models.Recette) interface{} { return r.Name }},
+	"total_time": {field: "total_minutes", value: func(r *models.Recette) interface{} { return r.TotalMinutes }},
+	"prep_time":  {field: "prep_minutes", value: func(r *models.Recette) interface{} { return r.PrepMinutes }},
+	"cook_time":  {field: "cook_minutes", value: func(r *models.Recette) interface{} { return r.CookMinutes }},
+	"servings":   {field: "servings", value: func(r *models.Recette) interface{} { return r.Servings }},
+}
+
+// SortNames tris disponibles, par ordre alphabétique
+func SortNames() []string {
+	names := make([]string, 0, len(sortFields))
+	for name := range sortFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidSort indique si le tri est disponible
+func ValidSort(name string) bool {
+	_, ok := sortFields[name]
+	return ok
+}
+
+// Filter filtre MongoDB des critères, recettes de la corbeille exclues
+func Filter(query Query) bson.M {
+	conditions := []bson.M{{"deleted_at": nil}}
+	for _, name := range query.Diets {
+		conditions = append(conditions, bson.M{"diets": bson.M{"$elemMatch": bson.M{"name": name, "compatible": true}}})
+	}
+	if len(query.ExcludeAllergens) > 0 {
+		conditions = append(conditions, bson.M{"allergens": bson.M{"$nin": query.ExcludeAllergens}})
+	}
+	if len(query.Categories) > 0 {
+		conditions = append(conditions, bson.M{"category": bson.M{"$in": query.Categories}})
+	}
+	for _, limit := range []struct {
+		field string
+		max   int
+	}{
+		{field: "total_minutes", max: query.MaxTotalMinutes},
+		{field: "prep_minutes", max: query.MaxPrepMinutes},
+		{field: "cook_minutes", max: query.MaxCookMinutes},
+	} {
+		if limit.max > 0 {
+			conditions = append(conditions, bson.M{limit.field: bson.M{"$gt": 0, "$lte": limit.max}})
+		}
+	}
+	if ingredient := strings.TrimSpace(query.Ingredient); ingredient != "" {
+		conditions = append(conditions, ingredientPhrase(ingredient))
+	}
+	if query.MissingInstructions {
+		conditions = append(conditions, bson.M{"$or": bson.A{
+			bson.M{"instructions": nil},
+			bson.M{"instructions": bson.M{"$size": 0}},
+		}})
+	}
+	if query.ImportRun != "" {
+		conditions = append(conditions, bson.M{"import_run": query.ImportRun})
+	}
+	return And(conditions...)
+}
+
+// And combine des filtres MongoDB (les filtres vides sont ignorés)
+func And(filters ...bson.M) bson.M {
+	var conditions []bson.M
+	for _, filter := range filters {
+		if len(filter) > 0 {
+			conditions = append(conditions, filter)
+		}
+	}
+
+	switch len(conditions) {
+	case 0:
+		return bson.M{}
+	case 1:
+		return conditions[0]
+	default:
+		return bson.M{"$and": conditions}
+	}
+}
+
+// Match indique si une recette hors corbeille correspond aux critères (équivalent de Filter en mémoire)
+func (q Query) Match(r *models.Recette) bool {
+	if r.DeletedAt != nil {
+		return false
+	}
+	for _, name := range q.Diets {
+		compatible := false
+		for _, tag := range r.Diets {
+			if tag.Name == name && tag.Compatible {
+				compatible = true
+				break
+			}
+		}
+		if !compatible {
+			return false
+		}
+	}
+	for _, excluded := range q.ExcludeAllergens {
+		for _, allergen := range r.Allergens {
+			if allergen == excluded {
+				return false
+			}
+		}
+	}
+	if len(q.Categories) > 0 && !contains(q.Categories, r.Category) {
+		return false
+	}
+	if ingredient := normalizeText(q.Ingredient); ingredient != "" && !containsIngredient(r, ingredient) {
+		return false
+	}
+	if q.MissingInstructions && len(r.Instructions) > 0 {
+		return false
+	}
+	if q.ImportRun != "" && r.ImportRun != q.ImportRun {
+		return false
+	}
+	return withinMinutes(r.TotalMinutes, q.MaxTotalMinutes) &&
+		withinMinutes(r.PrepMinutes, q.MaxPrepMinutes) &&
+		withinMinutes(r.CookMinutes, q.MaxCookMinutes)
+}
+
+// containsIngredient indique si une ligne d'ingrédient contient l'expression normalisée (normalizeText)
+func containsIngredient(r *models.Recette, phrase string) bool {
+	for _, i := range r.Ingredients {
+		if strings.Contains(normalizeText(i.Quantity), phrase) || strings.Contains(normalizeText(i.Unit), phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+// withinMinutes durée connue inférieure ou égale au maximum (0 : pas de maximum)
+func withinMinutes(minutes, max int) bool {
+	return max <= 0 || (minutes > 0 && minutes <= max)
+}
+
+// sortRecettes trie les recettes comme le pipeline MongoDB : valeurs inconnues en dernier,
+// puis champ trié, puis identifiant ; sans tri demandé, ordre d'insertion conservé
+func sortRecettes(recettes []models.Recette, query Query) {
+	field, ok := sortFields[query.Sort]
+	if !ok {
+		return
+	}
+	sort.SliceStable(recettes, func(i, j int) bool {
+		a, b := field.value(&recettes[i]), field.value(&recettes[j])
+		if missingA, missingB := missing(a), missing(b); missingA != missingB {
+			return missingB
+		}
+		if cmp := compare(a, b); cmp != 0 {
+			return (cmp < 0) != query.Desc
+		}
+		return recettes[i].ID.Hex() < recettes[j].ID.Hex()
+	})
+}
+
+// missing valeur de tri inconnue (comme $gt: 0 dans MongoDB, une chaîne n'est jamais inconnue)
+func missing(value interface{}) bool {
+	if v, ok := value.(int); ok {
+		return v <= 0
+	}
+	return false
+}
+
+// compare compare deux valeurs de tri de même type
+func compare(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		b := b.(string)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case int:
+		return a - b.(int)
+	}
+	return 0
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
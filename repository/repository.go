@@ -0,0 +1,138 @@
+// Package repository définit l'accès au stockage des recettes, indépendamment de MongoDB
+// Les handlers reçoivent un RecipeRepository : MongoDB en production, mémoire pour le développement et les tests
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/facets"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/revisions"
+	"github.com/maxime-louis14/api-golang/stats"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ErrNotFound recette absente ou dans la corbeille, révision, alias ou élément en quarantaine absent
+var ErrNotFound = errors.New("introuvable")
+
+// Résultat de l'importation d'une recette
+const (
+	ImportInserted  = "inserted"  // nouvelle page
+	ImportUpdated   = "updated"   // page déjà importée, contenu modifié
+	ImportUnchanged = "unchanged" // page déjà importée, contenu identique : rien n'est écrit
+)
+
+// Imported recette traitée par BulkImport
+type Imported struct {
+	Previous *models.Recette // version enregistrée avant l'importation (nil si nouvelle)
+	Recette  models.Recette  // version enregistrée après l'importation
+	Status   string
+}
+
+// RecipeRepository stockage des recettes
+// Les lectures ignorent les recettes placées dans la corbeille
+type RecipeRepository interface {
+	// Get retourne une recette par son identifiant (ErrNotFound si absente)
+	Get(ctx context.Context, id primitive.ObjectID) (*models.Recette, error)
+	// FindByName retourne la première recette de ce nom, sans tenir compte de la casse
+	FindByName(ctx context.Context, name string, query Query) (*models.Recette, error)
+	// Search retourne les recettes dont un ingrédient contient l'expression, mots dans l'ordre et sans tenir
+	// compte de la casse (contrat vérifié par searchContract sur chaque stockage)
+	// Au-delà de ce contrat, les stockages diffèrent : MongoDB (index texte) ne retient que les mots entiers,
+	// ramenés à leur racine ; le stockage en mémoire accepte une partie de mot ("pom" pour "pommes")
+	Search(ctx context.Context, ingredient string, query Query) ([]models.Recette, error)
+	// List retourne les recettes correspondant aux critères
+	List(ctx context.Context, query Query) ([]models.Recette, error)
+	// Facets calcule les facettes des recettes de List (ingrédient vide) ou de Search
+	Facets(ctx context.Context, ingredient string, query Query) (facets.Facets, error)
+	// Stats calcule les statistiques du corpus, recettes de la corbeille exclues
+	Stats(ctx context.Context) (stats.Report, error)
+	// Upsert enregistre une recette : insertion si elle n'a pas d'identifiant ou n'existe pas, remplacement sinon
+	Upsert(ctx context.Context, recette *models.Recette) error
+	// Delete place une recette dans la corbeille (ErrNotFound si absente ou déjà supprimée)
+	Delete(ctx context.Context, id primitive.ObjectID) error
+	// BulkImport enregistre un lot de recettes importées, rapprochées des recettes existantes par leur page
+	BulkImport(ctx context.Context, recettes []models.Recette) ([]Imported, error)
+
+	// Restore sort une recette de la corbeille (ErrNotFound si elle n'y est pas)
+	Restore(ctx context.Context, id primitive.ObjectID) (*models.Recette, error)
+	// ListDeleted retourne les recettes de la corbeille, les plus récemment supprimées en premier
+	ListDeleted(ctx context.Context) ([]models.Recette, error)
+	// Remove supprime définitivement des recettes, corbeille comprise (recettes fusionnées dans une autre)
+	Remove(ctx context.Context, ids []primitive.ObjectID) error
+	// Purge supprime définitivement les recettes mises à la corbeille avant la date et retourne leurs identifiants
+	Purge(ctx context.Context, before time.Time) ([]primitive.ObjectID, error)
+}
+
+// KeepExistingState reporte sur une recette réimportée l'état propre à la version enregistrée :
+// une recette mise à la corbeille y reste, ses étiquettes, ses alias et son image copiée sont conservés
+func KeepExistingState(previous *models.Recette, recette *models.Recette) {
+	if previous == nil {
+		return
+	}
+	recette.DeletedAt = previous.DeletedAt
+	recette.Tags = previous.Tags
+	recette.Aliases = previous.Aliases
+	if previous.Image == recette.Image {
+		recette.ImageKey = previous.ImageKey
+	}
+}
+
+// prepare complète une recette avant son enregistrement : identifiant, version du schéma et date de création
+// Une recette enregistrée avant l'ajout de created_at reçoit la date de son identifiant
+func prepare(recette *models.Recette) {
+	if recette.ID.IsZero() {
+		recette.ID = primitive.NewObjectID()
+	}
+	recette.SchemaVersion = domain.SchemaVersion
+	if recette.CreatedAt == nil {
+		createdAt := recette.ID.Timestamp().UTC()
+		if current := now(); createdAt.After(current) {
+			createdAt = current
+		}
+		recette.CreatedAt = &createdAt
+	}
+}
+
+// now date courante en UTC, comme les dates enregistrées par les contrôleurs
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// bulkImport rapproche chaque recette de la version enregistrée pour la même page puis l'enregistre
+// si elle est nouvelle ou modifiée ; partagé par les implémentations
+func bulkImport(ctx context.Context, recettes []models.Recette,
+	findByPage func(ctx context.Context, page string) (*models.Recette, error),
+	upsert func(ctx context.Context, recette *models.Recette) error,
+) ([]Imported, error) {
+	result := make([]Imported, 0, len(recettes))
+	for _, recette := range recettes {
+		var previous *models.Recette
+		if recette.Page != "" {
+			var err error
+			if previous, err = findByPage(ctx, recette.Page); err != nil {
+				return result, err
+			}
+		}
+
+		status := ImportInserted
+		if previous != nil {
+			KeepExistingState(previous, &recette)
+			if revisions.Compare(*previous, recette).Empty() {
+				result = append(result, Imported{Previous: previous, Recette: *previous, Status: ImportUnchanged})
+				continue
+			}
+			recette.ID = previous.ID
+			recette.CreatedAt = previous.CreatedAt
+			status = ImportUpdated
+		}
+		if err := upsert(ctx, &recette); err != nil {
+			return result, err
+		}
+		result = append(result, Imported{Previous: previous, Recette: recette, Status: status})
+	}
+	return result, nil
+}
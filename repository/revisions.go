@@ -0,0 +1,172 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// RevisionRepository historique des révisions des recettes
+type RevisionRepository interface {
+	// LastNumber retourne le numéro de la dernière révision de la recette (0 si aucune)
+	LastNumber(ctx context.Context, recetteID primitive.ObjectID) (int, error)
+	// Add ajoute une révision à l'historique
+	Add(ctx context.Context, revision models.RecetteRevision) error
+	// List retourne les révisions d'une recette par numéro croissant, sans leur contenu
+	List(ctx context.Context, recetteID primitive.ObjectID) ([]models.RecetteRevision, error)
+	// Get retourne une révision complète (ErrNotFound si absente)
+	Get(ctx context.Context, recetteID primitive.ObjectID, number int) (*models.RecetteRevision, error)
+	// DeleteFor supprime l'historique de ces recettes
+	DeleteFor(ctx context.Context, recetteIDs []primitive.ObjectID) error
+}
+
+// MongoRevisions historique des révisions dans une collection MongoDB
+type MongoRevisions struct {
+	collection *mongo.Collection
+}
+
+// NewMongoRevisions crée un historique sur la collection des révisions
+func NewMongoRevisions(collection *mongo.Collection) *MongoRevisions {
+	return &MongoRevisions{collection: collection}
+}
+
+// LastNumber retourne le numéro de la dernière révision de la recette (0 si aucune)
+func (m *MongoRevisions) LastNumber(ctx context.Context, recetteID primitive.ObjectID) (int, error) {
+	opts := options.FindOne().SetSort(bson.M{"number": -1}).SetProjection(bson.M{"number": 1})
+	var last models.RecetteRevision
+	err := m.collection.FindOne(ctx, bson.M{"recette_id": recetteID}, opts).Decode(&last)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	return last.Number, err
+}
+
+// Add ajoute une révision à l'historique
+func (m *MongoRevisions) Add(ctx context.Context, revision models.RecetteRevision) error {
+	_, err := m.collection.InsertOne(ctx, revision)
+	return err
+}
+
+// List retourne les révisions d'une recette par numéro croissant, sans leur contenu
+func (m *MongoRevisions) List(ctx context.Context, recetteID primitive.ObjectID) ([]models.RecetteRevision, error) {
+	opts := options.Find().SetSort(bson.M{"number": 1}).SetProjection(bson.M{"recette": 0})
+	cursor, err := m.collection.Find(ctx, bson.M{"recette_id": recetteID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	revisions := []models.RecetteRevision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// Get retourne une révision complète
+func (m *MongoRevisions) Get(ctx context.Context, recetteID primitive.ObjectID, number int) (*models.RecetteRevision, error) {
+	var revision models.RecetteRevision
+	err := m.collection.FindOne(ctx, bson.M{"recette_id": recetteID, "number": number}).Decode(&revision)
+	if err == mongo.ErrNoDocuments {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// DeleteFor supprime l'historique de ces recettes
+func (m *MongoRevisions) DeleteFor(ctx context.Context, recetteIDs []primitive.ObjectID) error {
+	if len(recetteIDs) == 0 {
+		return nil
+	}
+	_, err := m.collection.DeleteMany(ctx, bson.M{"recette_id": bson.M{"$in": recetteIDs}})
+	return err
+}
+
+// MemoryRevisions historique des révisions en mémoire
+type MemoryRevisions struct {
+	mu        sync.RWMutex
+	revisions map[primitive.ObjectID][]models.RecetteRevision
+}
+
+// NewMemoryRevisions crée un historique en mémoire vide
+func NewMemoryRevisions() *MemoryRevisions {
+	return &MemoryRevisions{revisions: map[primitive.ObjectID][]models.RecetteRevision{}}
+}
+
+// LastNumber retourne le numéro de la dernière révision de la recette (0 si aucune)
+func (m *MemoryRevisions) LastNumber(ctx context.Context, recetteID primitive.ObjectID) (int, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	last := 0
+	for _, revision := range m.revisions[recetteID] {
+		last = max(last, revision.Number)
+	}
+	return last, nil
+}
+
+// Add ajoute une copie de la révision à l'historique
+func (m *MemoryRevisions) Add(ctx context.Context, revision models.RecetteRevision) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if revision.ID.IsZero() {
+		revision.ID = primitive.NewObjectID()
+	}
+	if revision.Recette != nil {
+		recette := clone(*revision.Recette)
+		revision.Recette = &recette
+	}
+	m.revisions[revision.RecetteID] = append(m.revisions[revision.RecetteID], revision)
+	return nil
+}
+
+// List retourne les révisions d'une recette par numéro croissant, sans leur contenu
+func (m *MemoryRevisions) List(ctx context.Context, recetteID primitive.ObjectID) ([]models.RecetteRevision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	revisions := []models.RecetteRevision{}
+	for _, revision := range m.revisions[recetteID] {
+		revision.Recette = nil
+		revisions = append(revisions, revision)
+	}
+	sort.SliceStable(revisions, func(i, j int) bool { return revisions[i].Number < revisions[j].Number })
+	return revisions, nil
+}
+
+// Get retourne une copie d'une révision complète
+func (m *MemoryRevisions) Get(ctx context.Context, recetteID primitive.ObjectID, number int) (*models.RecetteRevision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, revision := range m.revisions[recetteID] {
+		if revision.Number != number {
+			continue
+		}
+		if revision.Recette != nil {
+			recette := clone(*revision.Recette)
+			revision.Recette = &recette
+		}
+		return &revision, nil
+	}
+	return nil, ErrNotFound
+}
+
+// DeleteFor supprime l'historique de ces recettes
+func (m *MemoryRevisions) DeleteFor(ctx context.Context, recetteIDs []primitive.ObjectID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, id := range recetteIDs {
+		delete(m.revisions, id)
+	}
+	return nil
+}
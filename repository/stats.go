@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/maxime-louis14/api-golang/stats"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// StatsRepository instantanés des statistiques du corpus (un par recalcul), pour suivre son évolution
+type StatsRepository interface {
+	// Save archive un instantané
+	Save(ctx context.Context, report stats.Report) error
+	// History retourne les derniers instantanés, les plus récents en premier
+	History(ctx context.Context, limit int) ([]stats.Report, error)
+}
+
+// MongoStats instantanés dans une collection MongoDB
+type MongoStats struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStats crée un historique des statistiques sur la collection fournie
+func NewMongoStats(collection *mongo.Collection) *MongoStats {
+	return &MongoStats{collection: collection}
+}
+
+// Save archive un instantané
+func (m *MongoStats) Save(ctx context.Context, report stats.Report) error {
+	_, err := m.collection.InsertOne(ctx, report)
+	return err
+}
+
+// History retourne les derniers instantanés, les plus récents en premier
+func (m *MongoStats) History(ctx context.Context, limit int) ([]stats.Report, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "generated_at", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := m.collection.Find(ctx, bson.M{}, opts)
+	if err != nil {
+		return nil, err
+	}
+	history := []stats.Report{}
+	if err := cursor.All(ctx, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// MemoryStats instantanés en mémoire
+type MemoryStats struct {
+	mu      sync.RWMutex
+	reports []stats.Report
+}
+
+// NewMemoryStats crée un historique des statistiques en mémoire vide
+func NewMemoryStats() *MemoryStats {
+	return &MemoryStats{}
+}
+
+// Save archive un instantané
+func (m *MemoryStats) Save(ctx context.Context, report stats.Report) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.reports = append(m.reports, report)
+	return nil
+}
+
+// History retourne les derniers instantanés, les plus récents en premier
+func (m *MemoryStats) History(ctx context.Context, limit int) ([]stats.Report, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history := append([]stats.Report{}, m.reports...)
+	sort.SliceStable(history, func(i, j int) bool { return history[i].GeneratedAt.After(history[j].GeneratedAt) })
+	if len(history) > limit {
+		history = history[:limit]
+	}
+	return history, nil
+}
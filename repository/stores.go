@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"os"
+
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/images"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Stockages des recettes disponibles (STORAGE)
+const (
+	StorageMongo  = "mongo"  // collections de MongoDB (par défaut)
+	StorageMemory = "memory" // en mémoire, pour le développement local : perdu à l'arrêt
+)
+
+// Storage stockage des recettes choisi par STORAGE
+func Storage() string {
+	if storage := os.Getenv("STORAGE"); storage != "" {
+		return storage
+	}
+	return StorageMongo
+}
+
+// Stores stockages injectés dans les handlers des recettes
+type Stores struct {
+	Recettes   RecipeRepository
+	Revisions  RevisionRepository
+	Aliases    AliasRepository
+	Quarantine QuarantineRepository
+	Stats      StatsRepository
+
+	// Images stockage des images copiées, ouvert à part (IMAGE_STORE_BACKEND) ; nil si indisponible
+	Images images.Store
+	// Events bus des événements publiés par les handlers des recettes et transmis aux webhooks
+	Events *events.Bus
+}
+
+// NewMongoStores crée les stockages sur les collections de la base MongoDB (sans stockage d'images ni bus d'événements)
+func NewMongoStores(db *mongo.Database) Stores {
+	return Stores{
+		Recettes:   NewMongo(db.Collection("recettes")),
+		Revisions:  NewMongoRevisions(db.Collection("recette_revisions")),
+		Aliases:    NewMongoAliases(db.Collection("recette_aliases")),
+		Quarantine: NewMongoQuarantine(db.Collection("recettes_quarantine")),
+		Stats:      NewMongoStats(db.Collection("recette_stats")),
+	}
+}
+
+// NewMemoryStores crée des stockages en mémoire vides (sans stockage d'images ni bus d'événements)
+func NewMemoryStores() Stores {
+	return Stores{
+		Recettes:   NewMemory(),
+		Revisions:  NewMemoryRevisions(),
+		Aliases:    NewMemoryAliases(),
+		Quarantine: NewMemoryQuarantine(),
+		Stats:      NewMemoryStats(),
+	}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/stats"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Les stockages en mémoire doivent rester interchangeables avec MongoDB
+var (
+	_ RevisionRepository   = (*MemoryRevisions)(nil)
+	_ RevisionRepository   = (*MongoRevisions)(nil)
+	_ AliasRepository      = (*MemoryAliases)(nil)
+	_ AliasRepository      = (*MongoAliases)(nil)
+	_ QuarantineRepository = (*MemoryQuarantine)(nil)
+	_ QuarantineRepository = (*MongoQuarantine)(nil)
+	_ StatsRepository      = (*MemoryStats)(nil)
+	_ StatsRepository      = (*MongoStats)(nil)
+)
+
+func TestMemoryRevisions(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryRevisions()
+	recetteID := primitive.NewObjectID()
+
+	last, err := store.LastNumber(ctx, recetteID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, last)
+
+	recette := testRecette("Soupe", "", 0, "2 carottes")
+	require.NoError(t, store.Add(ctx, models.RecetteRevision{RecetteID: recetteID, Number: 2, Recette: &recette}))
+	require.NoError(t, store.Add(ctx, models.RecetteRevision{RecetteID: recetteID, Number: 1, Recette: &recette}))
+	recette.Name = "modifiée après l'ajout"
+
+	last, err = store.LastNumber(ctx, recetteID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, last)
+
+	// Liste par numéro croissant, sans le contenu
+	list, err := store.List(ctx, recetteID)
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, 1, list[0].Number)
+	assert.Nil(t, list[0].Recette)
+
+	revision, err := store.Get(ctx, recetteID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Soupe", revision.Recette.Name)
+	_, err = store.Get(ctx, recetteID, 3)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.DeleteFor(ctx, []primitive.ObjectID{recetteID}))
+	list, err = store.List(ctx, recetteID)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestMemoryAliases(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryAliases()
+	first, second, canonical := primitive.NewObjectID(), primitive.NewObjectID(), primitive.NewObjectID()
+
+	_, err := store.Get(ctx, first)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Add(ctx, []models.RecetteAlias{
+		{ID: first, RecetteID: second, Page: "https://example.com/soupe"},
+		{ID: primitive.NewObjectID(), RecetteID: second, Page: "https://example.com/soupe"},
+	}))
+	pages, err := store.Pages(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/soupe"}, pages)
+
+	// La recette fusionnée l'est à son tour : ses alias suivent la nouvelle recette canonique
+	require.NoError(t, store.Reassign(ctx, []primitive.ObjectID{second}, canonical))
+	alias, err := store.Get(ctx, first)
+	require.NoError(t, err)
+	assert.Equal(t, canonical, alias.RecetteID)
+}
+
+func TestMemoryQuarantine(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryQuarantine()
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	put := func(page string, updatedAt time.Time, rules ...string) {
+		item := models.QuarantinedRecette{Recette: testRecette("Soupe", page, 0), CreatedAt: updatedAt, UpdatedAt: updatedAt}
+		for _, rule := range rules {
+			item.Issues = append(item.Issues, models.QualityIssue{Rule: rule})
+		}
+		require.NoError(t, store.Put(ctx, item))
+	}
+	put("https://example.com/soupe", created, "required")
+	put("", created.Add(time.Hour), "required", "required", "min_ingredients")
+	// Même page : l'élément est remplacé en conservant sa date de mise en quarantaine
+	put("https://example.com/soupe", created.Add(2*time.Hour), "valid_url")
+
+	total, err := store.Count(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	required, err := store.Count(ctx, "required")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), required)
+
+	counts, err := store.CountByRule(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []RuleCount{{Rule: "min_ingredients", Count: 1}, {Rule: "required", Count: 1}, {Rule: "valid_url", Count: 1}}, counts)
+
+	items, err := store.List(ctx, "", 10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, "https://example.com/soupe", items[0].Recette.Page)
+	assert.Equal(t, created, items[0].CreatedAt)
+
+	items[0].Issues = nil
+	require.NoError(t, store.Replace(ctx, items[0]))
+	item, err := store.Get(ctx, items[0].ID)
+	require.NoError(t, err)
+	assert.Empty(t, item.Issues)
+
+	require.NoError(t, store.Release(ctx, []string{"https://example.com/soupe"}))
+	_, err = store.Get(ctx, items[0].ID)
+	assert.ErrorIs(t, err, ErrNotFound)
+	require.NoError(t, store.Delete(ctx, items[1].ID))
+	assert.ErrorIs(t, store.Delete(ctx, items[1].ID), ErrNotFound)
+	assert.ErrorIs(t, store.Replace(ctx, items[1]), ErrNotFound)
+}
+
+func TestMemoryStats(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStats()
+	first := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, store.Save(ctx, stats.Report{GeneratedAt: first.Add(time.Duration(i) * time.Hour), TotalRecipes: i}))
+	}
+	history, err := store.History(ctx, 2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 2, history[0].TotalRecipes)
+	assert.Equal(t, 1, history[1].TotalRecipes)
+}
@@ -1,9 +1,100 @@
 package responses
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/duplicates"
+	"github.com/maxime-louis14/api-golang/facets"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/maxime-louis14/api-golang/nutrition"
+	"github.com/maxime-louis14/api-golang/quality"
+	"github.com/maxime-louis14/api-golang/revisions"
+)
 
 type RecetteResponse struct {
 	Status  int        `json:"status"`
 	Message string     `json:"message"`
 	Data    *fiber.Map `json:"data"`
 }
+
+// SimilarRecette recette similaire avec son score de similarité
+type SimilarRecette struct {
+	Recette           models.Recette `json:"recette"`
+	Score             float64        `json:"score"`
+	SharedIngredients []string       `json:"shared_ingredients"`
+}
+
+// RecetteNutrition estimation nutritionnelle d'une recette
+type RecetteNutrition struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	nutrition.Report
+}
+
+// RevisionDiff différences entre deux révisions d'une recette
+type RevisionDiff struct {
+	RecetteID string                 `json:"recette_id"`
+	From      models.RecetteRevision `json:"from"`
+	To        models.RecetteRevision `json:"to"`
+	revisions.Diff
+}
+
+// TrashedRecette recette de la corbeille avec sa date de suppression définitive
+type TrashedRecette struct {
+	models.RecetteV1
+	PurgeAt time.Time `json:"purge_at"`
+}
+
+// BulkResult résultat d'une opération en masse
+type BulkResult struct {
+	Operation string   `json:"operation"`
+	DryRun    bool     `json:"dry_run"`
+	Matched   int64    `json:"matched"`
+	Modified  int64    `json:"modified"`
+	SampleIDs []string `json:"sample_ids"`
+}
+
+// FacetedRecettes liste de recettes accompagnée des facettes
+type FacetedRecettes struct {
+	Recettes []models.Recette `json:"recettes"`
+	Total    int              `json:"total"`
+	Facets   facets.Facets    `json:"facets"`
+}
+
+// RuleCount nombre de recettes en quarantaine ne respectant pas une règle
+type RuleCount struct {
+	Rule  string `json:"rule"`
+	Count int    `json:"count"`
+}
+
+// QualityReport état de la quarantaine et règles de validation en vigueur
+type QualityReport struct {
+	Rules  quality.Rules               `json:"rules"`
+	Total  int64                       `json:"total"`
+	ByRule []RuleCount                 `json:"by_rule"`
+	Items  []models.QuarantinedRecette `json:"items"`
+}
+
+// DuplicateCandidates paires de recettes probablement identiques
+type DuplicateCandidates struct {
+	Total int               `json:"total"`
+	Pairs []duplicates.Pair `json:"pairs"`
+}
+
+// MergeResult recette canonique après fusion et alias enregistrés
+type MergeResult struct {
+	Recette models.Recette        `json:"recette"`
+	Aliases []models.RecetteAlias `json:"aliases"`
+}
+
+// ImportResult bilan d'une importation depuis un format tiers
+type ImportResult struct {
+	RunID       string `json:"run_id"`
+	Format      string `json:"format"`
+	Total       int    `json:"total"`
+	Inserted    int    `json:"inserted"`
+	Updated     int    `json:"updated"`
+	Unchanged   int    `json:"unchanged"`
+	Quarantined int    `json:"quarantined"`
+}
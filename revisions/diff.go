@@ -0,0 +1,158 @@
+package revisions
+
+import (
+	"strings"
+
+	"github.com/maxime-louis14/api-golang/ingredients"
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// Opérations d'une ligne modifiée
+const (
+	OpAdded   = "added"
+	OpRemoved = "removed"
+	OpChanged = "changed"
+)
+
+// FieldChange champ simple modifié entre deux versions
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// LineChange ligne d'ingrédient ou étape ajoutée, supprimée ou modifiée
+// From et To sont les positions (à partir de 1) dans l'ancienne et la nouvelle version
+type LineChange struct {
+	Op     string `json:"op"`
+	From   int    `json:"from,omitempty"`
+	To     int    `json:"to,omitempty"`
+	Before string `json:"before,omitempty"`
+	After  string `json:"after,omitempty"`
+}
+
+// Diff différences entre deux versions d'une recette
+type Diff struct {
+	Fields       []FieldChange `json:"fields"`
+	Ingredients  []LineChange  `json:"ingredients"`
+	Instructions []LineChange  `json:"instructions"`
+}
+
+// Empty indique si les deux versions sont identiques
+func (d Diff) Empty() bool {
+	return len(d.Fields) == 0 && len(d.Ingredients) == 0 && len(d.Instructions) == 0
+}
+
+// Compare calcule les différences entre deux versions d'une recette
+// Les champs calculés à l'importation (régimes, allergènes, minutes) et les métadonnées
+// (corbeille, importation d'origine) ne sont pas comparés
+func Compare(before, after models.Recette) Diff {
+	diff := Diff{
+		Fields:       []FieldChange{},
+		Ingredients:  compareLines(ingredientLines(before.Ingredients), ingredientLines(after.Ingredients)),
+		Instructions: compareLines(instructionLines(before.Instructions), instructionLines(after.Instructions)),
+	}
+
+	fields := []struct {
+		name          string
+		before, after interface{}
+	}{
+		{"name", before.Name, after.Name},
+		{"page", before.Page, after.Page},
+		{"image", before.Image, after.Image},
+		{"category", before.Category, after.Category},
+		{"tags", strings.Join(before.Tags, ", "), strings.Join(after.Tags, ", ")},
+		{"prep_time", before.PrepTime, after.PrepTime},
+		{"cook_time", before.CookTime, after.CookTime},
+		{"total_time", before.TotalTime, after.TotalTime},
+		{"servings", before.Servings, after.Servings},
+	}
+	for _, field := range fields {
+		if field.before != field.after {
+			diff.Fields = append(diff.Fields, FieldChange{Field: field.name, Before: field.before, After: field.after})
+		}
+	}
+	return diff
+}
+
+// ingredientLines retourne les lignes d'ingrédients non vides
+func ingredientLines(ings []models.Ingredient) []string {
+	lines := make([]string, 0, len(ings))
+	for _, ing := range ings {
+		if line := ingredients.Text(ing); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// instructionLines retourne le texte des étapes (la numérotation n'est pas comparée)
+func instructionLines(steps []models.Instruction) []string {
+	lines := make([]string, 0, len(steps))
+	for _, step := range steps {
+		if line := strings.TrimSpace(step.Description); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// compareLines calcule les lignes ajoutées et supprimées à partir de la plus longue sous-suite commune
+// Une suppression suivie d'un ajout au même endroit est présentée comme une modification
+func compareLines(before, after []string) []LineChange {
+	// lcs[i][j] longueur de la plus longue sous-suite commune de before[i:] et after[j:]
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	changes := []LineChange{}
+	var removed, added []LineChange
+	flush := func() {
+		paired := len(removed)
+		if len(added) < paired {
+			paired = len(added)
+		}
+		for k := 0; k < paired; k++ {
+			changes = append(changes, LineChange{
+				Op:     OpChanged,
+				From:   removed[k].From,
+				To:     added[k].To,
+				Before: removed[k].Before,
+				After:  added[k].After,
+			})
+		}
+		changes = append(changes, removed[paired:]...)
+		changes = append(changes, added[paired:]...)
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(before) || j < len(after) {
+		switch {
+		case i < len(before) && j < len(after) && before[i] == after[j]:
+			flush()
+			i++
+			j++
+		case j == len(after) || (i < len(before) && lcs[i+1][j] >= lcs[i][j+1]):
+			removed = append(removed, LineChange{Op: OpRemoved, From: i + 1, Before: before[i]})
+			i++
+		default:
+			added = append(added, LineChange{Op: OpAdded, To: j + 1, After: after[j]})
+			j++
+		}
+	}
+	flush()
+	return changes
+}
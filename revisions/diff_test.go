@@ -0,0 +1,77 @@
+package revisions
+
+import (
+	"testing"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompare(t *testing.T) {
+	before := models.Recette{
+		Recipe: domain.Recipe{
+			Name:     "Pancakes",
+			Servings: 4,
+			Ingredients: []models.Ingredient{
+				{Quantity: "2 cups flour"},
+				{Quantity: "1 cup milk"},
+				{Quantity: "2 eggs"},
+				{Quantity: "1 pinch salt"},
+			},
+			Instructions: []models.Instruction{
+				{Number: "1", Description: "Mix everything."},
+				{Number: "2", Description: "Cook in a pan."},
+			},
+		},
+	}
+	after := models.Recette{
+		Recipe: domain.Recipe{
+			Name:     "Fluffy pancakes",
+			Servings: 4,
+			Ingredients: []models.Ingredient{
+				{Quantity: "2 cups flour"},
+				{Quantity: "1 1/4 cups milk"},
+				{Quantity: "2 eggs"},
+				{Quantity: "1 tablespoon sugar"},
+				{Quantity: "1 pinch salt"},
+			},
+			Instructions: []models.Instruction{
+				{Number: "1", Description: "Mix everything."},
+			},
+		},
+	}
+
+	diff := Compare(before, after)
+
+	assert.Equal(t, []FieldChange{{Field: "name", Before: "Pancakes", After: "Fluffy pancakes"}}, diff.Fields)
+	assert.Equal(t, []LineChange{
+		{Op: OpChanged, From: 2, To: 2, Before: "1 cup milk", After: "1 1/4 cups milk"},
+		{Op: OpAdded, To: 4, After: "1 tablespoon sugar"},
+	}, diff.Ingredients)
+	assert.Equal(t, []LineChange{
+		{Op: OpRemoved, From: 2, Before: "Cook in a pan."},
+	}, diff.Instructions)
+	assert.False(t, diff.Empty())
+
+	// La renumérotation des étapes et les champs calculés ne comptent pas
+	renumbered := before
+	renumbered.Instructions = []models.Instruction{
+		{Number: "Step 1", Description: "Mix everything."},
+		{Number: "Step 2", Description: "Cook in a pan."},
+	}
+	renumbered.Allergens = []string{"milk", "eggs", "gluten"}
+	assert.True(t, Compare(before, renumbered).Empty())
+}
+
+func TestCompareLines(t *testing.T) {
+	assert.Empty(t, compareLines(nil, nil))
+	assert.Equal(t, []LineChange{
+		{Op: OpAdded, To: 1, After: "a"},
+		{Op: OpAdded, To: 2, After: "b"},
+	}, compareLines(nil, []string{"a", "b"}))
+	assert.Equal(t, []LineChange{
+		{Op: OpChanged, From: 1, To: 1, Before: "a", After: "x"},
+		{Op: OpRemoved, From: 2, Before: "b"},
+	}, compareLines([]string{"a", "b", "c"}, []string{"x", "c"}))
+}
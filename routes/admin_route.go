@@ -0,0 +1,18 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/controllers"
+	"github.com/maxime-louis14/api-golang/middleware"
+)
+
+// AdminRoute enregistre les routes d'administration (scope admin requis)
+func AdminRoute(router fiber.Router, admin *controllers.AdminHandler) {
+	group := router.Group("/admin", middleware.RequireScope(auth.ScopeAdmin))
+	group.Post("/keys", controllers.CreateAPIKey)
+	group.Get("/keys", controllers.GetAPIKeys)
+	group.Get("/keys/:id/usage", controllers.GetAPIKeyUsage)
+	group.Delete("/keys/:id", controllers.RevokeAPIKey)
+	group.Get("/indexes", admin.GetIndexes)
+}
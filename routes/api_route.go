@@ -0,0 +1,32 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/controllers"
+	"github.com/maxime-louis14/api-golang/middleware"
+)
+
+// APIVersion associe un nom de version à la fonction qui enregistre ses routes
+// Register capture les handlers de la version : chaque version choisit ses propres dépendances
+type APIVersion struct {
+	Name     string
+	Register func(router fiber.Router)
+}
+
+// V1 enregistre les routes de la version 1 de l'API
+func V1(router fiber.Router, recettes *controllers.RecetteHandler, webhooks *controllers.WebhookHandler, admin *controllers.AdminHandler) {
+	RecetteRoute(router, recettes)
+	AdminRoute(router, admin)
+	WebhookRoute(router, webhooks)
+	QualityRoute(router, recettes)
+}
+
+// APIRoute monte chaque version sous /api/<version>, servies côte à côte
+// Une v2 s'ajoute avec sa propre fonction d'enregistrement et ses propres handlers,
+// sans toucher aux routes de la v1
+func APIRoute(app *fiber.App, versions []APIVersion) {
+	api := app.Group("/api")
+	for _, version := range versions {
+		version.Register(api.Group("/"+version.Name, middleware.APIVersionMiddleware(version.Name)))
+	}
+}
@@ -0,0 +1,48 @@
+package routes
+
+import (
+	"os"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/controllers"
+	"github.com/maxime-louis14/api-golang/logger"
+	"github.com/maxime-louis14/api-golang/middleware"
+)
+
+// legacyDeprecation date de dépréciation des routes historiques (mise en service de /api/v1)
+var legacyDeprecation = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// defaultLegacySunset date de retrait par défaut des routes historiques
+const defaultLegacySunset = "2027-04-30T00:00:00Z"
+
+// legacySunset lit la date de retrait depuis LEGACY_API_SUNSET (RFC 3339)
+func legacySunset() time.Time {
+	value := os.Getenv("LEGACY_API_SUNSET")
+	if value == "" {
+		value = defaultLegacySunset
+	}
+
+	sunset, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		logger.LogError("LEGACY_API_SUNSET invalide, utilisation de la date par défaut", err, map[string]interface{}{
+			"value": value,
+		})
+		sunset, _ = time.Parse(time.RFC3339, defaultLegacySunset)
+	}
+	return sunset
+}
+
+// LegacyRoute conserve les routes historiques servies à la racine comme alias de /api/v1
+// Ces routes sont figées : les nouvelles routes ne sont exposées que sous /api/<version>
+func LegacyRoute(app *fiber.App, recettes *controllers.RecetteHandler) {
+	deprecated := middleware.DeprecationMiddleware(legacyDeprecation, legacySunset(), "/api/v1")
+
+	app.Post("/scraper/run", deprecated, middleware.RequireScope(auth.ScopeScrape), recettes.LaunchScraper)
+	app.Post("/recettes", deprecated, middleware.RequireScope(auth.ScopeImport), recettes.PostRecette)
+	app.Get("/recettes", deprecated, recettes.GetAllRecettes)
+	app.Get("/recette/:id", deprecated, recettes.GetRecetteByID)
+	app.Get("/recette/name/:name", deprecated, recettes.GetRecetteByName)
+	app.Get("/recette/ingredient/:ingredient", deprecated, recettes.GetRecettesByIngredient)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/controllers"
+	"github.com/maxime-louis14/api-golang/middleware"
+)
+
+// QualityRoute enregistre le rapport de qualité et la gestion de la quarantaine (scope import requis)
+func QualityRoute(router fiber.Router, recettes *controllers.RecetteHandler) {
+	quality := router.Group("/quality", middleware.RequireScope(auth.ScopeImport))
+	quality.Get("/report", recettes.GetQualityReport)
+	quality.Put("/quarantine/:id", recettes.FixQuarantinedRecette)
+	quality.Post("/quarantine/:id/promote", recettes.PromoteQuarantinedRecette)
+	quality.Delete("/quarantine/:id", recettes.DeleteQuarantinedRecette)
+}
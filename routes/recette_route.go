@@ -2,7 +2,9 @@ package routes
 
 import (
 	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
 	"github.com/maxime-louis14/api-golang/controllers"
+	"github.com/maxime-louis14/api-golang/middleware"
 )
 
 // GetRecetteByName récupère une recette par son nom
@@ -15,12 +17,30 @@ import (
 // @Failure 404 {string} string "Recette introuvable"
 // @Router /recettes/{name} [get]
 
-func RecetteRoute(app *fiber.App) {
-	app.Post("/scraper/run", controllers.LaunchScraper)
-	app.Post("/recettes", controllers.PostRecette)
-	app.Get("/recettes", controllers.GetAllRecettes)
-	app.Get("/recette/:id", controllers.GetRecetteByID)
-	app.Get("/recette/name/:name", controllers.GetRecetteByName)
-	app.Get("/recette/ingredient/:ingredient", controllers.GetRecettesByIngredient)
+func RecetteRoute(router fiber.Router, recettes *controllers.RecetteHandler) {
+	router.Post("/scraper/run", middleware.RequireScope(auth.ScopeScrape), recettes.LaunchScraper)
+	router.Post("/recettes", middleware.RequireScope(auth.ScopeImport), recettes.PostRecette)
+	router.Post("/recettes/import", middleware.RequireScope(auth.ScopeImport), recettes.ImportRecettes)
+	router.Get("/recettes", recettes.GetAllRecettes)
+	router.Post("/recettes/bulk", middleware.RequireScope(auth.ScopeAdmin), recettes.BulkRecettes)
+	router.Get("/recette/:id", recettes.GetRecetteByID)
+	router.Put("/recette/:id", middleware.RequireScope(auth.ScopeImport), recettes.UpdateRecette)
+	router.Delete("/recette/:id", middleware.RequireScope(auth.ScopeImport), recettes.DeleteRecette)
+	router.Post("/recette/:id/restore", middleware.RequireScope(auth.ScopeImport), recettes.RestoreRecette)
+	router.Get("/trash", middleware.RequireScope(auth.ScopeImport), recettes.GetTrash)
+	router.Get("/recette/:id/revisions", recettes.GetRecetteRevisions)
+	router.Get("/recette/:id/revisions/:a/diff/:b", recettes.GetRecetteRevisionDiff)
+	router.Get("/recette/:id/similar", recettes.GetSimilarRecettes)
+	router.Get("/recette/:id/nutrition", recettes.GetRecetteNutrition)
+	router.Get("/recette/:id/image", recettes.GetRecetteImage)
+	router.Get("/recette/:id/print", recettes.GetRecettePrint)
+	router.Get("/recette/name/:name", recettes.GetRecetteByName)
+	router.Get("/recette/ingredient/:ingredient", recettes.GetRecettesByIngredient)
+	router.Get("/suggest", recettes.GetSuggestions)
+	router.Get("/duplicates", recettes.GetDuplicates)
+	router.Post("/duplicates/merge", middleware.RequireScope(auth.ScopeAdmin), recettes.MergeDuplicates)
+	router.Get("/stats/recettes", recettes.GetRecetteStats)
+	router.Get("/stats/recettes/history", recettes.GetRecetteStatsHistory)
+	router.Get("/events", middleware.RequireScope(auth.ScopeRead), recettes.GetEvents)
 
 }
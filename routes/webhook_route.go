@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/maxime-louis14/api-golang/auth"
+	"github.com/maxime-louis14/api-golang/controllers"
+	"github.com/maxime-louis14/api-golang/middleware"
+)
+
+// WebhookRoute enregistre les routes de gestion des webhooks (scope admin requis)
+func WebhookRoute(router fiber.Router, webhooks *controllers.WebhookHandler) {
+	group := router.Group("/webhooks", middleware.RequireScope(auth.ScopeAdmin))
+	group.Post("/", webhooks.CreateWebhook)
+	group.Get("/", webhooks.GetWebhooks)
+	group.Delete("/:id", webhooks.DeleteWebhook)
+	group.Get("/:id/deliveries", webhooks.GetWebhookDeliveries)
+}
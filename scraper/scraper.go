@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -12,6 +13,10 @@ import (
 	"time"
 
 	"github.com/gocolly/colly"
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/events"
+	"github.com/maxime-louis14/api-golang/images"
+	"github.com/maxime-louis14/api-golang/isoduration"
 )
 
 // Variables de versioning injectées lors du build
@@ -32,33 +37,21 @@ type BuildInfo struct {
 	Arch      string `json:"arch"`       // Architecture (amd64, arm64, etc.)
 }
 
-// Recipe représente une recette complète avec tous ses détails
-type Recipe struct {
-	Name         string        `json:"name"`         // Nom de la recette
-	Page         string        `json:"page"`         // URL de la page de la recette
-	Image        string        `json:"image"`        // URL de l'image de la recette
-	Ingredients  []Ingredient  `json:"ingredients"`  // Liste des ingrédients
-	Instructions []Instruction `json:"instructions"` // Liste des instructions
-}
-
-// Ingredient représente un ingrédient avec sa quantité et son unité
-type Ingredient struct {
-	Quantity string `json:"quantity"` // Quantité (ex: "2", "1/2")
-	Unit     string `json:"unit"`     // Unité (ex: "cups", "tablespoons")
-}
-
-// Instruction représente une étape de la recette
-type Instruction struct {
-	Number      string `json:"number"`      // Numéro de l'étape (ex: "1", "2")
-	Description string `json:"description"` // Description de l'étape
-}
+// Recipe, Ingredient et Instruction sont définis par le package domain, partagé avec l'API
+// pour que data.json et les documents MongoDB suivent le même schéma versionné
+type (
+	Recipe      = domain.Recipe
+	Ingredient  = domain.Ingredient
+	Instruction = domain.Instruction
+)
 
 // RecipeData contient les informations de base d'une recette avant le scraping détaillé
 // Utilisé pour passer les données entre les goroutines
 type RecipeData struct {
-	URL   string // URL de la page de la recette
-	Title string // Titre de la recette
-	Image string // URL de l'image de la recette
+	URL      string // URL de la page de la recette
+	Title    string // Titre de la recette
+	Image    string // URL de l'image de la recette
+	Category string // Catégorie de la page de liste
 }
 
 // ScrapingStats contient toutes les statistiques de performance du scraper
@@ -362,9 +355,10 @@ func createMainCollector(stats *ScrapingStats, recipeURLs chan<- RecipeData) *co
 
 			// Créer l'objet RecipeData avec les informations extraites
 			recipeData := RecipeData{
-				URL:   page,
-				Title: title,
-				Image: image,
+				URL:      page,
+				Title:    title,
+				Image:    image,
+				Category: categoryFromURL(e.Request.URL.Path),
 			}
 
 			// Envoyer la recette dans le channel (non-bloquant)
@@ -407,9 +401,10 @@ func createMainCollectorWithPagination(stats *ScrapingStats, recipeURLs chan<- R
 		if page != "" && title != "" {
 			stats.IncrementRecipesFound()
 			recipeData := RecipeData{
-				URL:   page,
-				Title: title,
-				Image: image,
+				URL:      page,
+				Title:    title,
+				Image:    image,
+				Category: categoryFromURL(e.Request.URL.Path),
 			}
 
 			select {
@@ -500,6 +495,13 @@ func scrapeRecipeDetails(collector *colly.Collector, recipe *Recipe, completedRe
 		log.Printf("🔍 Ingrédients trouvés: %d pour '%s'\n", len(ingredients), recipe.Name)
 	})
 
+	// Collecter les temps et le rendement (bloc "Prep Time: 15 mins", "Servings: 4"...)
+	collector.OnHTML("div.mm-recipes-details__item", func(e *colly.HTMLElement) {
+		label := strings.TrimSpace(e.ChildText("div.mm-recipes-details__label"))
+		value := strings.TrimSpace(e.ChildText("div.mm-recipes-details__value"))
+		applyRecipeDetail(recipe, label, value)
+	})
+
 	// Collecter les instructions - Nouveaux sélecteurs CSS pour AllRecipes 2024
 	collector.OnHTML("div.mm-recipes-steps__content", func(e *colly.HTMLElement) {
 		var instructions []Instruction
@@ -527,12 +529,143 @@ func scrapeRecipeDetails(collector *colly.Collector, recipe *Recipe, completedRe
 
 	// Quand le scraping de la recette est terminé
 	collector.OnScraped(func(r *colly.Response) {
+		mirrorRecipeImage(recipe)
 		stats.IncrementRecipesCompleted()
 		completedRecipes <- *recipe
 		log.Printf("✅ Recette #%d complétée: '%s'\n", stats.RecipesCompleted, recipe.Name)
+		reportProgress(stats, recipe.Name, recipe.Page)
 	})
 }
 
+// reportProgress écrit l'avancement sur la sortie standard après chaque recette traitée
+// L'API qui lance le scraper en fait des événements scrape.progress
+func reportProgress(stats *ScrapingStats, name, page string) {
+	stats.Mutex.RLock()
+	progress := events.ScrapeProgressLine{
+		Found:     int(stats.RecipesFound),
+		Completed: int(stats.RecipesCompleted),
+		Failed:    int(stats.RecipesFailed),
+		Recipe:    name,
+		Page:      page,
+	}
+	stats.Mutex.RUnlock()
+	fmt.Println(events.FormatScrapeProgress(progress))
+}
+
+// imageStore stockage où sont copiées les images des recettes (nil si la copie est désactivée)
+var imageStore images.Store
+
+// setupImageStore ouvre le stockage d'images (IMAGE_STORE_BACKEND, IMAGE_STORE_DIR)
+// SCRAPER_MIRROR_IMAGES=false désactive la copie
+func setupImageStore() {
+	if os.Getenv("SCRAPER_MIRROR_IMAGES") == "false" {
+		log.Printf("🖼️  Copie des images désactivée\n")
+		return
+	}
+
+	store, err := images.OpenFromEnv()
+	if err != nil {
+		log.Printf("⚠️  Stockage d'images indisponible, les images ne seront pas copiées: %v\n", err)
+		return
+	}
+	imageStore = store
+}
+
+// mirrorRecipeImage copie l'image de la recette dans le stockage d'images
+// Un échec n'empêche pas l'enregistrement de la recette (l'API peut copier l'image plus tard)
+func mirrorRecipeImage(recipe *Recipe) {
+	if imageStore == nil || recipe.Image == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	key, err := images.Mirror(ctx, imageStore, nil, recipe.Image)
+	if err != nil {
+		log.Printf("⚠️  Échec de copie de l'image de '%s': %v\n", recipe.Name, err)
+		return
+	}
+	recipe.ImageKey = key
+}
+
+// applyRecipeDetail enregistre une ligne du bloc de détails d'une recette
+// label: "Prep Time:", "Cook Time:", "Total Time:", "Servings:" ou "Yield:"
+func applyRecipeDetail(recipe *Recipe, label, value string) {
+	switch strings.ToLower(strings.TrimSuffix(strings.TrimSpace(label), ":")) {
+	case "prep time":
+		if d, ok := parseRecipeDuration(value); ok {
+			recipe.PrepTime = isoduration.Format(d)
+		}
+	case "cook time":
+		if d, ok := parseRecipeDuration(value); ok {
+			recipe.CookTime = isoduration.Format(d)
+		}
+	case "total time":
+		if d, ok := parseRecipeDuration(value); ok {
+			recipe.TotalTime = isoduration.Format(d)
+		}
+	case "servings":
+		if servings, ok := parseServings(value); ok {
+			recipe.Servings = servings
+		}
+	case "yield":
+		// Le rendement ne sert qu'à défaut du nombre de portions ("6 servings", "1 9-inch pie")
+		if servings, ok := parseServings(value); ok && recipe.Servings == 0 && strings.Contains(strings.ToLower(value), "serving") {
+			recipe.Servings = servings
+		}
+	}
+}
+
+// categoryFromURL extrait la catégorie du chemin d'une page de liste
+// ex: "/recipes/16369/soups-stews-and-chili/soup/" -> "soup"
+func categoryFromURL(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := len(segments) - 1; i >= 0; i-- {
+		segment := segments[i]
+		if _, err := strconv.Atoi(segment); err == nil || segment == "" || segment == "recipes" {
+			continue
+		}
+		return domain.NormalizeCategory(segment)
+	}
+	return ""
+}
+
+// parseRecipeDuration convertit une durée affichée par AllRecipes ("1 hr 10 mins", "1 day 2 hrs")
+func parseRecipeDuration(value string) (time.Duration, bool) {
+	units := map[string]time.Duration{
+		"day": 24 * time.Hour, "days": 24 * time.Hour,
+		"hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+		"min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	}
+
+	fields := strings.Fields(strings.ToLower(value))
+	var total time.Duration
+	found := false
+	for i := 0; i+1 < len(fields); i++ {
+		amount, err := strconv.Atoi(fields[i])
+		if err != nil {
+			continue
+		}
+		if unit, ok := units[strings.Trim(fields[i+1], ".,")]; ok {
+			total += time.Duration(amount) * unit
+			found = true
+			i++
+		}
+	}
+	return total, found && total > 0
+}
+
+// parseServings extrait le nombre de portions ("4", "8 to 10" -> 8, "6 servings")
+func parseServings(value string) (int, bool) {
+	for _, field := range strings.Fields(value) {
+		if servings, err := strconv.Atoi(field); err == nil && servings > 0 {
+			return servings, true
+		}
+	}
+	return 0, false
+}
+
 // processRecipeReusable traite une recette dans un worker réutilisable
 func processRecipeReusable(recipeData RecipeData, stats *ScrapingStats, completedRecipes chan<- Recipe, workerStats *WorkerStats) {
 	startTime := time.Now()
@@ -542,9 +675,10 @@ func processRecipeReusable(recipeData RecipeData, stats *ScrapingStats, complete
 	recipeCollector := createRecipeCollector(stats)
 
 	recipe := Recipe{
-		Name:  recipeData.Title,
-		Page:  recipeData.URL,
-		Image: recipeData.Image,
+		Name:     recipeData.Title,
+		Page:     recipeData.URL,
+		Image:    recipeData.Image,
+		Category: recipeData.Category,
 	}
 
 	// Configurer le scraping des détails
@@ -555,6 +689,7 @@ func processRecipeReusable(recipeData RecipeData, stats *ScrapingStats, complete
 	if err != nil {
 		stats.IncrementRecipesFailed()
 		log.Printf("❌ Worker #%d - Erreur lors de la visite de la page de recette '%s': %v\n", workerStats.WorkerID, recipeData.Title, err)
+		reportProgress(stats, recipeData.Title, recipeData.URL)
 	} else {
 		// Mettre à jour les stats du worker
 		workerStats.RequestsHandled++
@@ -632,8 +767,11 @@ func startRecipeCollector(completedRecipes <-chan Recipe, recipes *[]Recipe, rec
 	}()
 }
 
-// saveRecipesToFile sauvegarde les recettes dans un fichier JSON
+// saveRecipesToFile sauvegarde les recettes dans un fichier JSON, avec la version courante du schéma
 func saveRecipesToFile(recipes []Recipe, filename string) error {
+	for i := range recipes {
+		recipes[i].SchemaVersion = domain.SchemaVersion
+	}
 	content, err := json.MarshalIndent(recipes, "", "  ")
 	if err != nil {
 		return err
@@ -758,6 +896,9 @@ func main() {
 	// Créer l'objet de statistiques thread-safe
 	stats := NewScrapingStats(optimalWorkers)
 
+	// Stockage local des images des recettes
+	setupImageStore()
+
 	// Afficher les informations de démarrage
 	log.Printf("🚀 Démarrage du script de scraping avec %d goroutines (version %s)...\n", optimalWorkers, version)
 	log.Printf("📋 Build info: %+v\n", getBuildInfo())
@@ -250,6 +250,55 @@ func TestCreateRecipeCollector(t *testing.T) {
 	assert.NotNil(t, collector)
 }
 
+// Test de l'extraction des temps et du nombre de portions
+func TestParseRecipeDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"15 mins":        15 * time.Minute,
+		"1 hr 10 mins":   70 * time.Minute,
+		"2 hrs":          2 * time.Hour,
+		"1 day 2 hrs":    26 * time.Hour,
+		"1 hour 5 mins.": 65 * time.Minute,
+	}
+	for value, expected := range tests {
+		d, ok := parseRecipeDuration(value)
+		assert.True(t, ok, value)
+		assert.Equal(t, expected, d, value)
+	}
+
+	_, ok := parseRecipeDuration("")
+	assert.False(t, ok)
+	_, ok = parseRecipeDuration("overnight")
+	assert.False(t, ok)
+}
+
+func TestApplyRecipeDetail(t *testing.T) {
+	recipe := Recipe{Name: "Test Recipe"}
+
+	applyRecipeDetail(&recipe, "Prep Time:", "15 mins")
+	applyRecipeDetail(&recipe, "Cook Time:", "1 hr 10 mins")
+	applyRecipeDetail(&recipe, "Total Time:", "1 hr 25 mins")
+	applyRecipeDetail(&recipe, "Yield:", "1 9-inch pie")
+	applyRecipeDetail(&recipe, "Servings:", "8 to 10")
+
+	assert.Equal(t, "PT15M", recipe.PrepTime)
+	assert.Equal(t, "PT1H10M", recipe.CookTime)
+	assert.Equal(t, "PT1H25M", recipe.TotalTime)
+	assert.Equal(t, 8, recipe.Servings)
+
+	// Le rendement n'est utilisé que s'il exprime des portions
+	other := Recipe{}
+	applyRecipeDetail(&other, "Yield:", "1 9-inch pie")
+	assert.Equal(t, 0, other.Servings)
+	applyRecipeDetail(&other, "Yield:", "6 servings")
+	assert.Equal(t, 6, other.Servings)
+}
+
+func TestCategoryFromURL(t *testing.T) {
+	assert.Equal(t, "soup", categoryFromURL("/recipes/16369/soups-stews-and-chili/soup/"))
+	assert.Equal(t, "desserts", categoryFromURL("/recipes/79/desserts/"))
+	assert.Equal(t, "", categoryFromURL("/recipes/79/"))
+}
+
 // Test des channels et goroutines
 func TestRecipeChannelCommunication(t *testing.T) {
 	completedRecipes := make(chan Recipe, 5)
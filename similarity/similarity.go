@@ -0,0 +1,139 @@
+package similarity
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Document représente une recette dans l'index : son identifiant et ses noms d'ingrédients normalisés
+type Document struct {
+	ID    string
+	Terms []string
+}
+
+// Match est une recette similaire avec son score de similarité (cosinus TF-IDF)
+type Match struct {
+	ID     string   `json:"id"`
+	Score  float64  `json:"score"`
+	Shared []string `json:"shared_ingredients"`
+}
+
+// Index précalcule les vecteurs TF-IDF des recettes pour des recherches rapides
+// Thread-safe : Build remplace l'index pendant que Similar continue de servir l'ancien
+type Index struct {
+	mu       sync.RWMutex
+	vectors  map[string]map[string]float64 // id -> ingrédient -> poids normalisé
+	postings map[string][]string           // ingrédient -> ids des recettes le contenant
+	builtAt  time.Time
+}
+
+// NewIndex crée un index vide
+func NewIndex() *Index {
+	return &Index{
+		vectors:  make(map[string]map[string]float64),
+		postings: make(map[string][]string),
+	}
+}
+
+// Build reconstruit entièrement l'index à partir des documents
+func (idx *Index) Build(docs []Document) {
+	// Fréquence documentaire de chaque ingrédient (présence, pas d'occurrences multiples)
+	termSets := make(map[string]map[string]bool, len(docs))
+	df := make(map[string]int)
+	for _, doc := range docs {
+		set := make(map[string]bool, len(doc.Terms))
+		for _, term := range doc.Terms {
+			if term != "" && !set[term] {
+				set[term] = true
+				df[term]++
+			}
+		}
+		termSets[doc.ID] = set
+	}
+
+	// Pondération IDF : les ingrédients rares (ex: "lobster tail") pèsent plus que "salt"
+	n := float64(len(termSets))
+	vectors := make(map[string]map[string]float64, len(termSets))
+	postings := make(map[string][]string, len(df))
+	for id, set := range termSets {
+		vector := make(map[string]float64, len(set))
+		norm := 0.0
+		for term := range set {
+			weight := math.Log(1 + n/float64(df[term]))
+			vector[term] = weight
+			norm += weight * weight
+			postings[term] = append(postings[term], id)
+		}
+		norm = math.Sqrt(norm)
+		for term := range vector {
+			vector[term] /= norm
+		}
+		vectors[id] = vector
+	}
+
+	idx.mu.Lock()
+	idx.vectors = vectors
+	idx.postings = postings
+	idx.builtAt = time.Now()
+	idx.mu.Unlock()
+}
+
+// Similar retourne les recettes les plus proches de la recette id, par score décroissant
+// Le booléen vaut false si la recette n'est pas dans l'index
+func (idx *Index) Similar(id string, limit int) ([]Match, bool) {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	query, exists := idx.vectors[id]
+	if !exists {
+		return nil, false
+	}
+
+	// Produit scalaire via l'index inversé : seules les recettes partageant un ingrédient sont visitées
+	scores := make(map[string]float64)
+	shared := make(map[string][]string)
+	for term, weight := range query {
+		for _, other := range idx.postings[term] {
+			if other == id {
+				continue
+			}
+			scores[other] += weight * idx.vectors[other][term]
+			shared[other] = append(shared[other], term)
+		}
+	}
+
+	matches := make([]Match, 0, len(scores))
+	for other, score := range scores {
+		terms := shared[other]
+		sort.Strings(terms)
+		matches = append(matches, Match{ID: other, Score: math.Round(score*10000) / 10000, Shared: terms})
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Score != matches[j].Score {
+			return matches[i].Score > matches[j].Score
+		}
+		return matches[i].ID < matches[j].ID
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches, true
+}
+
+// Size retourne le nombre de recettes indexées
+func (idx *Index) Size() int {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return len(idx.vectors)
+}
+
+// BuiltAt retourne la date de la dernière reconstruction
+func (idx *Index) BuiltAt() time.Time {
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+	return idx.builtAt
+}
@@ -0,0 +1,61 @@
+package similarity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDocuments() []Document {
+	return []Document{
+		{ID: "soup", Terms: []string{"tomato", "onion", "garlic", "salt", "chicken broth"}},
+		{ID: "soup2", Terms: []string{"tomato", "onion", "garlic", "salt", "bone broth"}},
+		{ID: "salad", Terms: []string{"tomato", "cucumber", "salt"}},
+		{ID: "cake", Terms: []string{"flour", "egg", "sugar", "salt"}},
+		{ID: "drink", Terms: []string{"vodka", "lime juice"}},
+	}
+}
+
+func TestSimilarRanking(t *testing.T) {
+	idx := NewIndex()
+	idx.Build(testDocuments())
+
+	matches, found := idx.Similar("soup", 10)
+	require.True(t, found)
+	require.Len(t, matches, 3) // "drink" ne partage aucun ingrédient
+
+	assert.Equal(t, "soup2", matches[0].ID)
+	assert.Equal(t, "salad", matches[1].ID)
+	assert.Equal(t, "cake", matches[2].ID)
+	assert.Equal(t, []string{"garlic", "onion", "salt", "tomato"}, matches[0].Shared)
+	assert.Greater(t, matches[0].Score, matches[1].Score)
+	assert.LessOrEqual(t, matches[0].Score, 1.0)
+}
+
+func TestSimilarLimitAndUnknown(t *testing.T) {
+	idx := NewIndex()
+	idx.Build(testDocuments())
+
+	matches, found := idx.Similar("soup", 1)
+	require.True(t, found)
+	assert.Len(t, matches, 1)
+
+	_, found = idx.Similar("missing", 10)
+	assert.False(t, found)
+}
+
+func TestBuildReplacesIndex(t *testing.T) {
+	idx := NewIndex()
+	idx.Build(testDocuments())
+	assert.Equal(t, 5, idx.Size())
+
+	idx.Build([]Document{{ID: "a", Terms: []string{"salt", "salt"}}, {ID: "b", Terms: []string{"salt"}}})
+	assert.Equal(t, 2, idx.Size())
+
+	matches, found := idx.Similar("a", 0)
+	require.True(t, found)
+	require.Len(t, matches, 1)
+	assert.Equal(t, 1.0, matches[0].Score)
+	assert.False(t, idx.BuiltAt().IsZero())
+}
@@ -0,0 +1,320 @@
+package stats
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DefaultTopIngredients nombre d'ingrédients les plus fréquents retournés
+const DefaultTopIngredients = 20
+
+// instructionLengthBounds bornes inférieures (incluses) des tranches de longueur d'étape, en caractères
+var instructionLengthBounds = []int{0, 50, 100, 200, 400, 800}
+
+// IngredientCount nombre de recettes utilisant un ingrédient
+type IngredientCount struct {
+	Name    string `json:"name" bson:"name"`
+	Recipes int    `json:"recipes" bson:"recipes"`
+}
+
+// CategoryCount nombre de recettes d'une catégorie
+type CategoryCount struct {
+	Category string `json:"category" bson:"category"`
+	Recipes  int    `json:"recipes" bson:"recipes"`
+}
+
+// Missing nombre de recettes incomplètes
+type Missing struct {
+	Image        int `json:"image" bson:"image"`
+	Ingredients  int `json:"ingredients" bson:"ingredients"`
+	Instructions int `json:"instructions" bson:"instructions"`
+}
+
+// LengthBucket nombre d'étapes dont la longueur est dans la tranche
+type LengthBucket struct {
+	Range string `json:"range" bson:"range"`
+	Steps int    `json:"steps" bson:"steps"`
+}
+
+// Report statistiques du corpus de recettes à un instant donné
+type Report struct {
+	GeneratedAt          time.Time         `json:"generated_at" bson:"generated_at"`
+	TotalRecipes         int               `json:"total_recipes" bson:"total_recipes"`
+	AvgIngredients       float64           `json:"avg_ingredients" bson:"avg_ingredients"`
+	AvgSteps             float64           `json:"avg_steps" bson:"avg_steps"`
+	AvgInstructionLength float64           `json:"avg_instruction_length" bson:"avg_instruction_length"`
+	TopIngredients       []IngredientCount `json:"top_ingredients" bson:"top_ingredients"`
+	Categories           []CategoryCount   `json:"categories" bson:"categories"`
+	Missing              Missing           `json:"missing" bson:"missing"`
+	InstructionLengths   []LengthBucket    `json:"instruction_lengths" bson:"instruction_lengths"`
+}
+
+// sizeOf nombre d'éléments d'un tableau éventuellement absent
+func sizeOf(field string) bson.M {
+	return bson.M{"$size": bson.M{"$ifNull": bson.A{field, bson.A{}}}}
+}
+
+// Stage retourne l'étape $facet calculant toutes les statistiques en une passe
+func Stage(top int) bson.D {
+	if top <= 0 {
+		top = DefaultTopIngredients
+	}
+
+	boundaries := make(bson.A, 0, len(instructionLengthBounds)+1)
+	for _, bound := range instructionLengthBounds {
+		boundaries = append(boundaries, bound)
+	}
+	boundaries = append(boundaries, math.MaxInt32)
+
+	isEmpty := func(field string) bson.M {
+		return bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{sizeOf(field), 0}}, 1, 0}}
+	}
+
+	return bson.D{{Key: "$facet", Value: bson.M{
+		"summary": bson.A{bson.M{"$group": bson.M{
+			"_id":                  nil,
+			"total":                bson.M{"$sum": 1},
+			"avg_ingredients":      bson.M{"$avg": sizeOf("$ingredients")},
+			"avg_steps":            bson.M{"$avg": sizeOf("$instructions")},
+			"missing_image":        bson.M{"$sum": bson.M{"$cond": bson.A{bson.M{"$eq": bson.A{bson.M{"$ifNull": bson.A{"$image", ""}}, ""}}, 1, 0}}},
+			"missing_ingredients":  bson.M{"$sum": isEmpty("$ingredients")},
+			"missing_instructions": bson.M{"$sum": isEmpty("$instructions")},
+		}}},
+		"top_ingredients": bson.A{
+			bson.M{"$unwind": "$ingredient_names"},
+			bson.M{"$sortByCount": "$ingredient_names"},
+			bson.M{"$limit": top},
+		},
+		"categories": bson.A{
+			bson.M{"$sortByCount": bson.M{"$ifNull": bson.A{"$category", ""}}},
+		},
+		"instruction_lengths": bson.A{
+			bson.M{"$unwind": "$instructions"},
+			bson.M{"$project": bson.M{"length": bson.M{"$strLenCP": bson.M{"$ifNull": bson.A{"$instructions.description", ""}}}}},
+			bson.M{"$facet": bson.M{
+				"average": bson.A{bson.M{"$group": bson.M{"_id": nil, "value": bson.M{"$avg": "$length"}}}},
+				"buckets": bson.A{bson.M{"$bucket": bson.M{"groupBy": "$length", "boundaries": boundaries, "default": "other"}}},
+			}},
+		},
+	}}}
+}
+
+// rawCount décompte brut retourné par $sortByCount ou $bucket
+type rawCount struct {
+	ID    interface{} `bson:"_id"`
+	Count int         `bson:"count"`
+}
+
+// rawReport document produit par Stage
+type rawReport struct {
+	Summary            []rawSummary `bson:"summary"`
+	TopIngredients     []rawCount   `bson:"top_ingredients"`
+	Categories         []rawCount   `bson:"categories"`
+	InstructionLengths []rawLengths `bson:"instruction_lengths"`
+}
+
+// rawSummary totaux et moyennes sur l'ensemble des recettes
+type rawSummary struct {
+	Total               int     `bson:"total"`
+	AvgIngredients      float64 `bson:"avg_ingredients"`
+	AvgSteps            float64 `bson:"avg_steps"`
+	MissingImage        int     `bson:"missing_image"`
+	MissingIngredients  int     `bson:"missing_ingredients"`
+	MissingInstructions int     `bson:"missing_instructions"`
+}
+
+// rawLengths longueur moyenne et tranches de longueur des étapes
+type rawLengths struct {
+	Average []rawAverage `bson:"average"`
+	Buckets []rawCount   `bson:"buckets"`
+}
+
+type rawAverage struct {
+	Value float64 `bson:"value"`
+}
+
+// Decode convertit le document produit par Stage (nil si l'agrégation n'a rien retourné)
+func Decode(doc bson.Raw, generatedAt time.Time) (Report, error) {
+	var raw rawReport
+	if len(doc) > 0 {
+		if err := bson.Unmarshal(doc, &raw); err != nil {
+			return Report{}, err
+		}
+	}
+	return decodeRaw(raw, generatedAt), nil
+}
+
+// Compute calcule les statistiques d'une liste de recettes déjà filtrée, sans passer par MongoDB
+// Même résultat que Decode sur l'agrégation Stage des mêmes recettes (ingrédients à égalité triés par nom)
+func Compute(recettes []models.Recette, top int, generatedAt time.Time) Report {
+	if top <= 0 {
+		top = DefaultTopIngredients
+	}
+
+	var raw rawReport
+	if len(recettes) > 0 {
+		raw.Summary = make([]rawSummary, 1)
+		raw.InstructionLengths = make([]rawLengths, 1)
+	}
+	ingredients := map[string]int{}
+	categories := map[string]int{}
+	steps, totalLength := 0, 0
+	bucketCounts := make([]int, len(instructionLengthBounds))
+	for _, recette := range recettes {
+		summary := &raw.Summary[0]
+		summary.Total++
+		summary.AvgIngredients += float64(len(recette.Ingredients))
+		summary.AvgSteps += float64(len(recette.Instructions))
+		if recette.Image == "" {
+			summary.MissingImage++
+		}
+		if len(recette.Ingredients) == 0 {
+			summary.MissingIngredients++
+		}
+		if len(recette.Instructions) == 0 {
+			summary.MissingInstructions++
+		}
+
+		for _, name := range recette.IngredientNames {
+			ingredients[name]++
+		}
+		categories[recette.Category]++
+
+		for _, instruction := range recette.Instructions {
+			length := utf8.RuneCountInString(instruction.Description)
+			steps++
+			totalLength += length
+			for i := len(instructionLengthBounds) - 1; i >= 0; i-- {
+				if length >= instructionLengthBounds[i] {
+					bucketCounts[i]++
+					break
+				}
+			}
+		}
+	}
+	if len(recettes) > 0 {
+		raw.Summary[0].AvgIngredients /= float64(len(recettes))
+		raw.Summary[0].AvgSteps /= float64(len(recettes))
+	}
+	if steps > 0 {
+		raw.InstructionLengths[0].Average = []rawAverage{{Value: float64(totalLength) / float64(steps)}}
+		for i, count := range bucketCounts {
+			if count > 0 {
+				raw.InstructionLengths[0].Buckets = append(raw.InstructionLengths[0].Buckets, rawCount{ID: instructionLengthBounds[i], Count: count})
+			}
+		}
+	}
+
+	raw.TopIngredients = sortByCount(ingredients)
+	if len(raw.TopIngredients) > top {
+		raw.TopIngredients = raw.TopIngredients[:top]
+	}
+	raw.Categories = sortByCount(categories)
+	return decodeRaw(raw, generatedAt)
+}
+
+// sortByCount décomptes les plus fréquents en premier, comme $sortByCount (égalités triées par valeur)
+func sortByCount(counts map[string]int) []rawCount {
+	result := make([]rawCount, 0, len(counts))
+	for value, count := range counts {
+		result = append(result, rawCount{ID: value, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].ID.(string) < result[j].ID.(string)
+	})
+	return result
+}
+
+// decodeRaw convertit les décomptes bruts en rapport
+func decodeRaw(raw rawReport, generatedAt time.Time) Report {
+	report := Report{
+		GeneratedAt:        generatedAt,
+		TopIngredients:     []IngredientCount{},
+		Categories:         []CategoryCount{},
+		InstructionLengths: make([]LengthBucket, len(instructionLengthBounds)),
+	}
+	if len(raw.Summary) > 0 {
+		summary := raw.Summary[0]
+		report.TotalRecipes = summary.Total
+		report.AvgIngredients = round(summary.AvgIngredients)
+		report.AvgSteps = round(summary.AvgSteps)
+		report.Missing = Missing{
+			Image:        summary.MissingImage,
+			Ingredients:  summary.MissingIngredients,
+			Instructions: summary.MissingInstructions,
+		}
+	}
+
+	for _, r := range raw.TopIngredients {
+		if name, ok := r.ID.(string); ok {
+			report.TopIngredients = append(report.TopIngredients, IngredientCount{Name: name, Recipes: r.Count})
+		}
+	}
+	for _, r := range raw.Categories {
+		category, _ := r.ID.(string)
+		if category == "" {
+			category = "uncategorized"
+		}
+		report.Categories = append(report.Categories, CategoryCount{Category: category, Recipes: r.Count})
+	}
+
+	for i := range instructionLengthBounds {
+		report.InstructionLengths[i] = LengthBucket{Range: lengthLabel(i)}
+	}
+	if len(raw.InstructionLengths) > 0 {
+		lengths := raw.InstructionLengths[0]
+		if len(lengths.Average) > 0 {
+			report.AvgInstructionLength = round(lengths.Average[0].Value)
+		}
+		for _, r := range lengths.Buckets {
+			lower, ok := toInt(r.ID)
+			if !ok {
+				continue
+			}
+			for i, bound := range instructionLengthBounds {
+				if bound == lower {
+					report.InstructionLengths[i].Steps += r.Count
+				}
+			}
+		}
+	}
+	return report
+}
+
+// lengthLabel libellé de la tranche i ("50-99", "800+")
+func lengthLabel(i int) string {
+	lower := instructionLengthBounds[i]
+	if i == len(instructionLengthBounds)-1 {
+		return strconv.Itoa(lower) + "+"
+	}
+	return strconv.Itoa(lower) + "-" + strconv.Itoa(instructionLengthBounds[i+1]-1)
+}
+
+// toInt convertit une borne numérique décodée depuis BSON
+func toInt(value interface{}) (int, bool) {
+	switch v := value.(type) {
+	case int:
+		return v, true
+	case int32:
+		return int(v), true
+	case int64:
+		return int(v), true
+	case float64:
+		return int(v), true
+	}
+	return 0, false
+}
+
+// round arrondit à deux décimales
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,123 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/domain"
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestStage(t *testing.T) {
+	stage := Stage(0)
+	require.Len(t, stage, 1)
+	facet := stage[0].Value.(bson.M)
+
+	top := facet["top_ingredients"].(bson.A)
+	assert.Equal(t, bson.M{"$limit": DefaultTopIngredients}, top[len(top)-1])
+	for _, name := range []string{"summary", "categories", "instruction_lengths"} {
+		assert.Contains(t, facet, name)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	doc, err := bson.Marshal(bson.M{
+		"summary": bson.A{bson.M{
+			"_id":                  nil,
+			"total":                int32(12),
+			"avg_ingredients":      8.3333,
+			"avg_steps":            4.5,
+			"missing_image":        int32(1),
+			"missing_ingredients":  int32(0),
+			"missing_instructions": int32(2),
+		}},
+		"top_ingredients": bson.A{
+			bson.M{"_id": "salt", "count": int32(9)},
+			bson.M{"_id": "butter", "count": int32(5)},
+		},
+		"categories": bson.A{
+			bson.M{"_id": "soup", "count": int32(7)},
+			bson.M{"_id": "", "count": int32(5)},
+		},
+		"instruction_lengths": bson.A{bson.M{
+			"average": bson.A{bson.M{"_id": nil, "value": 132.456}},
+			"buckets": bson.A{
+				bson.M{"_id": int32(50), "count": int32(10)},
+				bson.M{"_id": int32(800), "count": int32(1)},
+			},
+		}},
+	})
+	require.NoError(t, err)
+
+	generatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	report, err := Decode(doc, generatedAt)
+	require.NoError(t, err)
+
+	assert.Equal(t, generatedAt, report.GeneratedAt)
+	assert.Equal(t, 12, report.TotalRecipes)
+	assert.Equal(t, 8.33, report.AvgIngredients)
+	assert.Equal(t, 4.5, report.AvgSteps)
+	assert.Equal(t, 132.46, report.AvgInstructionLength)
+	assert.Equal(t, Missing{Image: 1, Ingredients: 0, Instructions: 2}, report.Missing)
+	assert.Equal(t, []IngredientCount{{Name: "salt", Recipes: 9}, {Name: "butter", Recipes: 5}}, report.TopIngredients)
+	assert.Equal(t, []CategoryCount{{Category: "soup", Recipes: 7}, {Category: "uncategorized", Recipes: 5}}, report.Categories)
+	assert.Equal(t, []LengthBucket{
+		{Range: "0-49"}, {Range: "50-99", Steps: 10}, {Range: "100-199"},
+		{Range: "200-399"}, {Range: "400-799"}, {Range: "800+", Steps: 1},
+	}, report.InstructionLengths)
+}
+
+func TestDecodeEmpty(t *testing.T) {
+	report, err := Decode(nil, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0, report.TotalRecipes)
+	assert.NotNil(t, report.TopIngredients)
+	assert.Len(t, report.InstructionLengths, len(instructionLengthBounds))
+}
+
+func TestCompute(t *testing.T) {
+	recettes := []models.Recette{
+		{
+			Recipe: domain.Recipe{
+				Category:     "soup",
+				Image:        "https://example.com/soupe.jpg",
+				Ingredients:  make([]models.Ingredient, 3),
+				Instructions: []models.Instruction{{Description: strings.Repeat("é", 60)}, {Description: "Servir"}},
+			},
+			IngredientNames: []string{"salt", "carrot"},
+		},
+		{
+			Recipe:          domain.Recipe{Category: "soup", Ingredients: make([]models.Ingredient, 1)},
+			IngredientNames: []string{"salt"},
+		},
+		{
+			Recipe:          domain.Recipe{Instructions: []models.Instruction{{Description: strings.Repeat("a", 900)}}},
+			IngredientNames: []string{"butter"},
+		},
+	}
+
+	generatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := Compute(recettes, 2, generatedAt)
+
+	assert.Equal(t, generatedAt, report.GeneratedAt)
+	assert.Equal(t, 3, report.TotalRecipes)
+	assert.Equal(t, 1.33, report.AvgIngredients)
+	assert.Equal(t, 1.0, report.AvgSteps)
+	assert.Equal(t, 322.0, report.AvgInstructionLength)
+	assert.Equal(t, Missing{Image: 2, Ingredients: 1, Instructions: 1}, report.Missing)
+	assert.Equal(t, []IngredientCount{{Name: "salt", Recipes: 2}, {Name: "butter", Recipes: 1}}, report.TopIngredients)
+	assert.Equal(t, []CategoryCount{{Category: "soup", Recipes: 2}, {Category: "uncategorized", Recipes: 1}}, report.Categories)
+	assert.Equal(t, []LengthBucket{
+		{Range: "0-49", Steps: 1}, {Range: "50-99", Steps: 1}, {Range: "100-199"},
+		{Range: "200-399"}, {Range: "400-799"}, {Range: "800+", Steps: 1},
+	}, report.InstructionLengths)
+
+	// Même rapport vide que l'agrégation sans recette
+	empty, err := Decode(nil, generatedAt)
+	require.NoError(t, err)
+	assert.Equal(t, empty, Compute(nil, 0, generatedAt))
+}
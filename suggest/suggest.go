@@ -0,0 +1,252 @@
+package suggest
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// Types de suggestions
+const (
+	TypeRecipe     = "recipe"
+	TypeIngredient = "ingredient"
+)
+
+// Suggestion est une proposition d'autocomplétion
+type Suggestion struct {
+	Text     string `json:"text"`
+	Type     string `json:"type"`
+	Count    int    `json:"count"`
+	Distance int    `json:"distance"`
+}
+
+// entry est un texte indexé avec son poids (nombre de recettes concernées)
+type entry struct {
+	text   string
+	kind   string
+	weight int
+}
+
+// hit référence une entrée depuis un nœud terminal du trie
+type hit struct {
+	entry int
+	start bool // true si le texte indexé commence au début de l'entrée (et non à un mot interne)
+}
+
+// node est un nœud du trie
+type node struct {
+	children map[rune]*node
+	hits     []hit
+}
+
+func newNode() *node {
+	return &node{children: make(map[rune]*node)}
+}
+
+// Index d'autocomplétion : un trie par type, reconstruit à chaque importation
+// Thread-safe : Build remplace les tries pendant que Suggest sert les anciens
+type Index struct {
+	mu      sync.RWMutex
+	entries []entry
+	tries   map[string]*node
+}
+
+// NewIndex crée un index vide
+func NewIndex() *Index {
+	return &Index{tries: map[string]*node{
+		TypeRecipe:     newNode(),
+		TypeIngredient: newNode(),
+	}}
+}
+
+// Build reconstruit l'index à partir des noms de recettes et des noms d'ingrédients
+// Le poids de chaque texte est son nombre d'occurrences (ex: nombre de recettes utilisant l'ingrédient)
+func (idx *Index) Build(recipeNames, ingredientNames []string) {
+	var entries []entry
+	tries := map[string]*node{
+		TypeRecipe:     newNode(),
+		TypeIngredient: newNode(),
+	}
+
+	for kind, names := range map[string][]string{TypeRecipe: recipeNames, TypeIngredient: ingredientNames} {
+		counts := make(map[string]int)
+		display := make(map[string]string)
+		for _, name := range names {
+			key := normalize(name)
+			if key == "" {
+				continue
+			}
+			if _, exists := display[key]; !exists {
+				display[key] = strings.TrimSpace(name)
+			}
+			counts[key]++
+		}
+
+		for key, count := range counts {
+			entries = append(entries, entry{text: display[key], kind: kind, weight: count})
+			id := len(entries) - 1
+
+			// Indexer le texte complet puis chaque suffixe commençant par un mot,
+			// pour que "soup" propose "Cottage Cheese Tomato Soup"
+			words := strings.Fields(key)
+			for i := range words {
+				insert(tries[kind], strings.Join(words[i:], " "), hit{entry: id, start: i == 0})
+			}
+		}
+	}
+
+	idx.mu.Lock()
+	idx.entries = entries
+	idx.tries = tries
+	idx.mu.Unlock()
+}
+
+// insert ajoute un texte au trie
+func insert(root *node, text string, h hit) {
+	current := root
+	for _, r := range text {
+		child, exists := current.children[r]
+		if !exists {
+			child = newNode()
+			current.children[r] = child
+		}
+		current = child
+	}
+	current.hits = append(current.hits, h)
+}
+
+// Suggest retourne les meilleures suggestions pour la requête
+// kind: TypeRecipe, TypeIngredient ou "" pour les deux
+// Les correspondances de préfixe exactes passent avant les correspondances approchées
+func (idx *Index) Suggest(query, kind string, limit int) []Suggestion {
+	query = normalize(query)
+	if query == "" {
+		return []Suggestion{}
+	}
+	maxDistance := MaxDistance(query)
+
+	idx.mu.RLock()
+	defer idx.mu.RUnlock()
+
+	type candidate struct {
+		distance int
+		start    bool
+	}
+	best := make(map[int]candidate)
+
+	for trieKind, root := range idx.tries {
+		if kind != "" && kind != trieKind {
+			continue
+		}
+		searchFuzzy(root, []rune(query), maxDistance, func(h hit, distance int) {
+			current, seen := best[h.entry]
+			if !seen || distance < current.distance || (distance == current.distance && h.start && !current.start) {
+				best[h.entry] = candidate{distance: distance, start: h.start}
+			}
+		})
+	}
+
+	type ranked struct {
+		Suggestion
+		start bool
+	}
+	rankedSuggestions := make([]ranked, 0, len(best))
+	for id, cand := range best {
+		e := idx.entries[id]
+		rankedSuggestions = append(rankedSuggestions, ranked{
+			Suggestion: Suggestion{Text: e.text, Type: e.kind, Count: e.weight, Distance: cand.distance},
+			start:      cand.start,
+		})
+	}
+
+	sort.Slice(rankedSuggestions, func(i, j int) bool {
+		a, b := rankedSuggestions[i], rankedSuggestions[j]
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		if a.start != b.start {
+			return a.start
+		}
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		return a.Text < b.Text
+	})
+
+	if limit > 0 && len(rankedSuggestions) > limit {
+		rankedSuggestions = rankedSuggestions[:limit]
+	}
+	suggestions := make([]Suggestion, 0, len(rankedSuggestions))
+	for _, r := range rankedSuggestions {
+		suggestions = append(suggestions, r.Suggestion)
+	}
+	return suggestions
+}
+
+// searchFuzzy parcourt le trie en calculant la distance de Levenshtein entre la requête
+// et chaque préfixe indexé ; tous les textes sous un préfixe assez proche sont des candidats
+func searchFuzzy(root *node, query []rune, maxDistance int, emit func(h hit, distance int)) {
+	firstRow := make([]int, len(query)+1)
+	for i := range firstRow {
+		firstRow[i] = i
+	}
+
+	var walk func(n *node, previous []int)
+	walk = func(n *node, previous []int) {
+		for r, child := range n.children {
+			row := make([]int, len(query)+1)
+			row[0] = previous[0] + 1
+			rowMin := row[0]
+			for i := 1; i <= len(query); i++ {
+				cost := 1
+				if query[i-1] == r {
+					cost = 0
+				}
+				row[i] = min(row[i-1]+1, previous[i]+1, previous[i-1]+cost)
+				rowMin = min(rowMin, row[i])
+			}
+
+			if distance := row[len(query)]; distance <= maxDistance {
+				// Le préfixe courant correspond : tout le sous-arbre est candidat
+				collect(child, func(h hit) { emit(h, distance) })
+			}
+			if rowMin <= maxDistance {
+				walk(child, row)
+			}
+		}
+	}
+	walk(root, firstRow)
+}
+
+// collect parcourt tous les textes terminaux d'un sous-arbre
+func collect(n *node, emit func(h hit)) {
+	for _, h := range n.hits {
+		emit(h)
+	}
+	for _, child := range n.children {
+		collect(child, emit)
+	}
+}
+
+// MaxDistance retourne la tolérance aux fautes de frappe selon la longueur de la requête
+// (aucune faute sous 3 caractères, 1 jusqu'à 5, 2 au-delà)
+func MaxDistance(query string) int {
+	length := len([]rune(query))
+	switch {
+	case length < 3:
+		return 0
+	case length <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// normalize met en minuscules et réduit les espaces et la ponctuation
+func normalize(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '\'' && r != '-'
+	})
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,80 @@
+package suggest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testIndex() *Index {
+	idx := NewIndex()
+	idx.Build(
+		[]string{"Cottage Cheese Tomato Soup", "Tomato Basil Salad", "Chicken Noodle Soup", "Tomatillo Salsa"},
+		[]string{"tomato", "tomato", "tomato", "tomato paste", "cherry tomato", "thyme", "tofu"},
+	)
+	return idx
+}
+
+func TestSuggestPrefix(t *testing.T) {
+	results := testIndex().Suggest("tom", TypeIngredient, 10)
+	require.NotEmpty(t, results)
+
+	// "tomato" est utilisé 3 fois : il passe devant "tomato paste"
+	assert.Equal(t, "tomato", results[0].Text)
+	assert.Equal(t, 3, results[0].Count)
+	assert.Equal(t, 0, results[0].Distance)
+	assert.Equal(t, "tomato paste", results[1].Text)
+
+	// Les correspondances sur un mot interne viennent après celles en début de texte
+	assert.Equal(t, "cherry tomato", results[2].Text)
+}
+
+func TestSuggestRecipeWordPrefix(t *testing.T) {
+	results := testIndex().Suggest("soup", TypeRecipe, 10)
+
+	var texts []string
+	for _, s := range results {
+		texts = append(texts, s.Text)
+		assert.Equal(t, TypeRecipe, s.Type)
+	}
+	assert.ElementsMatch(t, []string{"Cottage Cheese Tomato Soup", "Chicken Noodle Soup"}, texts)
+}
+
+func TestSuggestTypoTolerance(t *testing.T) {
+	idx := testIndex()
+
+	// Une faute de frappe sur une requête courte
+	results := idx.Suggest("tomoto", TypeIngredient, 10)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "tomato", results[0].Text)
+	assert.Equal(t, 1, results[0].Distance)
+
+	// Deux fautes sur une requête longue
+	results = idx.Suggest("chikcen nood", TypeRecipe, 10)
+	require.NotEmpty(t, results)
+	assert.Equal(t, "Chicken Noodle Soup", results[0].Text)
+}
+
+func TestSuggestLimitAndBothTypes(t *testing.T) {
+	idx := testIndex()
+
+	results := idx.Suggest("to", "", 0)
+	kinds := map[string]bool{}
+	for _, s := range results {
+		kinds[s.Type] = true
+		assert.Equal(t, 0, s.Distance) // pas de tolérance sous 3 caractères
+	}
+	assert.True(t, kinds[TypeRecipe])
+	assert.True(t, kinds[TypeIngredient])
+
+	assert.Len(t, idx.Suggest("to", "", 2), 2)
+	assert.Empty(t, idx.Suggest("  ", "", 10))
+}
+
+func TestMaxDistance(t *testing.T) {
+	assert.Equal(t, 0, MaxDistance("to"))
+	assert.Equal(t, 1, MaxDistance("tom"))
+	assert.Equal(t, 1, MaxDistance("tomat"))
+	assert.Equal(t, 2, MaxDistance("tomato"))
+}
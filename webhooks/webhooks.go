@@ -0,0 +1,154 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/models"
+)
+
+// En-têtes ajoutés à chaque envoi
+const (
+	SignatureHeader = "X-Webhook-Signature" // "sha256=" + HMAC-SHA256(secret, "<timestamp>.<corps>")
+	TimestampHeader = "X-Webhook-Timestamp" // Horodatage Unix signé avec le corps (protection contre le rejeu)
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// ErrRejected est retournée quand le destinataire refuse définitivement l'événement (4xx)
+var ErrRejected = errors.New("webhook refusé par le destinataire")
+
+// Message événement à envoyer
+type Message struct {
+	DeliveryID string
+	EventType  string
+	Body       []byte
+}
+
+// Sender envoie les événements avec reprise à intervalle exponentiel
+type Sender struct {
+	Client      *http.Client
+	MaxAttempts int
+	BaseDelay   time.Duration // Délai avant la deuxième tentative, doublé ensuite
+	MaxDelay    time.Duration
+}
+
+// NewSender retourne un Sender avec les réglages par défaut (6 tentatives sur environ 5 minutes)
+func NewSender() *Sender {
+	return &Sender{
+		Client:      &http.Client{Timeout: 10 * time.Second},
+		MaxAttempts: 6,
+		BaseDelay:   10 * time.Second,
+		MaxDelay:    2 * time.Minute,
+	}
+}
+
+// Backoff retourne le délai d'attente après la tentative n (à partir de 1)
+func (s *Sender) Backoff(n int) time.Duration {
+	delay := s.BaseDelay
+	for i := 1; i < n && delay < s.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.MaxDelay {
+		delay = s.MaxDelay
+	}
+	return delay
+}
+
+// Send envoie le message jusqu'à obtenir une réponse 2xx ou épuiser les tentatives
+// Les réponses 4xx (hors 408 et 429) sont définitives et ne sont pas retentées
+// onAttempt est appelé après chaque tentative pour alimenter le journal de livraison
+func (s *Sender) Send(ctx context.Context, url, secret string, msg Message, onAttempt func(models.WebhookAttempt)) error {
+	var lastErr error
+	for n := 1; n <= s.MaxAttempts; n++ {
+		attempt, retry, err := s.attempt(ctx, n, url, secret, msg)
+		if onAttempt != nil {
+			onAttempt(attempt)
+		}
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+		if !retry || n == s.MaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(s.Backoff(n)):
+		}
+	}
+	return lastErr
+}
+
+// attempt effectue une tentative et indique si un échec mérite d'être retenté
+func (s *Sender) attempt(ctx context.Context, n int, url, secret string, msg Message) (models.WebhookAttempt, bool, error) {
+	start := time.Now()
+	attempt := models.WebhookAttempt{Number: n, At: start.UTC()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(msg.Body))
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt, false, err
+	}
+	timestamp := start.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "go-api-mongo-scrapper-webhooks")
+	req.Header.Set(EventHeader, msg.EventType)
+	req.Header.Set(DeliveryHeader, msg.DeliveryID)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(secret, timestamp, msg.Body))
+
+	resp, err := s.Client.Do(req)
+	attempt.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		attempt.Error = err.Error()
+		return attempt, true, err
+	}
+	resp.Body.Close()
+	attempt.StatusCode = resp.StatusCode
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return attempt, false, nil
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		attempt.Error = fmt.Sprintf("%v (HTTP %d)", ErrRejected, resp.StatusCode)
+		return attempt, false, fmt.Errorf("%w (HTTP %d)", ErrRejected, resp.StatusCode)
+	default:
+		err := fmt.Errorf("réponse HTTP %d", resp.StatusCode)
+		attempt.Error = err.Error()
+		return attempt, true, err
+	}
+}
+
+// Sign calcule la signature d'un corps pour un horodatage donné
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify vérifie une signature (à utiliser côté destinataire)
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// GenerateSecret génère une clé de signature aléatoire
+func GenerateSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(bytes), nil
+}
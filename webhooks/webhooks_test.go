@@ -0,0 +1,113 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/maxime-louis14/api-golang/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSender() *Sender {
+	return &Sender{
+		Client:      &http.Client{Timeout: time.Second},
+		MaxAttempts: 4,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    4 * time.Millisecond,
+	}
+}
+
+func TestSendSigned(t *testing.T) {
+	body := []byte(`{"type":"import.completed"}`)
+	var received atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		timestamp, err := strconv.ParseInt(r.Header.Get(TimestampHeader), 10, 64)
+		require.NoError(t, err)
+
+		assert.Equal(t, body, payload)
+		assert.Equal(t, "import.completed", r.Header.Get(EventHeader))
+		assert.Equal(t, "d1", r.Header.Get(DeliveryHeader))
+		assert.True(t, Verify("secret", timestamp, payload, r.Header.Get(SignatureHeader)))
+		assert.False(t, Verify("other", timestamp, payload, r.Header.Get(SignatureHeader)))
+		received.Store(true)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var attempts []models.WebhookAttempt
+	err := testSender().Send(context.Background(), server.URL, "secret", Message{DeliveryID: "d1", EventType: "import.completed", Body: body}, func(a models.WebhookAttempt) {
+		attempts = append(attempts, a)
+	})
+
+	require.NoError(t, err)
+	assert.True(t, received.Load())
+	require.Len(t, attempts, 1)
+	assert.Equal(t, http.StatusNoContent, attempts[0].StatusCode)
+}
+
+func TestSendRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	var attempts []models.WebhookAttempt
+	err := testSender().Send(context.Background(), server.URL, "secret", Message{Body: []byte("{}")}, func(a models.WebhookAttempt) {
+		attempts = append(attempts, a)
+	})
+
+	require.NoError(t, err)
+	require.Len(t, attempts, 3)
+	assert.Equal(t, http.StatusServiceUnavailable, attempts[0].StatusCode)
+	assert.NotEmpty(t, attempts[0].Error)
+	assert.Equal(t, 3, attempts[2].Number)
+	assert.Equal(t, http.StatusOK, attempts[2].StatusCode)
+}
+
+func TestSendGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	err := testSender().Send(context.Background(), server.URL, "secret", Message{Body: []byte("{}")}, nil)
+	assert.Error(t, err)
+	assert.Equal(t, int32(4), calls.Load())
+
+	// Un refus 4xx est définitif
+	calls.Store(0)
+	rejecting := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusGone)
+	}))
+	defer rejecting.Close()
+
+	err = testSender().Send(context.Background(), rejecting.URL, "secret", Message{Body: []byte("{}")}, nil)
+	assert.True(t, errors.Is(err, ErrRejected))
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestBackoff(t *testing.T) {
+	s := &Sender{BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+	assert.Equal(t, time.Second, s.Backoff(1))
+	assert.Equal(t, 2*time.Second, s.Backoff(2))
+	assert.Equal(t, 8*time.Second, s.Backoff(4))
+	assert.Equal(t, 10*time.Second, s.Backoff(5))
+	assert.Equal(t, 10*time.Second, s.Backoff(50))
+}